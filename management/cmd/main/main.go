@@ -56,16 +56,34 @@ func main() {
 		os.Exit(1)
 	}
 
+	schemaVersion, err := migrator.LatestVersion(migrationsPath)
+	if err != nil {
+		slog.Error("failed to read migrations", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
 	slog.Info("repository pool stats",
 		slog.Int("total_conns", int(db.Stats().TotalConns())),
 		slog.Int("idle_conns", int(db.Stats().IdleConns())),
 	)
 
-	application := app.New(cfg, db)
+	application := app.New(cfg, db, schemaVersion)
 	go application.GRPCSrv.MustRun()
 
 	slog.Info("GRPC server is running on port", slog.Int("port", cfg.GRPC.Port))
 
+	if application.HTTPSrv != nil {
+		go application.HTTPSrv.MustRun()
+
+		slog.Info("HTTP server with metrics and health checks is running on port", slog.Int("port", cfg.HTTP.Port))
+	}
+
+	if application.GatewaySrv != nil {
+		go application.GatewaySrv.MustRun()
+
+		slog.Info("REST gateway is running on port", slog.Int("port", cfg.Gateway.Port))
+	}
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -76,7 +94,10 @@ func main() {
 
 	slog.Info("shutting down gracefully...")
 
-	db.Close()
+	if !application.Stop() {
+		slog.Error("application stopped with unfinished components")
+		os.Exit(1)
+	}
 
 	slog.Info("application stopped")
 }
@@ -2,24 +2,71 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"strings"
 	"time"
 
 	grpcapp "github.com/makhtech/management/internal/app/gprc"
+	httpapp "github.com/makhtech/management/internal/app/http"
+	"github.com/makhtech/management/internal/billing"
+	"github.com/makhtech/management/internal/clients/proxmox"
 	"github.com/makhtech/management/internal/clients/sso"
 	"github.com/makhtech/management/internal/config"
+	"github.com/makhtech/management/internal/expiry"
+	"github.com/makhtech/management/internal/gateway"
+	grpcInt "github.com/makhtech/management/internal/grpc"
+	"github.com/makhtech/management/internal/health"
+	"github.com/makhtech/management/internal/jwtverify"
+	"github.com/makhtech/management/internal/metrics"
+	"github.com/makhtech/management/internal/outbox"
+	"github.com/makhtech/management/internal/placement"
 	"github.com/makhtech/management/internal/repository/postgres"
+	auditService "github.com/makhtech/management/internal/service/audit"
+	ipPoolService "github.com/makhtech/management/internal/service/ippool"
+	nodeService "github.com/makhtech/management/internal/service/node"
 	planService "github.com/makhtech/management/internal/service/plan"
+	taskService "github.com/makhtech/management/internal/service/task"
+	vdsService "github.com/makhtech/management/internal/service/vds"
+	webhookService "github.com/makhtech/management/internal/service/webhook"
+	"github.com/makhtech/management/internal/watch"
+	"github.com/makhtech/management/internal/webhook"
+	"github.com/makhtech/management/internal/worker"
 	"github.com/makhtech/management/pkg/ratelimiter"
+	managementv1 "github.com/makhtech/proto/gen/go/management"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// migrationsTable таблица golang-migrate, в которой хранится версия схемы
+const migrationsTable = "migrations"
+
 type App struct {
 	GRPCSrv     *grpcapp.App
+	HTTPSrv     *httpapp.App
+	GatewaySrv  *httpapp.App
+	Health      *health.Checker
 	SSOClient   *sso.Client
 	RateLimiter *ratelimiter.TokenBucket
+	Worker      *worker.Pool
+	Billing     *billing.Billing
+	Expiry      *expiry.Scheduler
+	Outbox      *outbox.Relay
+	Webhooks    *webhook.Dispatcher
+	Idempotency *grpcInt.IdempotencyInterceptor
+	Watch       *watch.Hub
+
+	gateway         *gateway.Gateway
+	stopFakeProxmox func()
+	db              *postgres.Database
+	drainDelay      time.Duration
+	shutdownTimeout time.Duration
 }
 
-func New(cfg *config.Config, db *postgres.Database) *App {
+// New собирает приложение. schemaVersion - версия последней миграции,
+// без которой сервис не считается готовым. Пул соединений db закрывается в Stop
+func New(cfg *config.Config, db *postgres.Database, schemaVersion uint) *App {
 	// Создаём Rate Limiter
 	rateLimiterCfg := ratelimiter.Config{
 		Rate:            cfg.RateLimiter.GetRate(),
@@ -49,30 +96,323 @@ func New(cfg *config.Config, db *postgres.Database) *App {
 		ssoClient = nil
 	}
 
+	// Локальная проверка токенов по открытым ключам SSO: основной способ в режиме local
+	// и запасной, когда SSO недоступен
+	var verifier *jwtverify.Verifier
+	if cfg.Auth.HasKeys() {
+		verifier, err = jwtverify.New(context.Background(), cfg.Auth.ToVerifierConfig(), slog.Default())
+		if err != nil {
+			if cfg.Auth.GetMode() == string(grpcInt.AuthModeLocal) {
+				panic(fmt.Sprintf("failed to load SSO public keys: %s", err))
+			}
+			slog.Warn("failed to load SSO public keys, local token verification disabled",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	switch cfg.Auth.GetMode() {
+	case string(grpcInt.AuthModeSSO):
+	case string(grpcInt.AuthModeLocal):
+		if verifier == nil {
+			panic("auth mode local requires public_key_file, jwks_file or jwks_url")
+		}
+	default:
+		panic(fmt.Sprintf("invalid auth mode %q (available: sso, local)", cfg.Auth.Mode))
+	}
+
 	// Создаём репозитории
 	planRepo := postgres.NewPlanRepository(db)
+	nodeRepo := postgres.NewNodeRepository(db)
+	// Шаблон клонирования занимает свой VMID в кластере
+	vdsRepo := postgres.NewVDSRepository(db, cfg.Proxmox.GetTemplateID())
+	taskRepo := postgres.NewTaskRepository(db)
+	ipPoolRepo := postgres.NewIPPoolRepository(db)
+	auditRepo := postgres.NewAuditRepository(db)
+	idempotencyRepo := postgres.NewIdempotencyRepository(db)
+	outboxRepo := postgres.NewOutboxRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db)
+
+	// Создаём планировщик размещения VDS по нодам
+	strategy, err := placement.ByName(cfg.Placement.GetStrategy())
+	if err != nil {
+		panic(fmt.Sprintf("invalid placement config: %s (available: %s)", err, strings.Join(placement.Names(), ", ")))
+	}
+	scheduler := placement.New(nodeRepo, strategy, slog.Default())
+
+	slog.Info("vds placement initialized", slog.String("strategy", strategy.Name()))
+
+	// Оплата VDS работает через SSO. Без SSO биллинг отвечает ErrUnavailable,
+	// и создание платных VDS отклоняется, а не становится бесплатным
+	var bill *billing.Billing
+	if cfg.Billing.Enabled {
+		autoRenewKey, err := cfg.Billing.GetAutoRenewKey()
+		if err != nil {
+			panic(fmt.Sprintf("invalid billing config: %s", err))
+		}
+		bill = billing.New(ssoClient, vdsRepo, billing.Config{
+			AppID:             cfg.Billing.AppID,
+			ServiceToken:      cfg.Billing.ServiceToken,
+			ReconcileInterval: cfg.Billing.GetReconcileInterval(),
+			AutoRenewKey:      autoRenewKey,
+		}, slog.Default())
+		if ssoClient == nil {
+			slog.Warn("billing is unavailable: SSO client is not connected, paid vds cannot be created")
+		}
+	}
 
 	// Создаём сервисы
 	planSvc := planService.New(planRepo, slog.Default())
+	nodeSvc := nodeService.New(nodeRepo, slog.Default())
+	vdsSvc := vdsService.New(vdsRepo, planRepo, nodeRepo, scheduler, bill, slog.Default())
+	taskSvc := taskService.New(taskRepo, slog.Default())
+	ipPoolSvc := ipPoolService.New(ipPoolRepo, slog.Default())
+	auditSvc := auditService.New(auditRepo, slog.Default())
+	webhookSvc := webhookService.New(webhookRepo, slog.Default())
+
+	// Метрики собираются всегда, HTTP сервер с /metrics поднимается только при заданном порте
+	registry := metrics.NewRegistry()
+	grpcMetrics := grpcInt.NewMetrics(registry)
+
+	// Готовность сервиса: Postgres и схема обязательны, SSO - только если токены
+	// нечем проверить локально
+	checker := health.New(health.Config{
+		Interval: cfg.Health.GetCheckInterval(),
+		Timeout:  cfg.Health.GetCheckTimeout(),
+	}, slog.Default(), managementv1.Management_ServiceDesc.ServiceName)
+	checker.Add("postgres", db.HealthCheck, true)
+	checker.Add("migrations", migrationsCheck(db, schemaVersion), true)
+	checker.Add("sso", ssoCheck(ssoClient), verifier == nil)
+
+	// Ключи идемпотентности изменяющих вызовов
+	idempotency := grpcInt.NewIdempotencyInterceptor(idempotencyRepo, grpcInt.IdempotencyConfig{
+		TTL:             cfg.Idempotency.GetTTL(),
+		LockTimeout:     cfg.Idempotency.GetLockTimeout(),
+		CleanupInterval: cfg.Idempotency.GetCleanupInterval(),
+	}, slog.Default())
+	idempotency.Start()
+
+	// Изменения статусов задач и VDS для WatchTask / WatchVDS приходят через LISTEN/NOTIFY
+	watchHub := watch.New(db, watch.Config{}, slog.Default())
+	watchHub.Start()
 
 	// Создаём gRPC App с SSO клиентом, Rate Limiter и сервисами
-	grpcApp := grpcapp.New(cfg, ssoClient, rl, planSvc)
+	grpcApp := grpcapp.New(cfg, ssoClient, verifier, rl, planSvc, nodeSvc, vdsSvc, taskSvc, ipPoolSvc, auditSvc, webhookSvc, idempotency, watchHub, grpcMetrics, checker)
+
+	registry.MustRegister(metrics.NewCollector(metrics.Sources{
+		DB:          db,
+		RateLimiter: rl,
+		TaskRepo:    taskRepo,
+		NodeRepo:    nodeRepo,
+		OutboxRepo:  outboxRepo,
+		AuthCache:   grpcApp.AuthCacheStats,
+	}, slog.Default()))
+
+	var httpApp *httpapp.App
+	if cfg.HTTP.Port > 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}))
+		mux.Handle("/healthz", checker.LivenessHandler())
+		mux.Handle("/readyz", checker.ReadinessHandler())
+		httpApp = httpapp.New(cfg.HTTP.Port, mux)
+	}
+
+	// Создаём клиентов Proxmox. В режиме fake все ноды обслуживает встроенный fake сервер,
+	// он есть только в сборке с тегом fakeproxmox
+	proxmoxCfg := cfg.Proxmox.ToProxmoxClientConfig()
+	var stopFakeProxmox func()
+	if cfg.Proxmox.Fake {
+		proxmoxCfg.Endpoint, stopFakeProxmox = startFakeProxmox(proxmoxCfg, cfg.Proxmox.GetTemplateID())
+
+		slog.Warn("using fake proxmox api", slog.String("endpoint", proxmoxCfg.Endpoint))
+	}
+	proxmoxPool := proxmox.NewPool(proxmoxCfg)
+
+	// Запускаем пул воркеров, выполняющих задачи из очереди
+	workerPool := worker.New(taskRepo, worker.Config{
+		Concurrency:  cfg.Worker.GetConcurrency(),
+		PollInterval: cfg.Worker.GetPollInterval(),
+		TaskTimeout:  cfg.Worker.GetTaskTimeout(),
+	}, slog.Default())
+	worker.NewVDSHandlers(vdsRepo, nodeRepo, planRepo, proxmoxPool, worker.VDSConfig{
+		TemplateID:       cfg.Proxmox.GetTemplateID(),
+		Storage:          cfg.Proxmox.Storage,
+		Disk:             cfg.Proxmox.Disk,
+		TaskPollInterval: cfg.Proxmox.GetTaskPollInterval(),
+	}, slog.Default()).Register(workerPool)
+	if bill != nil {
+		workerPool.OnComplete(bill.OnTaskComplete)
+		bill.Start()
+	}
+	workerPool.Start()
+
+	// Планировщик подписок продлевает VDS с включённым автопродлением, останавливает и удаляет неоплаченные
+	var expiryScheduler *expiry.Scheduler
+	if cfg.Expiry.Enabled {
+		expiryScheduler = expiry.New(vdsRepo, vdsSvc, expiry.Config{
+			Interval:    cfg.Expiry.GetInterval(),
+			RenewBefore: cfg.Expiry.GetRenewBefore(),
+			RetryAfter:  cfg.Expiry.GetRetryAfter(),
+			GracePeriod: cfg.Expiry.GetGracePeriod(),
+			Retention:   cfg.Expiry.GetRetention(),
+			BatchSize:   cfg.Expiry.GetBatchSize(),
+		}, slog.Default())
+		expiryScheduler.Start()
+	}
+
+	// Доменные события пишутся в outbox триггерами БД всегда, relay только публикует их
+	var relay *outbox.Relay
+	if cfg.Outbox.Enabled {
+		sink, err := outbox.NewSink(outbox.SinkConfig{
+			Type:    cfg.Outbox.GetSink(),
+			Path:    cfg.Outbox.FilePath,
+			URL:     cfg.Outbox.WebhookURL,
+			Timeout: cfg.Outbox.GetWebhookTimeout(),
+		})
+		if err != nil {
+			panic(fmt.Sprintf("invalid outbox config: %s", err))
+		}
+		// Доставки подписок создаются до публикации во внешний sink: при его ошибке
+		// событие уйдёт повторно, а повторные доставки не создадутся
+		if cfg.Webhooks.Enabled {
+			sink = outbox.NewMultiSink(webhook.NewFanoutSink(webhookRepo, slog.Default()), sink)
+		}
+		relay = outbox.New(outboxRepo, sink, outbox.Config{
+			Interval:   cfg.Outbox.GetInterval(),
+			BatchSize:  cfg.Outbox.GetBatchSize(),
+			MaxBackoff: cfg.Outbox.GetMaxBackoff(),
+			Retention:  cfg.Outbox.GetRetention(),
+		}, slog.Default())
+		relay.Start()
+	} else {
+		slog.Warn("outbox relay is disabled, domain events accumulate in the outbox table")
+	}
+
+	// Подписки на события: доставки создаёт relay, отправляет dispatcher
+	var dispatcher *webhook.Dispatcher
+	if cfg.Webhooks.Enabled {
+		if !cfg.Outbox.Enabled {
+			slog.Warn("webhooks are enabled without outbox relay, new deliveries will not be created")
+		}
+		dispatcher = webhook.New(webhookRepo, webhook.Config{
+			Interval:    cfg.Webhooks.GetInterval(),
+			BatchSize:   cfg.Webhooks.GetBatchSize(),
+			Concurrency: cfg.Webhooks.GetConcurrency(),
+			Timeout:     cfg.Webhooks.GetTimeout(),
+			MaxAttempts: cfg.Webhooks.GetMaxAttempts(),
+			BaseBackoff: cfg.Webhooks.GetBaseBackoff(),
+			MaxBackoff:  cfg.Webhooks.GetMaxBackoff(),
+			Retention:   cfg.Webhooks.GetRetention(),
+		}, slog.Default())
+		dispatcher.Start()
+	}
+
+	// REST/JSON gateway проксирует запросы на gRPC порт и проходит через те же interceptors
+	var gw *gateway.Gateway
+	var gatewayApp *httpapp.App
+	if cfg.Gateway.Port > 0 {
+		gw, err = gateway.New(context.Background(), fmt.Sprintf("localhost:%d", cfg.GRPC.Port), slog.Default())
+		if err != nil {
+			panic(fmt.Sprintf("failed to create gateway: %s", err))
+		}
+		gatewayApp = httpapp.New(cfg.Gateway.Port, gw.Handler())
+	}
+
+	checker.Start()
 
 	return &App{
 		GRPCSrv:     grpcApp,
+		HTTPSrv:     httpApp,
+		GatewaySrv:  gatewayApp,
+		Health:      checker,
 		SSOClient:   ssoClient,
 		RateLimiter: rl,
+		Worker:      workerPool,
+		Billing:     bill,
+		Expiry:      expiryScheduler,
+		Outbox:      relay,
+		Webhooks:    dispatcher,
+		Idempotency: idempotency,
+		Watch:       watchHub,
+
+		gateway:         gw,
+		stopFakeProxmox: stopFakeProxmox,
+		db:              db,
+		drainDelay:      cfg.Health.GetDrainDelay(),
+		shutdownTimeout: cfg.Shutdown.GetTimeout(),
 	}
 }
 
-// Stop останавливает все компоненты приложения
-func (a *App) Stop() {
-	if a.SSOClient != nil {
+// Stop останавливает все компоненты приложения в порядке зависимостей в пределах
+// shutdown.timeout. Возвращает false, если какой-то компонент не остановился вовремя
+func (a *App) Stop() bool {
+	c := newShutdownCoordinator(a.shutdownTimeout, slog.Default())
+
+	// Сначала сообщаем балансировщикам, что сервис уходит, и даём им снять трафик
+	c.addFunc("health", a.Health.Shutdown)
+	c.add("drain", func(ctx context.Context) {
+		if a.drainDelay <= 0 {
+			return
+		}
+		slog.Info("draining traffic before shutdown", slog.Duration("delay", a.drainDelay))
+		select {
+		case <-time.After(a.drainDelay):
+		case <-ctx.Done():
+		}
+	})
+
+	// Потоки WatchTask / WatchVDS бесконечны: закрываем их до остановки серверов,
+	// иначе graceful stop ждал бы их до таймаута
+	c.addFunc("watch", a.Watch.Stop)
+
+	// Новые запросы не принимаются, активные дорабатывают. Gateway останавливается
+	// раньше gRPC, потому что проксирует запросы в него
+	if a.GatewaySrv != nil {
+		c.add("gateway", a.GatewaySrv.Stop)
+		c.addFunc("gateway conn", func() {
+			if err := a.gateway.Close(); err != nil {
+				slog.Warn("failed to close gateway connection", slog.String("error", err.Error()))
+			}
+		})
+	}
+	c.add("grpc", a.GRPCSrv.Stop)
+	if a.HTTPSrv != nil {
+		c.add("http", a.HTTPSrv.Stop)
+	}
+
+	// Фоновые компоненты: планировщик ставит задачи, поэтому останавливается раньше воркеров.
+	// Итоги задач, завершённых при остановке, отправляет биллинг
+	if a.Expiry != nil {
+		c.addFunc("expiry", a.Expiry.Stop)
+	}
+	c.add("worker", a.Worker.Stop)
+	if a.Billing != nil {
+		c.addFunc("billing", a.Billing.Stop)
+	}
+	if a.Outbox != nil {
+		c.addFunc("outbox", a.Outbox.Stop)
+	}
+	if a.Webhooks != nil {
+		c.addFunc("webhooks", a.Webhooks.Stop)
+	}
+	c.addFunc("idempotency", a.Idempotency.Stop)
+	c.addFunc("health checker", a.Health.Stop)
+	c.addFunc("rate limiter", a.RateLimiter.Stop)
+
+	// Внешние соединения закрываются последними, когда их больше никто не использует
+	if a.stopFakeProxmox != nil {
+		c.addFunc("fake proxmox", a.stopFakeProxmox)
+	}
+	c.addFunc("sso", func() {
+		if a.SSOClient == nil {
+			return
+		}
 		if err := a.SSOClient.Close(); err != nil {
 			slog.Warn("failed to close SSO client", slog.String("error", err.Error()))
 		}
-	}
-	a.GRPCSrv.Stop()
+	})
+	c.addFunc("postgres", a.db.Close)
+
+	return c.run()
 }
 
 // MustConnectSSO пытается подключиться к SSO сервису с ретраями
@@ -106,3 +446,30 @@ func (a *App) MustConnectSSO(cfg *config.Config, maxRetries int) {
 
 	slog.Error("failed to connect to SSO service after all retries")
 }
+
+// migrationsCheck проверяет, что схема БД не отстаёт от миграций сервиса и не осталась в dirty состоянии
+func migrationsCheck(db *postgres.Database, expected uint) health.CheckFunc {
+	return func(ctx context.Context) error {
+		version, dirty, err := db.MigrationVersion(ctx, migrationsTable)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("migration %d is dirty", version)
+		}
+		if version < expected {
+			return fmt.Errorf("schema version %d is behind %d", version, expected)
+		}
+		return nil
+	}
+}
+
+// ssoCheck проверяет соединение с SSO
+func ssoCheck(client *sso.Client) health.CheckFunc {
+	return func(ctx context.Context) error {
+		if client == nil {
+			return errors.New("sso client is not connected")
+		}
+		return client.HealthCheck(ctx)
+	}
+}
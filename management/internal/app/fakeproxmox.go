@@ -0,0 +1,16 @@
+//go:build fakeproxmox
+
+package app
+
+import (
+	"github.com/makhtech/management/internal/clients/proxmox"
+	"github.com/makhtech/management/internal/clients/proxmox/proxmoxtest"
+)
+
+// startFakeProxmox запускает встроенный fake Proxmox API с шаблоном templateID.
+// Возвращает адрес API и функцию остановки сервера
+func startFakeProxmox(cfg proxmox.Config, templateID int32) (string, func()) {
+	srv := proxmoxtest.NewServer(cfg.TokenID, cfg.TokenSecret)
+	srv.AddTemplate(templateID)
+	return srv.APIURL(), srv.Close
+}
@@ -0,0 +1,12 @@
+//go:build !fakeproxmox
+
+package app
+
+import (
+	"github.com/makhtech/management/internal/clients/proxmox"
+)
+
+// startFakeProxmox в обычной сборке недоступен: fake Proxmox API не попадает в рабочий бинарник
+func startFakeProxmox(proxmox.Config, int32) (string, func()) {
+	panic("proxmox.fake requires a build with -tags fakeproxmox")
+}
@@ -9,10 +9,15 @@ import (
 	"github.com/makhtech/management/internal/clients/sso"
 	"github.com/makhtech/management/internal/config"
 	grpcInt "github.com/makhtech/management/internal/grpc"
+	"github.com/makhtech/management/internal/health"
+	"github.com/makhtech/management/internal/jwtverify"
 	"github.com/makhtech/management/internal/service"
+	"github.com/makhtech/management/internal/watch"
 	"github.com/makhtech/management/pkg/ratelimiter"
+	"github.com/makhtech/management/pkg/ttlcache"
 	managementv1 "github.com/makhtech/proto/gen/go/management"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -50,35 +55,93 @@ func chainStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) grpc.
 	}
 }
 
-func New(cfg *config.Config, ssoClient *sso.Client, rateLimiter *ratelimiter.TokenBucket, planSvc service.PlanService) *App {
-	var opts []grpc.ServerOption
-	var authInterceptor *grpcInt.AuthInterceptor
+func New(
+	cfg *config.Config,
+	ssoClient *sso.Client,
+	verifier *jwtverify.Verifier,
+	rateLimiter *ratelimiter.TokenBucket,
+	planSvc service.PlanService,
+	nodeSvc service.NodeService,
+	vdsSvc service.VDSService,
+	taskSvc service.TaskService,
+	poolSvc service.IPPoolService,
+	auditSvc service.AuditService,
+	webhookSvc service.WebhookService,
+	idempotency *grpcInt.IdempotencyInterceptor,
+	watchHub *watch.Hub,
+	metrics *grpcInt.Metrics,
+	checker *health.Checker,
+) *App {
+	// Аутентификация включена всегда. Без SSO токены проверяются локально по открытым ключам,
+	// а если и их нет - непубличные методы отклоняются
+	authInterceptor := grpcInt.NewAuthInterceptor(ssoClient, rateLimiter)
+	if verifier != nil {
+		authInterceptor.SetVerifier(verifier, grpcInt.AuthMode(cfg.Auth.GetMode()))
+	}
 
-	if ssoClient != nil {
-		authInterceptor = grpcInt.NewAuthInterceptor(ssoClient, rateLimiter)
+	switch {
+	case ssoClient == nil && verifier == nil:
+		slog.Error("no token verifier available: SSO is not connected and no public keys are configured, " +
+			"all protected methods will be rejected")
+	case cfg.Auth.GetMode() == string(grpcInt.AuthModeLocal) || ssoClient == nil:
+		slog.Warn("tokens are verified locally with SSO public keys")
+	case verifier != nil:
+		slog.Info("tokens are verified by SSO with local fallback")
+	}
 
-		authInterceptor.SetPublicMethods(
-			"/management.Management/ListPlans",
-			"/management.Management/GetPlan",
+	// Политика доступа: какие роли могут вызывать каждый метод
+	policy := grpcInt.DefaultPolicy()
+	authInterceptor.SetPublicMethods(policy.PublicMethods()...)
+	policyInterceptor := grpcInt.NewPolicyInterceptor(policy)
+
+	// Кэш проверенных токенов, чтобы не ходить в SSO на каждый запрос. SSO не сообщает о logout,
+	// поэтому вышедший из системы токен принимается, пока не истечёт его запись в кэше
+	if ttl := cfg.SSO.GetTokenCacheTTL(); ttl > 0 {
+		authInterceptor.EnableCache(cfg.SSO.GetTokenCacheSize(), ttl)
+		slog.Info("auth token cache enabled",
+			slog.Int("size", cfg.SSO.GetTokenCacheSize()),
+			slog.Duration("ttl", ttl),
 		)
+	}
 
-		opts = append(opts,
-			grpc.UnaryInterceptor(authInterceptor.UnaryInterceptor()),
-			grpc.StreamInterceptor(authInterceptor.StreamInterceptor()),
-		)
+	// Метрики идут первыми, чтобы учитывать и запросы, отклонённые аутентификацией
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	if metrics != nil {
+		unary = append(unary, metrics.UnaryInterceptor())
+		stream = append(stream, metrics.StreamInterceptor())
+	}
+	// Аудит после аутентификации, чтобы знать автора, но до политики, чтобы попадали и отказы
+	serverAPI := grpcInt.NewServerAPI(planSvc, nodeSvc, vdsSvc, taskSvc, poolSvc, auditSvc, webhookSvc, watchHub)
+	auditInterceptor := grpcInt.NewAuditInterceptor(auditSvc, serverAPI, slog.Default())
+
+	unary = append(unary, authInterceptor.UnaryInterceptor())
+	// Повтор по ключу идемпотентности отдаёт сохранённый ответ и в журнал аудита не попадает
+	if idempotency != nil {
+		unary = append(unary, idempotency.UnaryInterceptor())
+	}
+	unary = append(unary,
+		auditInterceptor.UnaryInterceptor(),
+		policyInterceptor.UnaryInterceptor(),
+	)
+	stream = append(stream, authInterceptor.StreamInterceptor(), policyInterceptor.StreamInterceptor())
 
-		slog.Info("auth interceptor enabled with rate limiting")
-	} else {
-		slog.Warn("auth interceptor disabled - SSO client not available")
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(chainUnaryInterceptors(unary...)),
+		grpc.StreamInterceptor(chainStreamInterceptors(stream...)),
 	}
 
+	slog.Info("auth interceptor enabled with rate limiting and role policy")
+
 	gRPCServer := grpc.NewServer(opts...)
 
+	// Стандартный grpc.health.v1 для балансировщиков и оркестратора
+	healthpb.RegisterHealthServer(gRPCServer, checker.GRPCServer())
+
 	// Включаем серверную рефлексию (полезно для отладки)
 	reflection.Register(gRPCServer)
 
 	// Регистрируем сервисы
-	serverAPI := grpcInt.NewServerAPI(planSvc)
 	managementv1.RegisterManagementServer(gRPCServer, serverAPI)
 
 	slog.Info("management service registered")
@@ -90,6 +153,17 @@ func New(cfg *config.Config, ssoClient *sso.Client, rateLimiter *ratelimiter.Tok
 	}
 }
 
+// EvictToken удаляет токен из кэша аутентификации. Сейчас его никто не вызывает: SSO не публикует
+// событие logout, и отзыв токена ограничен TTL кэша (sso.token_cache_ttl). Хук для такого события
+func (a *App) EvictToken(accessToken string) {
+	a.authInterceptor.EvictToken(accessToken)
+}
+
+// AuthCacheStats возвращает счётчики кэша аутентификации. ok=false - кэш выключен
+func (a *App) AuthCacheStats() (stats ttlcache.Stats, ok bool) {
+	return a.authInterceptor.CacheStats()
+}
+
 func (a *App) MustRun() {
 	if err := a.run(); err != nil {
 		panic(err)
@@ -118,12 +192,27 @@ func (a *App) run() error {
 	return nil
 }
 
-func (a *App) Stop() {
+// Stop дожидается завершения активных запросов. Если ctx истекает раньше,
+// оставшиеся соединения закрываются принудительно
+func (a *App) Stop(ctx context.Context) {
 	const op = "grpcapp.Stop"
 
-	slog.With(
+	log := slog.With(
 		slog.String("op", op),
-	).Info("stopping gRPC server", slog.Int("port", a.port))
-
-	a.gRPCServer.GracefulStop()
+	)
+	log.Info("stopping gRPC server", slog.Int("port", a.port))
+
+	done := make(chan struct{})
+	go func() {
+		a.gRPCServer.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Warn("graceful stop timed out, closing remaining connections")
+		a.gRPCServer.Stop()
+		<-done
+	}
 }
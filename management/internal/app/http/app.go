@@ -0,0 +1,67 @@
+package httpapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// App HTTP сервер: служебные эндпоинты (метрики, проверки готовности) или REST gateway
+type App struct {
+	httpServer *http.Server
+	port       int
+}
+
+func New(port int, handler http.Handler) *App {
+	return &App{
+		port: port,
+		httpServer: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           handler,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+}
+
+func (a *App) MustRun() {
+	if err := a.run(); err != nil {
+		panic(err)
+	}
+}
+
+func (a *App) run() error {
+	const op = "httpapp.Run"
+
+	l, err := net.Listen("tcp", a.httpServer.Addr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.Info("HTTP server is running", slog.String("addr", l.Addr().String()))
+
+	if err = a.httpServer.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Stop дожидается завершения активных запросов. Если ctx истекает раньше,
+// оставшиеся соединения закрываются принудительно
+func (a *App) Stop(ctx context.Context) {
+	const op = "httpapp.Stop"
+
+	log := slog.With(
+		slog.String("op", op),
+	)
+	log.Info("stopping HTTP server", slog.Int("port", a.port))
+
+	if err := a.httpServer.Shutdown(ctx); err != nil {
+		log.Warn("failed to stop HTTP server gracefully", slog.String("error", err.Error()))
+		_ = a.httpServer.Close()
+	}
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// shutdownStep шаг остановки приложения
+type shutdownStep struct {
+	name string
+	fn   func(ctx context.Context)
+}
+
+// shutdownCoordinator выполняет шаги остановки строго по порядку в пределах общего дедлайна.
+// Шаг, не уложившийся в дедлайн, бросается, чтобы не блокировать выход процесса:
+// остальные шаги выполняются с уже истёкшим ctx и завершаются принудительно
+type shutdownCoordinator struct {
+	timeout time.Duration
+	steps   []shutdownStep
+	log     *slog.Logger
+}
+
+func newShutdownCoordinator(timeout time.Duration, log *slog.Logger) *shutdownCoordinator {
+	return &shutdownCoordinator{timeout: timeout, log: log}
+}
+
+// add добавляет шаг, который учитывает ctx
+func (c *shutdownCoordinator) add(name string, fn func(ctx context.Context)) {
+	c.steps = append(c.steps, shutdownStep{name: name, fn: fn})
+}
+
+// addFunc добавляет шаг без поддержки ctx
+func (c *shutdownCoordinator) addFunc(name string, fn func()) {
+	c.add(name, func(context.Context) { fn() })
+}
+
+// run выполняет шаги и возвращает false, если какой-то шаг не уложился в дедлайн
+func (c *shutdownCoordinator) run() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	clean := true
+
+	for _, step := range c.steps {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			step.fn(ctx)
+		}()
+
+		select {
+		case <-done:
+			c.log.Debug("shutdown step completed", slog.String("step", step.name))
+		case <-ctx.Done():
+			// Даём шагу шанс завершиться принудительно, но не ждём его бесконечно
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				c.log.Error("shutdown step did not finish before deadline", slog.String("step", step.name))
+				clean = false
+			}
+		}
+	}
+
+	c.log.Info("shutdown completed", slog.Duration("took", time.Since(start)), slog.Bool("clean", clean))
+
+	return clean
+}
@@ -0,0 +1,167 @@
+package billing
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/makhtech/management/internal/domain/models"
+)
+
+var (
+	// ErrAutoRenewUnavailable ключ шифрования авторизаций автопродления не настроен
+	ErrAutoRenewUnavailable = errors.New("auto-renewal is not configured")
+	// ErrAutoRenewRevoked авторизация владельца больше не действует: SSO отказал в обмене
+	// refresh token или токен принадлежит другому пользователю
+	ErrAutoRenewRevoked = errors.New("auto-renewal authorization is no longer valid")
+)
+
+// newRenewCipher создаёт AES-GCM для хранения refresh token владельцев. Пустой ключ - автопродление недоступно
+func newRenewCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) == 0 {
+		return nil, nil
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// AuthorizeAutoRenew обменивает refresh token владельца VDS на новый и возвращает его зашифрованным
+// для хранения в VDS. Переданный токен SSO после обмена может отозвать, поэтому клиент передаёт
+// токен отдельного входа. Токен другого пользователя или отклонённый SSO - ErrAutoRenewRevoked
+func (b *Billing) AuthorizeAutoRenew(ctx context.Context, vds *models.VDS, refreshToken string) ([]byte, error) {
+	const op = "billing.AuthorizeAutoRenew"
+
+	if b.renewCipher == nil {
+		return nil, ErrAutoRenewUnavailable
+	}
+
+	_, rotated, err := b.refresh(ctx, vds.UserID, refreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return b.seal(vds.ID, rotated), nil
+}
+
+// AutoRenewAccess выпускает access token владельца VDS по сохранённой авторизации автопродления
+// и сохраняет обновлённый refresh token. Если авторизация больше не действует, она удаляется
+// и возвращается ErrAutoRenewRevoked
+func (b *Billing) AutoRenewAccess(ctx context.Context, vds *models.VDS) (string, error) {
+	const op = "billing.AutoRenewAccess"
+
+	if b.renewCipher == nil {
+		return "", ErrAutoRenewUnavailable
+	}
+
+	sealed, err := b.vdsRepo.GetAutoRenewToken(ctx, vds.ID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if sealed == nil {
+		return "", fmt.Errorf("%s: %w: auto-renewal is disabled", op, ErrAutoRenewRevoked)
+	}
+
+	accessToken, rotated, err := b.refreshSealed(ctx, vds, sealed)
+	if err != nil {
+		if errors.Is(err, ErrAutoRenewRevoked) {
+			b.revokeAutoRenew(ctx, vds.ID, sealed)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := b.vdsRepo.RotateAutoRenewToken(ctx, vds.ID, sealed, b.seal(vds.ID, rotated)); err != nil {
+		// Старый токен мог быть отозван обменом: следующая попытка, скорее всего, отключит автопродление
+		b.log.Error("failed to store rotated auto-renewal token",
+			slog.Int("vds_id", int(vds.ID)),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return accessToken, nil
+}
+
+func (b *Billing) refreshSealed(ctx context.Context, vds *models.VDS, sealed []byte) (string, string, error) {
+	refreshToken, err := b.open(vds.ID, sealed)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: stored token cannot be decrypted", ErrAutoRenewRevoked)
+	}
+	return b.refresh(ctx, vds.UserID, refreshToken)
+}
+
+// revokeAutoRenew удаляет недействующую авторизацию, если владелец не заменил её за это время
+func (b *Billing) revokeAutoRenew(ctx context.Context, vdsID int32, sealed []byte) {
+	if _, err := b.vdsRepo.RotateAutoRenewToken(ctx, vdsID, sealed, nil); err != nil {
+		b.log.Error("failed to disable revoked auto-renewal",
+			slog.Int("vds_id", int(vdsID)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// refresh обменивает refresh token в SSO и проверяет, что новая пара выдана ownerID
+func (b *Billing) refresh(ctx context.Context, ownerID int32, refreshToken string) (string, string, error) {
+	if b.sso == nil {
+		return "", "", errNoSSO
+	}
+
+	tokens, err := b.sso.RefreshToken(ctx, refreshToken)
+	if err != nil {
+		return "", "", rejected(err)
+	}
+
+	user, err := b.sso.ValidateJWT(ctx, tokens.GetAccessToken())
+	if err != nil {
+		return "", "", rejected(err)
+	}
+	if user.GetUserId() != int64(ownerID) {
+		return "", "", fmt.Errorf("%w: token belongs to another user", ErrAutoRenewRevoked)
+	}
+
+	// SSO без ротации возвращает только access token
+	rotated := tokens.GetRefreshToken()
+	if rotated == "" {
+		rotated = refreshToken
+	}
+	return tokens.GetAccessToken(), rotated, nil
+}
+
+// rejected отделяет недоступность SSO от отказа в обмене токена
+func rejected(err error) error {
+	if err = classify(err); errors.Is(err, ErrUnavailable) {
+		return err
+	}
+	return fmt.Errorf("%w: %s", ErrAutoRenewRevoked, err.Error())
+}
+
+// seal шифрует refresh token. ID VDS входит в аутентифицируемые данные: шифротекст не переносится на другую VDS
+func (b *Billing) seal(vdsID int32, token string) []byte {
+	nonce := make([]byte, b.renewCipher.NonceSize())
+	// crypto/rand.Read не возвращает ошибку начиная с Go 1.24
+	_, _ = rand.Read(nonce)
+	return b.renewCipher.Seal(nonce, nonce, []byte(token), renewAAD(vdsID))
+}
+
+func (b *Billing) open(vdsID int32, sealed []byte) (string, error) {
+	n := b.renewCipher.NonceSize()
+	if len(sealed) < n {
+		return "", errors.New("sealed token is too short")
+	}
+
+	token, err := b.renewCipher.Open(nil, sealed[:n], sealed[n:], renewAAD(vdsID))
+	if err != nil {
+		return "", err
+	}
+	return string(token), nil
+}
+
+func renewAAD(vdsID int32) []byte {
+	return []byte(fmt.Sprintf("vds-auto-renew:%d", vdsID))
+}
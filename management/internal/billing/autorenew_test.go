@@ -0,0 +1,70 @@
+package billing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/makhtech/management/internal/domain/models"
+)
+
+func newTestBilling(t *testing.T, key []byte) *Billing {
+	t.Helper()
+	return New(nil, nil, Config{AutoRenewKey: key}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestRenewTokenSealing(t *testing.T) {
+	b := newTestBilling(t, bytes.Repeat([]byte{7}, 32))
+
+	sealed := b.seal(1, "refresh-token")
+	if bytes.Contains(sealed, []byte("refresh-token")) {
+		t.Fatal("token is stored in plain text")
+	}
+	if bytes.Equal(sealed, b.seal(1, "refresh-token")) {
+		t.Fatal("sealing must use a fresh nonce")
+	}
+
+	token, err := b.open(1, sealed)
+	if err != nil || token != "refresh-token" {
+		t.Fatalf("open() = %q, %v", token, err)
+	}
+
+	tampered := bytes.Clone(sealed)
+	tampered[len(tampered)-1] ^= 1
+
+	other := newTestBilling(t, bytes.Repeat([]byte{8}, 32))
+
+	tests := []struct {
+		name   string
+		b      *Billing
+		vdsID  int32
+		sealed []byte
+	}{
+		{"other vds", b, 2, sealed},
+		{"tampered", b, 1, tampered},
+		{"truncated", b, 1, sealed[:5]},
+		{"other key", other, 1, sealed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.b.open(tt.vdsID, tt.sealed); err == nil {
+				t.Fatal("open() accepted a token it must reject")
+			}
+		})
+	}
+}
+
+func TestAutoRenewWithoutKey(t *testing.T) {
+	b := newTestBilling(t, nil)
+	vds := &models.VDS{ID: 1, UserID: 7}
+
+	if _, err := b.AuthorizeAutoRenew(context.Background(), vds, "refresh-token"); !errors.Is(err, ErrAutoRenewUnavailable) {
+		t.Fatalf("AuthorizeAutoRenew() error = %v, want ErrAutoRenewUnavailable", err)
+	}
+	if _, err := b.AutoRenewAccess(context.Background(), vds); !errors.Is(err, ErrAutoRenewUnavailable) {
+		t.Fatalf("AutoRenewAccess() error = %v, want ErrAutoRenewUnavailable", err)
+	}
+}
@@ -0,0 +1,365 @@
+package billing
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/makhtech/management/internal/clients/sso"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+	ssov1 "github.com/makhtech/proto/gen/go/sso"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrUnavailable SSO не смог обработать операцию с балансом, её можно повторить позже
+var ErrUnavailable = errors.New("billing is temporarily unavailable")
+
+// errNoSSO SSO не был подключён при старте, операции с балансом невозможны
+var errNoSSO = fmt.Errorf("%w: SSO client is not connected", ErrUnavailable)
+
+const (
+	// settleTimeout время на отправку итога резервирования вне контекста запроса
+	settleTimeout = 10 * time.Second
+	// reconcileBatch сколько резервирований обрабатывается за один проход сверки
+	reconcileBatch = 100
+	// reconcileGrace возраст завершённой задачи, после которого итог отправляет сверка, а не воркер
+	reconcileGrace = time.Minute
+)
+
+// Config конфигурация списания оплаты за VDS
+type Config struct {
+	// AppID приложение в SSO, на балансе которого ведутся операции
+	AppID int32
+	// ServiceToken токен, от имени которого подтверждаются и отменяются резервирования
+	// вне запроса пользователя. Пусто - запросы отправляются без авторизации
+	ServiceToken string
+	// ReconcileInterval интервал повторной отправки неподтверждённых итогов
+	ReconcileInterval time.Duration
+	// AutoRenewKey ключ AES-256 для хранения авторизаций автопродления. Пусто - автопродление недоступно
+	AutoRenewKey []byte
+}
+
+// Billing резервирует оплату VDS в SSO и подтверждает или отменяет её по итогу задачи create или продления
+type Billing struct {
+	sso     *sso.Client
+	vdsRepo repository.VDSRepository
+	cfg     Config
+	log     *slog.Logger
+	// renewCipher шифрует refresh token владельцев для автопродления, nil - автопродление недоступно
+	renewCipher cipher.AEAD
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New создаёт биллинг поверх Transactions API SSO. Без ssoClient все операции возвращают ErrUnavailable
+func New(ssoClient *sso.Client, vdsRepo repository.VDSRepository, cfg Config, log *slog.Logger) *Billing {
+	if cfg.ReconcileInterval <= 0 {
+		cfg.ReconcileInterval = time.Minute
+	}
+
+	renewCipher, err := newRenewCipher(cfg.AutoRenewKey)
+	if err != nil {
+		log.Error("auto-renewal is unavailable: invalid key", slog.String("error", err.Error()))
+	}
+
+	return &Billing{
+		sso:         ssoClient,
+		vdsRepo:     vdsRepo,
+		cfg:         cfg,
+		log:         log,
+		renewCipher: renewCipher,
+	}
+}
+
+// AmountFromPrice переводит месячную цену тарифа в копейки
+func AmountFromPrice(price float64) int64 {
+	return int64(math.Round(price * 100))
+}
+
+// Причины отказов SSO из google.rpc.ErrorInfo. Текст ошибки не разбирается: он не является контрактом
+const (
+	reasonInsufficientFunds = "INSUFFICIENT_FUNDS"
+	reasonAlreadyCommitted  = "RESERVATION_ALREADY_COMMITTED"
+	reasonAlreadyCancelled  = "RESERVATION_ALREADY_CANCELLED"
+)
+
+// IdempotencyKey выводит ключ идемпотентности резервирования из запроса на создание VDS.
+// Повтор запроса с тем же request_id получает то же резервирование. request_id обязателен для платных тарифов
+func IdempotencyKey(req *models.CreateVDSRequest) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("vds-create:%d:%d:%s", req.UserID, req.PlanID, req.RequestID)))
+	return "vds-create-" + hex.EncodeToString(sum[:16])
+}
+
+// Reserve замораживает amount на балансе владельца accessToken и возвращает ID резервирования.
+// Нехватка средств - repository.ErrInsufficientFunds, недоступность SSO - ErrUnavailable
+func (b *Billing) Reserve(ctx context.Context, accessToken string, amount int64, key, description string) (string, error) {
+	const op = "billing.Reserve"
+
+	reservationID, err := b.reserve(ctx, accessToken, &ssov1.ReserveRequest{
+		AppId:          b.cfg.AppID,
+		Amount:         amount,
+		IdempotencyKey: key,
+		Description:    description,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reservationID, nil
+}
+
+func (b *Billing) reserve(ctx context.Context, accessToken string, req *ssov1.ReserveRequest) (string, error) {
+	if b.sso == nil {
+		return "", errNoSSO
+	}
+
+	resp, err := b.sso.Reserve(ctx, accessToken, req)
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition && reason(st) == reasonInsufficientFunds {
+			return "", repository.ErrInsufficientFunds
+		}
+		return "", classify(err)
+	}
+
+	if resp.GetStatus() != ssov1.TransactionStatus_TRANSACTION_SUCCESS || resp.GetReservationId() == "" {
+		// Отказ без ошибки gRPC: нехватку средств видно по остатку баланса
+		if resp.GetRemainingBalance() < req.GetAmount() {
+			return "", repository.ErrInsufficientFunds
+		}
+		return "", fmt.Errorf("reserve rejected: %s", resp.GetErrorMessage())
+	}
+
+	return resp.GetReservationId(), nil
+}
+
+// Commit списывает зарезервированные средства. Повторное подтверждение не считается ошибкой
+func (b *Billing) Commit(ctx context.Context, reservationID string) error {
+	const op = "billing.Commit"
+
+	if err := b.commitReserve(ctx, reservationID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// CommitRenewal списывает оплату записанного продления вне контекста запроса: отмена запроса
+// клиентом не должна оставить средства в резерве. Если SSO недоступен, итог позже отправит сверка
+func (b *Billing) CommitRenewal(vdsID int32, reservationID string) {
+	b.settle(context.Background(), &models.BillingSettlement{
+		VDSID:         vdsID,
+		ReservationID: reservationID,
+		Commit:        true,
+		Renewal:       true,
+	})
+}
+
+// Release отменяет резервирование, которое не попало ни в одну VDS.
+// Вызывается после неудачного создания VDS, поэтому не зависит от контекста запроса
+func (b *Billing) Release(reservationID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), settleTimeout)
+	defer cancel()
+
+	if err := b.cancelReserve(ctx, reservationID); err != nil {
+		b.log.Error("failed to release unused reservation",
+			slog.String("reservation_id", reservationID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// OnTaskComplete подтверждает оплату после успешной задачи create и отменяет её после неудачной.
+// Если SSO недоступен, итог позже отправит сверка
+func (b *Billing) OnTaskComplete(ctx context.Context, task *models.Task) {
+	if task.Type != models.TaskTypeCreate {
+		return
+	}
+
+	vds, err := b.vdsRepo.GetByID(ctx, task.VDSID)
+	if err != nil {
+		b.log.Error("failed to load vds for billing",
+			slog.Int("vds_id", int(task.VDSID)),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	if vds.BillingStatus != models.BillingStatusReserved {
+		return
+	}
+
+	b.settle(ctx, &models.BillingSettlement{
+		VDSID:         vds.ID,
+		ReservationID: vds.ReservationID,
+		Commit:        task.Status == models.TaskStatusDone,
+	})
+}
+
+// Start запускает периодическую сверку неподтверждённых резервирований
+func (b *Billing) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+
+	b.wg.Add(1)
+	go b.reconcile(ctx)
+
+	b.log.Info("billing reconciler started", slog.Duration("interval", b.cfg.ReconcileInterval))
+}
+
+// Stop останавливает сверку и дожидается текущего прохода
+func (b *Billing) Stop() {
+	if b.cancel == nil {
+		return
+	}
+	b.cancel()
+	b.wg.Wait()
+}
+
+// reconcile отправляет итоги, которые воркер не смог отправить: SSO был недоступен,
+// реплика упала или задачу завершила очистка зависших задач
+func (b *Billing) reconcile(ctx context.Context) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.cfg.ReconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pending, err := b.vdsRepo.ListUnsettled(ctx, reconcileGrace, reconcileBatch)
+			if err != nil {
+				if ctx.Err() == nil {
+					b.log.Error("failed to list unsettled reservations", slog.String("error", err.Error()))
+				}
+				continue
+			}
+			for _, s := range pending {
+				b.settle(ctx, s)
+			}
+		}
+	}
+}
+
+// settle отправляет итог резервирования в SSO и записывает его в VDS или продление
+func (b *Billing) settle(ctx context.Context, s *models.BillingSettlement) {
+	log := b.log.With(
+		slog.Int("vds_id", int(s.VDSID)),
+		slog.String("reservation_id", s.ReservationID),
+		slog.Bool("commit", s.Commit),
+		slog.Bool("renewal", s.Renewal),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, settleTimeout)
+	defer cancel()
+
+	var err error
+	result := models.BillingStatusCommitted
+	if s.Commit {
+		err = b.commitReserve(ctx, s.ReservationID)
+	} else {
+		result = models.BillingStatusCancelled
+		err = b.cancelReserve(ctx, s.ReservationID)
+	}
+	if err != nil {
+		log.Error("failed to settle vds reservation", slog.String("error", err.Error()))
+		return
+	}
+
+	if s.Renewal {
+		_, err = b.vdsRepo.SettleRenewal(ctx, s.ReservationID)
+	} else {
+		_, err = b.vdsRepo.SettleBilling(ctx, s.VDSID, result)
+	}
+	if err != nil {
+		log.Error("failed to record reservation result", slog.String("error", err.Error()))
+		return
+	}
+
+	log.Info("vds reservation settled", slog.String("result", string(result)))
+}
+
+func (b *Billing) commitReserve(ctx context.Context, reservationID string) error {
+	if b.sso == nil {
+		return errNoSSO
+	}
+
+	resp, err := b.sso.CommitReserve(ctx, b.cfg.ServiceToken, &ssov1.CommitReserveRequest{
+		ReservationId: reservationID,
+		AppId:         b.cfg.AppID,
+	})
+	// Повторное подтверждение после сбоя записи итога - не ошибка
+	if err != nil && !isSettled(err, reasonAlreadyCommitted) {
+		return classify(err)
+	}
+	if err == nil && !resp.GetSuccess() {
+		return fmt.Errorf("commit rejected: %s", resp.GetErrorMessage())
+	}
+	return nil
+}
+
+func (b *Billing) cancelReserve(ctx context.Context, reservationID string) error {
+	if b.sso == nil {
+		return errNoSSO
+	}
+
+	resp, err := b.sso.CancelReserve(ctx, b.cfg.ServiceToken, &ssov1.CancelReserveRequest{
+		ReservationId: reservationID,
+		AppId:         b.cfg.AppID,
+	})
+	if err != nil && !isSettled(err, reasonAlreadyCancelled) {
+		return classify(err)
+	}
+	if err == nil && !resp.GetSuccess() {
+		return fmt.Errorf("cancel rejected: %s", resp.GetErrorMessage())
+	}
+	return nil
+}
+
+// classify приводит ошибку вызова SSO к ошибкам биллинга
+func classify(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnavailable, err.Error())
+	}
+
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled, codes.ResourceExhausted:
+		return fmt.Errorf("%w: %s", ErrUnavailable, st.Message())
+	}
+	return err
+}
+
+// isSettled проверяет, что SSO отказал, потому что резервирование уже получило этот итог
+func isSettled(err error, settledReason string) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch st.Code() {
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		return reason(st) == settledReason
+	}
+	return false
+}
+
+// reason возвращает причину отказа из google.rpc.ErrorInfo в деталях статуса
+func reason(st *status.Status) string {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func ssoError(t *testing.T, code codes.Code, msg, reason string) error {
+	t.Helper()
+
+	st := status.New(code, msg)
+	if reason != "" {
+		var err error
+		st, err = st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: "sso"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Клиент SSO оборачивает ошибку gRPC
+	return fmt.Errorf("clients.sso.CommitReserve: %w", st.Err())
+}
+
+func TestIsSettled(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+		want   bool
+	}{
+		{"already committed", ssoError(t, codes.FailedPrecondition, "reservation is already committed", reasonAlreadyCommitted), reasonAlreadyCommitted, true},
+		{"already exists", ssoError(t, codes.AlreadyExists, "done", reasonAlreadyCommitted), reasonAlreadyCommitted, true},
+		{"reworded message", ssoError(t, codes.FailedPrecondition, "reservation was settled earlier", reasonAlreadyCommitted), reasonAlreadyCommitted, true},
+		{"cancelled is not committed", ssoError(t, codes.FailedPrecondition, "already cancelled", reasonAlreadyCancelled), reasonAlreadyCommitted, false},
+		{"message without reason", ssoError(t, codes.FailedPrecondition, "reservation already committed", ""), reasonAlreadyCommitted, false},
+		{"other code", ssoError(t, codes.Internal, "boom", reasonAlreadyCommitted), reasonAlreadyCommitted, false},
+		{"not a status", errors.New("already committed"), reasonAlreadyCommitted, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSettled(tt.err, tt.reason); got != tt.want {
+				t.Fatalf("isSettled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		wantUnavailable bool
+	}{
+		{"unavailable", ssoError(t, codes.Unavailable, "connection refused", ""), true},
+		{"deadline", ssoError(t, codes.DeadlineExceeded, "timeout", ""), true},
+		{"transport error", errors.New("dial tcp: connection refused"), true},
+		{"rejected", ssoError(t, codes.FailedPrecondition, "insufficient funds", ""), false},
+		{"not found", ssoError(t, codes.NotFound, "reservation not found", ""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(classify(tt.err), ErrUnavailable); got != tt.wantUnavailable {
+				t.Fatalf("classify() unavailable = %v, want %v", got, tt.wantUnavailable)
+			}
+		})
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	req := &models.CreateVDSRequest{UserID: 7, PlanID: 3, RequestID: "order-42"}
+
+	key := IdempotencyKey(req)
+	if key != IdempotencyKey(&models.CreateVDSRequest{UserID: 7, PlanID: 3, RequestID: "order-42"}) {
+		t.Fatal("retry with the same request_id must get the same key")
+	}
+
+	for _, other := range []*models.CreateVDSRequest{
+		{UserID: 8, PlanID: 3, RequestID: "order-42"},
+		{UserID: 7, PlanID: 4, RequestID: "order-42"},
+		{UserID: 7, PlanID: 3, RequestID: "order-43"},
+	} {
+		if IdempotencyKey(other) == key {
+			t.Fatalf("request %+v got the same key", other)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package proxmox
+
+import (
+	"sync"
+)
+
+// Pool кеширует клиентов по api_url ноды, чтобы переиспользовать HTTP соединения
+type Pool struct {
+	cfg Config
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// NewPool создаёт пул клиентов с общей конфигурацией
+func NewPool(cfg Config) *Pool {
+	return &Pool{
+		cfg:     cfg,
+		clients: make(map[string]*Client),
+	}
+}
+
+// Get возвращает клиент для api_url ноды. Если в конфигурации задан Endpoint,
+// все ноды обслуживаются им
+func (p *Pool) Get(apiURL string) (*Client, error) {
+	if p.cfg.Endpoint != "" {
+		apiURL = p.cfg.Endpoint
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.clients[apiURL]; ok {
+		return c, nil
+	}
+
+	c, err := New(apiURL, p.cfg)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[apiURL] = c
+	return c, nil
+}
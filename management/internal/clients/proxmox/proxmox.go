@@ -0,0 +1,316 @@
+package proxmox
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrProxmox базовая ошибка для всех сбоев при обращении к Proxmox API
+var ErrProxmox = errors.New("proxmox error")
+
+// ErrVMNotFound VM с указанным vmid не существует на ноде
+var ErrVMNotFound = errors.New("proxmox vm not found")
+
+// Error ошибка, которую вернул Proxmox API
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("proxmox: %d %s", e.StatusCode, e.Message)
+}
+
+// Is позволяет проверять любую ошибку API через errors.Is(err, ErrProxmox)
+func (e *Error) Is(target error) bool {
+	return target == ErrProxmox
+}
+
+// Config конфигурация клиента Proxmox
+type Config struct {
+	// TokenID идентификатор API токена в формате USER@REALM!TOKENID
+	TokenID string
+	// TokenSecret секрет API токена
+	TokenSecret string
+	// Timeout таймаут одного HTTP запроса
+	Timeout time.Duration
+	// InsecureSkipVerify отключает проверку TLS сертификата (самоподписанные сертификаты нод)
+	InsecureSkipVerify bool
+	// Endpoint, если задан, используется вместо api_url всех нод (например, fake сервер)
+	Endpoint string
+}
+
+// Client клиент Proxmox VE API одной ноды (кластера)
+type Client struct {
+	baseURL    string
+	authHeader string
+	httpClient *http.Client
+}
+
+// New создаёт клиент. baseURL указывает на /api2/json, например https://pve1:8006/api2/json
+func New(baseURL string, cfg Config) (*Client, error) {
+	const op = "clients.proxmox.New"
+
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%s: invalid base url %q", op, baseURL)
+	}
+	if cfg.TokenID == "" || cfg.TokenSecret == "" {
+		return nil, fmt.Errorf("%s: api token is required", op)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		authHeader: fmt.Sprintf("PVEAPIToken=%s=%s", cfg.TokenID, cfg.TokenSecret),
+		httpClient: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: transport,
+		},
+	}, nil
+}
+
+// CloneOptions параметры клонирования VM из шаблона
+type CloneOptions struct {
+	Name string
+	// Full полный клон вместо linked clone
+	Full bool
+	// Storage хранилище для дисков полного клона. Пусто - как у шаблона
+	Storage string
+}
+
+// VMConfig параметры VM, изменяемые после клонирования
+type VMConfig struct {
+	Cores    int32
+	MemoryMB int32
+}
+
+// VMStatus текущее состояние VM
+type VMStatus struct {
+	VMID   int32  `json:"vmid"`
+	Name   string `json:"name"`
+	Status string `json:"status"` // running, stopped
+	Uptime int64  `json:"uptime"`
+}
+
+// Task статус асинхронной задачи Proxmox (UPID)
+type Task struct {
+	UPID       string `json:"upid"`
+	Status     string `json:"status"`     // running, stopped
+	ExitStatus string `json:"exitstatus"` // OK или текст ошибки, если задача завершена
+}
+
+// Done возвращает true, если задача завершена
+func (t *Task) Done() bool {
+	return t.Status == "stopped"
+}
+
+// CloneVM клонирует шаблон templateID в новую VM newID
+func (c *Client) CloneVM(ctx context.Context, node string, templateID, newID int32, opts CloneOptions) (string, error) {
+	form := url.Values{}
+	form.Set("newid", strconv.Itoa(int(newID)))
+	if opts.Name != "" {
+		form.Set("name", opts.Name)
+	}
+	if opts.Full {
+		form.Set("full", "1")
+	}
+	if opts.Storage != "" {
+		form.Set("storage", opts.Storage)
+	}
+
+	return c.doUPID(ctx, http.MethodPost, vmPath(node, templateID)+"/clone", form)
+}
+
+// ConfigureVM задаёт количество ядер и объём памяти VM
+func (c *Client) ConfigureVM(ctx context.Context, node string, vmid int32, cfg VMConfig) error {
+	form := url.Values{}
+	if cfg.Cores > 0 {
+		form.Set("cores", strconv.Itoa(int(cfg.Cores)))
+	}
+	if cfg.MemoryMB > 0 {
+		form.Set("memory", strconv.Itoa(int(cfg.MemoryMB)))
+	}
+
+	return c.do(ctx, http.MethodPut, vmPath(node, vmid)+"/config", form, nil)
+}
+
+// ResizeDisk увеличивает диск VM до sizeGB
+func (c *Client) ResizeDisk(ctx context.Context, node string, vmid int32, disk string, sizeGB int32) error {
+	form := url.Values{}
+	form.Set("disk", disk)
+	form.Set("size", fmt.Sprintf("%dG", sizeGB))
+
+	return c.do(ctx, http.MethodPut, vmPath(node, vmid)+"/resize", form, nil)
+}
+
+// StartVM запускает VM
+func (c *Client) StartVM(ctx context.Context, node string, vmid int32) (string, error) {
+	return c.doUPID(ctx, http.MethodPost, vmPath(node, vmid)+"/status/start", nil)
+}
+
+// ShutdownVM корректно выключает VM через ACPI
+func (c *Client) ShutdownVM(ctx context.Context, node string, vmid int32) (string, error) {
+	return c.doUPID(ctx, http.MethodPost, vmPath(node, vmid)+"/status/shutdown", nil)
+}
+
+// StopVM немедленно останавливает VM
+func (c *Client) StopVM(ctx context.Context, node string, vmid int32) (string, error) {
+	return c.doUPID(ctx, http.MethodPost, vmPath(node, vmid)+"/status/stop", nil)
+}
+
+// RebootVM перезагружает VM
+func (c *Client) RebootVM(ctx context.Context, node string, vmid int32) (string, error) {
+	return c.doUPID(ctx, http.MethodPost, vmPath(node, vmid)+"/status/reboot", nil)
+}
+
+// DestroyVM удаляет VM вместе с дисками
+func (c *Client) DestroyVM(ctx context.Context, node string, vmid int32) (string, error) {
+	form := url.Values{}
+	form.Set("purge", "1")
+	form.Set("destroy-unreferenced-disks", "1")
+
+	return c.doUPID(ctx, http.MethodDelete, vmPath(node, vmid)+"?"+form.Encode(), nil)
+}
+
+// GetVMStatus возвращает текущее состояние VM. Если VM нет - ErrVMNotFound
+func (c *Client) GetVMStatus(ctx context.Context, node string, vmid int32) (*VMStatus, error) {
+	var status VMStatus
+	if err := c.do(ctx, http.MethodGet, vmPath(node, vmid)+"/status/current", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetTaskStatus возвращает статус задачи по UPID
+func (c *Client) GetTaskStatus(ctx context.Context, node, upid string) (*Task, error) {
+	var task Task
+	path := "/nodes/" + url.PathEscape(node) + "/tasks/" + url.PathEscape(upid) + "/status"
+	if err := c.do(ctx, http.MethodGet, path, nil, &task); err != nil {
+		return nil, err
+	}
+	task.UPID = upid
+	return &task, nil
+}
+
+// WaitTask опрашивает задачу с интервалом interval до её завершения.
+// Если задача завершилась не с OK, возвращается *Error с exitstatus задачи
+func (c *Client) WaitTask(ctx context.Context, node, upid string, interval time.Duration) error {
+	if upid == "" {
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		task, err := c.GetTaskStatus(ctx, node, upid)
+		if err != nil {
+			return err
+		}
+		if task.Done() {
+			if task.ExitStatus != "OK" {
+				return &Error{StatusCode: http.StatusOK, Message: fmt.Sprintf("task %s failed: %s", upid, task.ExitStatus)}
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: waiting for task %s: %w", ErrProxmox, upid, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// doUPID выполняет запрос, который запускает асинхронную задачу, и возвращает её UPID
+func (c *Client) doUPID(ctx context.Context, method, path string, form url.Values) (string, error) {
+	var upid string
+	if err := c.do(ctx, method, path, form, &upid); err != nil {
+		return "", err
+	}
+	return upid, nil
+}
+
+// do выполняет запрос к API и декодирует поле data ответа в out
+func (c *Client) do(ctx context.Context, method, path string, form url.Values, out any) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrProxmox, err)
+	}
+	req.Header.Set("Authorization", c.authHeader)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s %s: %w", ErrProxmox, method, path, err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data    json.RawMessage   `json:"data"`
+		Message string            `json:"message"`
+		Errors  map[string]string `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: decode response: %w", ErrProxmox, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return apiError(resp, payload.Message, payload.Errors)
+	}
+
+	if out == nil || len(payload.Data) == 0 || string(payload.Data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(payload.Data, out); err != nil {
+		return fmt.Errorf("%w: decode data: %w", ErrProxmox, err)
+	}
+	return nil
+}
+
+// apiError собирает ошибку из ответа. Proxmox кладёт основной текст в reason phrase
+// (новые версии дублируют его в поле message), а ошибки параметров - в поле errors
+func apiError(resp *http.Response, message string, fieldErrors map[string]string) error {
+	msg := strings.TrimSpace(message)
+	if msg == "" {
+		msg = strings.TrimSpace(strings.TrimPrefix(resp.Status, strconv.Itoa(resp.StatusCode)))
+	}
+	for field, fieldMsg := range fieldErrors {
+		msg += fmt.Sprintf("; %s: %s", field, strings.TrimSpace(fieldMsg))
+	}
+
+	apiErr := &Error{StatusCode: resp.StatusCode, Message: msg}
+	// На отсутствующую VM Proxmox отвечает 500 с текстом про конфигурационный файл
+	if strings.Contains(msg, "does not exist") {
+		return fmt.Errorf("%w: %w", ErrVMNotFound, apiErr)
+	}
+	return apiErr
+}
+
+func vmPath(node string, vmid int32) string {
+	return "/nodes/" + url.PathEscape(node) + "/qemu/" + strconv.Itoa(int(vmid))
+}
@@ -0,0 +1,317 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/makhtech/management/internal/clients/proxmox/proxmoxtest"
+)
+
+const (
+	testTokenID     = "management@pve!worker"
+	testTokenSecret = "secret"
+	testNode        = "pve1"
+	testTemplate    = 9000
+	pollInterval    = time.Millisecond
+)
+
+func newTestClient(t *testing.T) (*Client, *proxmoxtest.Server) {
+	t.Helper()
+
+	srv := proxmoxtest.NewServer(testTokenID, testTokenSecret)
+	srv.AddTemplate(testTemplate)
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.APIURL(), Config{TokenID: testTokenID, TokenSecret: testTokenSecret})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c, srv
+}
+
+// run выполняет асинхронную операцию и дожидается её задачи
+func run(t *testing.T, c *Client, upid string, err error) error {
+	t.Helper()
+
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(upid, "UPID:"+testNode+":") {
+		t.Fatalf("unexpected upid %q", upid)
+	}
+	return c.WaitTask(context.Background(), testNode, upid, pollInterval)
+}
+
+func TestVMLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c, srv := newTestClient(t)
+
+	upid, err := c.CloneVM(ctx, testNode, testTemplate, 101, CloneOptions{Name: "vds-1", Full: true})
+	if err := run(t, c, upid, err); err != nil {
+		t.Fatalf("CloneVM: %v", err)
+	}
+	if err := c.ConfigureVM(ctx, testNode, 101, VMConfig{Cores: 4, MemoryMB: 8192}); err != nil {
+		t.Fatalf("ConfigureVM: %v", err)
+	}
+	if err := c.ResizeDisk(ctx, testNode, 101, "scsi0", 80); err != nil {
+		t.Fatalf("ResizeDisk: %v", err)
+	}
+
+	vm, ok := srv.GetVM(testNode, 101)
+	if !ok || vm.Name != "vds-1" || vm.Cores != 4 || vm.MemoryMB != 8192 || vm.DiskGB != 80 {
+		t.Fatalf("vm after configure = %+v, exists %v", vm, ok)
+	}
+
+	steps := []struct {
+		name   string
+		action func(ctx context.Context, node string, vmid int32) (string, error)
+		want   string
+	}{
+		{"start", c.StartVM, "running"},
+		{"reboot", c.RebootVM, "running"},
+		{"shutdown", c.ShutdownVM, "stopped"},
+		{"start again", c.StartVM, "running"},
+		{"stop", c.StopVM, "stopped"},
+	}
+	for _, step := range steps {
+		upid, err := step.action(ctx, testNode, 101)
+		if err := run(t, c, upid, err); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		status, err := c.GetVMStatus(ctx, testNode, 101)
+		if err != nil {
+			t.Fatalf("%s: GetVMStatus: %v", step.name, err)
+		}
+		if status.Status != step.want || status.VMID != 101 {
+			t.Fatalf("%s: status = %+v, want %s", step.name, status, step.want)
+		}
+	}
+
+	upid, err = c.DestroyVM(ctx, testNode, 101)
+	if err := run(t, c, upid, err); err != nil {
+		t.Fatalf("DestroyVM: %v", err)
+	}
+	if _, err := c.GetVMStatus(ctx, testNode, 101); !errors.Is(err, ErrVMNotFound) {
+		t.Fatalf("GetVMStatus after destroy: %v, want ErrVMNotFound", err)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// setup готовит fake сервер, call выполняет проверяемый запрос
+		setup        func(t *testing.T, c *Client, srv *proxmoxtest.Server)
+		call         func(c *Client) error
+		wantNotFound bool
+		wantStatus   int
+		wantMessage  string
+	}{
+		{
+			name:         "clone of unknown template",
+			call:         func(c *Client) error { _, err := c.CloneVM(ctx, testNode, 1234, 101, CloneOptions{}); return err },
+			wantNotFound: true,
+			wantStatus:   http.StatusInternalServerError,
+			wantMessage:  "does not exist",
+		},
+		{
+			name:  "clone into taken vmid",
+			setup: cloneVM(101),
+			call: func(c *Client) error {
+				_, err := c.CloneVM(ctx, testNode, testTemplate, 101, CloneOptions{})
+				return err
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantMessage: "already exists",
+		},
+		{
+			name:         "status of missing vm",
+			call:         func(c *Client) error { _, err := c.GetVMStatus(ctx, testNode, 404); return err },
+			wantNotFound: true,
+			wantStatus:   http.StatusInternalServerError,
+		},
+		{
+			name:        "shrinking disk",
+			setup:       cloneVM(101),
+			call:        func(c *Client) error { return c.ResizeDisk(ctx, testNode, 101, "scsi0", 1) },
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "size: invalid size",
+		},
+		{
+			name: "destroy running vm",
+			setup: func(t *testing.T, c *Client, srv *proxmoxtest.Server) {
+				cloneVM(101)(t, c, srv)
+				upid, err := c.StartVM(ctx, testNode, 101)
+				if err := run(t, c, upid, err); err != nil {
+					t.Fatal(err)
+				}
+			},
+			call:        func(c *Client) error { _, err := c.DestroyVM(ctx, testNode, 101); return err },
+			wantStatus:  http.StatusInternalServerError,
+			wantMessage: "is running",
+		},
+		{
+			name:        "unknown task",
+			call:        func(c *Client) error { _, err := c.GetTaskStatus(ctx, testNode, "UPID:pve1:missing"); return err },
+			wantStatus:  http.StatusInternalServerError,
+			wantMessage: "no such task",
+		},
+		{
+			name: "failed task",
+			setup: func(t *testing.T, c *Client, srv *proxmoxtest.Server) {
+				cloneVM(101)(t, c, srv)
+				srv.FailNext("start", "start failed: kvm: no space left")
+			},
+			call: func(c *Client) error {
+				upid, err := c.StartVM(ctx, testNode, 101)
+				if err != nil {
+					return err
+				}
+				return c.WaitTask(ctx, testNode, upid, pollInterval)
+			},
+			wantStatus:  http.StatusOK,
+			wantMessage: "no space left",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, srv := newTestClient(t)
+			if tt.setup != nil {
+				tt.setup(t, c, srv)
+			}
+
+			err := tt.call(c)
+			if !errors.Is(err, ErrProxmox) {
+				t.Fatalf("error = %v, want ErrProxmox", err)
+			}
+			if errors.Is(err, ErrVMNotFound) != tt.wantNotFound {
+				t.Fatalf("error = %v, want not found %v", err, tt.wantNotFound)
+			}
+			var apiErr *Error
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+				t.Fatalf("error = %v, want api error with status %d", err, tt.wantStatus)
+			}
+			if !strings.Contains(apiErr.Message, tt.wantMessage) {
+				t.Fatalf("message = %q, want %q", apiErr.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func cloneVM(vmid int32) func(t *testing.T, c *Client, srv *proxmoxtest.Server) {
+	return func(t *testing.T, c *Client, _ *proxmoxtest.Server) {
+		t.Helper()
+
+		upid, err := c.CloneVM(context.Background(), testNode, testTemplate, vmid, CloneOptions{})
+		if err := run(t, c, upid, err); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestWaitTask(t *testing.T) {
+	c, srv := newTestClient(t)
+	srv.TaskPolls = 3
+
+	upid, err := c.CloneVM(context.Background(), testNode, testTemplate, 101, CloneOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// UPID содержит двоеточия и должен уйти в путь без искажений
+	task, err := c.GetTaskStatus(context.Background(), testNode, upid)
+	if err != nil {
+		t.Fatalf("GetTaskStatus: %v", err)
+	}
+	if task.UPID != upid || task.Done() {
+		t.Fatalf("task = %+v, want running task %s", task, upid)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.WaitTask(ctx, testNode, upid, time.Hour); !errors.Is(err, ErrProxmox) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("WaitTask with cancelled context: %v", err)
+	}
+
+	if err := c.WaitTask(context.Background(), testNode, upid, pollInterval); err != nil {
+		t.Fatalf("WaitTask: %v", err)
+	}
+	if err := c.WaitTask(context.Background(), testNode, "", pollInterval); err != nil {
+		t.Fatalf("WaitTask without upid: %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "valid", baseURL: "https://pve1:8006/api2/json", cfg: Config{TokenID: testTokenID, TokenSecret: testTokenSecret}},
+		{name: "relative url", baseURL: "/api2/json", cfg: Config{TokenID: testTokenID, TokenSecret: testTokenSecret}, wantErr: true},
+		{name: "no token", baseURL: "https://pve1:8006/api2/json", cfg: Config{TokenID: testTokenID}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.baseURL, tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWrongToken(t *testing.T) {
+	srv := proxmoxtest.NewServer(testTokenID, testTokenSecret)
+	defer srv.Close()
+
+	c, err := New(srv.APIURL(), Config{TokenID: testTokenID, TokenSecret: "wrong"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var apiErr *Error
+	if _, err := c.GetVMStatus(context.Background(), testNode, 101); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("error = %v, want 401", err)
+	}
+}
+
+func TestPool(t *testing.T) {
+	cfg := Config{TokenID: testTokenID, TokenSecret: testTokenSecret}
+
+	p := NewPool(cfg)
+	a, err := p.Get("https://pve1:8006/api2/json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := p.Get("https://pve1:8006/api2/json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := p.Get("https://pve2:8006/api2/json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b || a == other {
+		t.Fatal("pool must reuse the client of one api url and separate different ones")
+	}
+	if _, err := p.Get("not a url"); err == nil {
+		t.Fatal("invalid api url accepted")
+	}
+
+	cfg.Endpoint = "http://127.0.0.1:8006/api2/json"
+	p = NewPool(cfg)
+	a, _ = p.Get("https://pve1:8006/api2/json")
+	b, _ = p.Get("https://pve2:8006/api2/json")
+	if a == nil || a != b || a.baseURL != cfg.Endpoint {
+		t.Fatal("endpoint must serve all nodes")
+	}
+}
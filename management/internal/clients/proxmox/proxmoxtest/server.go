@@ -0,0 +1,349 @@
+// Package proxmoxtest содержит in-process fake Proxmox VE API на httptest.
+// Подходит для локального запуска сервиса и проверки воркера без реального кластера
+package proxmoxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const apiPrefix = "/api2/json"
+
+// VM состояние VM в fake сервере
+type VM struct {
+	Node     string
+	VMID     int32
+	Name     string
+	Status   string // running, stopped
+	Cores    int32
+	MemoryMB int32
+	DiskGB   int32
+}
+
+type task struct {
+	node       string
+	exitStatus string
+	// pollsLeft сколько раз задача ещё будет в статусе running
+	pollsLeft int
+}
+
+// Server fake Proxmox API. Асинхронные операции возвращают UPID, задачи завершаются
+// после TaskPolls опросов статуса
+type Server struct {
+	*httptest.Server
+
+	TokenID     string
+	TokenSecret string
+	// TaskPolls количество опросов, в течение которых задача остаётся running
+	TaskPolls int
+
+	mu        sync.Mutex
+	templates map[int32]bool
+	vms       map[string]*VM
+	tasks     map[string]*task
+	failures  map[string]string
+	taskSeq   int
+}
+
+// NewServer запускает fake сервер с указанным API токеном
+func NewServer(tokenID, tokenSecret string) *Server {
+	s := &Server{
+		TokenID:     tokenID,
+		TokenSecret: tokenSecret,
+		templates:   make(map[int32]bool),
+		vms:         make(map[string]*VM),
+		tasks:       make(map[string]*task),
+		failures:    make(map[string]string),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+apiPrefix+"/nodes/{node}/qemu/{vmid}/clone", s.handleClone)
+	mux.HandleFunc("PUT "+apiPrefix+"/nodes/{node}/qemu/{vmid}/config", s.handleConfig)
+	mux.HandleFunc("PUT "+apiPrefix+"/nodes/{node}/qemu/{vmid}/resize", s.handleResize)
+	mux.HandleFunc("POST "+apiPrefix+"/nodes/{node}/qemu/{vmid}/status/{action}", s.handleAction)
+	mux.HandleFunc("GET "+apiPrefix+"/nodes/{node}/qemu/{vmid}/status/current", s.handleStatus)
+	mux.HandleFunc("DELETE "+apiPrefix+"/nodes/{node}/qemu/{vmid}", s.handleDestroy)
+	mux.HandleFunc("GET "+apiPrefix+"/nodes/{node}/tasks/{upid}/status", s.handleTaskStatus)
+
+	s.Server = httptest.NewServer(s.authenticate(mux))
+	return s
+}
+
+// APIURL возвращает адрес API в формате nodes.api_url
+func (s *Server) APIURL() string {
+	return s.URL + apiPrefix
+}
+
+// AddTemplate регистрирует шаблон, доступный для клонирования на любой ноде
+func (s *Server) AddTemplate(vmid int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.templates[vmid] = true
+}
+
+// FailNext заставляет следующую операцию action (clone, start, stop, shutdown, reboot, destroy)
+// завершиться задачей с exitstatus msg
+func (s *Server) FailNext(action, msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures[action] = msg
+}
+
+// GetVM возвращает копию состояния VM
+func (s *Server) GetVM(node string, vmid int32) (VM, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vm, ok := s.vms[vmKey(node, vmid)]
+	if !ok {
+		return VM{}, false
+	}
+	return *vm, true
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	expected := fmt.Sprintf("PVEAPIToken=%s=%s", s.TokenID, s.TokenSecret)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != expected {
+			writeError(w, http.StatusUnauthorized, "authentication failure")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleClone(w http.ResponseWriter, r *http.Request) {
+	node := r.PathValue("node")
+	templateID, ok := pathVMID(w, r)
+	if !ok {
+		return
+	}
+	newID, err := strconv.Atoi(r.FormValue("newid"))
+	if err != nil || newID <= 0 {
+		writeError(w, http.StatusBadRequest, "parameter verification failed", "newid", "invalid vmid")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.templates[templateID] {
+		writeError(w, http.StatusInternalServerError, notExists(node, templateID))
+		return
+	}
+	key := vmKey(node, int32(newID))
+	if _, exists := s.vms[key]; exists {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("unable to create VM %d: config file already exists", newID))
+		return
+	}
+
+	upid, failed := s.startTask(node, "clone")
+	if !failed {
+		s.vms[key] = &VM{
+			Node:     node,
+			VMID:     int32(newID),
+			Name:     r.FormValue("name"),
+			Status:   "stopped",
+			Cores:    1,
+			MemoryMB: 512,
+			DiskGB:   10,
+		}
+	}
+	writeData(w, upid)
+}
+
+func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
+	vm, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	if v := r.FormValue("cores"); v != "" {
+		n, _ := strconv.Atoi(v)
+		vm.Cores = int32(n)
+	}
+	if v := r.FormValue("memory"); v != "" {
+		n, _ := strconv.Atoi(v)
+		vm.MemoryMB = int32(n)
+	}
+	writeData(w, nil)
+}
+
+func (s *Server) handleResize(w http.ResponseWriter, r *http.Request) {
+	vm, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	size, err := strconv.Atoi(strings.TrimSuffix(r.FormValue("size"), "G"))
+	if err != nil || int32(size) < vm.DiskGB {
+		writeError(w, http.StatusBadRequest, "parameter verification failed", "size", "invalid size")
+		return
+	}
+	vm.DiskGB = int32(size)
+	writeData(w, nil)
+}
+
+func (s *Server) handleAction(w http.ResponseWriter, r *http.Request) {
+	action := r.PathValue("action")
+
+	var next string
+	switch action {
+	case "start", "reboot":
+		next = "running"
+	case "stop", "shutdown":
+		next = "stopped"
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	vm, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	upid, failed := s.startTask(vm.Node, action)
+	if !failed {
+		vm.Status = next
+	}
+	writeData(w, upid)
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	vm, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	writeData(w, map[string]any{
+		"vmid":   vm.VMID,
+		"name":   vm.Name,
+		"status": vm.Status,
+		"uptime": 0,
+	})
+}
+
+func (s *Server) handleDestroy(w http.ResponseWriter, r *http.Request) {
+	vm, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	if vm.Status == "running" {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("VM %d is running - destroy failed", vm.VMID))
+		return
+	}
+
+	upid, failed := s.startTask(vm.Node, "destroy")
+	if !failed {
+		delete(s.vms, vmKey(vm.Node, vm.VMID))
+	}
+	writeData(w, upid)
+}
+
+func (s *Server) handleTaskStatus(w http.ResponseWriter, r *http.Request) {
+	upid := r.PathValue("upid")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tasks[upid]
+	if !ok || t.node != r.PathValue("node") {
+		writeError(w, http.StatusInternalServerError, "no such task")
+		return
+	}
+
+	if t.pollsLeft > 0 {
+		t.pollsLeft--
+		writeData(w, map[string]any{"upid": upid, "status": "running"})
+		return
+	}
+	writeData(w, map[string]any{"upid": upid, "status": "stopped", "exitstatus": t.exitStatus})
+}
+
+// lookup находит VM из пути запроса. При успехе возвращает VM с захваченным мьютексом
+func (s *Server) lookup(w http.ResponseWriter, r *http.Request) (*VM, bool) {
+	node := r.PathValue("node")
+	vmid, ok := pathVMID(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	vm, exists := s.vms[vmKey(node, vmid)]
+	if !exists {
+		s.mu.Unlock()
+		writeError(w, http.StatusInternalServerError, notExists(node, vmid))
+		return nil, false
+	}
+	return vm, true
+}
+
+// startTask создаёт задачу и возвращает её UPID. failed=true, если для action
+// была запланирована ошибка через FailNext. Вызывается под мьютексом
+func (s *Server) startTask(node, action string) (upid string, failed bool) {
+	s.taskSeq++
+	upid = fmt.Sprintf("UPID:%s:%08X:%08X:%08X:qm%s::fake@pve:", node, s.taskSeq, 0, time.Now().Unix(), action)
+
+	exitStatus := "OK"
+	if msg, ok := s.failures[action]; ok {
+		delete(s.failures, action)
+		exitStatus = msg
+		failed = true
+	}
+
+	s.tasks[upid] = &task{node: node, exitStatus: exitStatus, pollsLeft: s.TaskPolls}
+	return upid, failed
+}
+
+func pathVMID(w http.ResponseWriter, r *http.Request) (int32, bool) {
+	vmid, err := strconv.Atoi(r.PathValue("vmid"))
+	if err != nil || vmid <= 0 {
+		writeError(w, http.StatusBadRequest, "parameter verification failed", "vmid", "invalid vmid")
+		return 0, false
+	}
+	return int32(vmid), true
+}
+
+func vmKey(node string, vmid int32) string {
+	return node + "/" + strconv.Itoa(int(vmid))
+}
+
+func notExists(node string, vmid int32) string {
+	return fmt.Sprintf("Configuration file 'nodes/%s/qemu-server/%d.conf' does not exist", node, vmid)
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+// writeError отвечает как Proxmox: текст ошибки в reason phrase, ошибки параметров в errors
+func writeError(w http.ResponseWriter, code int, msg string, fieldErrors ...string) {
+	errs := make(map[string]string)
+	for i := 0; i+1 < len(fieldErrors); i += 2 {
+		errs[fieldErrors[i]] = fieldErrors[i+1]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	body := map[string]any{"data": nil, "message": msg}
+	if len(errs) > 0 {
+		body["errors"] = errs
+	}
+	_ = json.NewEncoder(w).Encode(body)
+}
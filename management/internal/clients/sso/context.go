@@ -13,7 +13,11 @@ const (
 	BearerPrefix = "Bearer "
 )
 
-// contextWithAccessToken добавляет access token в контекст как gRPC metadata
+// contextWithAccessToken добавляет access token в контекст как gRPC metadata.
+// Пустой токен не добавляется
 func contextWithAccessToken(ctx context.Context, accessToken string) context.Context {
+	if accessToken == "" {
+		return ctx
+	}
 	return metadata.AppendToOutgoingContext(ctx, AuthorizationHeader, BearerPrefix+accessToken)
 }
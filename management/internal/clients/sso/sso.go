@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	ssov1 "github.com/makhtech/proto/gen/go/sso"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
@@ -64,6 +66,28 @@ func (c *Client) Close() error {
 	return nil
 }
 
+// HealthCheck проверяет, что соединение с SSO установлено. Простаивающее соединение
+// переподключается, ожидание ограничено ctx
+func (c *Client) HealthCheck(ctx context.Context) error {
+	const op = "clients.sso.HealthCheck"
+
+	for {
+		state := c.conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Shutdown:
+			return fmt.Errorf("%s: connection is closed", op)
+		case connectivity.Idle:
+			c.conn.Connect()
+		}
+
+		if !c.conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("%s: connection is %s", op, strings.ToLower(state.String()))
+		}
+	}
+}
+
 // ValidateJWT валидирует JWT токен и возвращает информацию о пользователе
 func (c *Client) ValidateJWT(ctx context.Context, accessToken string) (*ssov1.ValidateJWTResponse, error) {
 	const op = "clients.sso.ValidateJWT"
@@ -79,6 +103,18 @@ func (c *Client) ValidateJWT(ctx context.Context, accessToken string) (*ssov1.Va
 	return resp, nil
 }
 
+// RefreshToken обменивает refresh token на новую пару токенов
+func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (*ssov1.TokenPair, error) {
+	const op = "clients.sso.RefreshToken"
+
+	resp, err := c.authClient.RefreshToken(ctx, &ssov1.RefreshTokenRequest{RefreshToken: refreshToken})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.GetTokens(), nil
+}
+
 // Auth возвращает Auth клиент для низкоуровневых операций
 func (c *Client) Auth() ssov1.AuthClient {
 	return c.authClient
@@ -93,3 +129,39 @@ func (c *Client) User() ssov1.UserClient {
 func (c *Client) Transactions() ssov1.TransactionsClient {
 	return c.transactionsClient
 }
+
+// Reserve замораживает amount на балансе владельца accessToken
+func (c *Client) Reserve(ctx context.Context, accessToken string, req *ssov1.ReserveRequest) (*ssov1.ReserveResponse, error) {
+	const op = "clients.sso.Reserve"
+
+	resp, err := c.transactionsClient.Reserve(contextWithAccessToken(ctx, accessToken), req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+// CommitReserve списывает зарезервированные средства
+func (c *Client) CommitReserve(ctx context.Context, accessToken string, req *ssov1.CommitReserveRequest) (*ssov1.CommitReserveResponse, error) {
+	const op = "clients.sso.CommitReserve"
+
+	resp, err := c.transactionsClient.CommitReserve(contextWithAccessToken(ctx, accessToken), req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+// CancelReserve размораживает зарезервированные средства
+func (c *Client) CancelReserve(ctx context.Context, accessToken string, req *ssov1.CancelReserveRequest) (*ssov1.CancelReserveResponse, error) {
+	const op = "clients.sso.CancelReserve"
+
+	resp, err := c.transactionsClient.CancelReserve(contextWithAccessToken(ctx, accessToken), req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
@@ -1,14 +1,18 @@
 package config
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/makhtech/management/internal/clients/proxmox"
+	"github.com/makhtech/management/internal/jwtverify"
 	"github.com/makhtech/management/internal/repository/postgres"
 	"github.com/makhtech/management/pkg/directories"
 )
@@ -19,12 +23,74 @@ type Config struct {
 	Database    DatabaseConfig    `json:"repository"`
 	SSO         SSOConfig         `json:"sso"`
 	RateLimiter RateLimiterConfig `json:"rate_limiter"`
+	Worker      WorkerConfig      `json:"worker"`
+	Proxmox     ProxmoxConfig     `json:"proxmox"`
+	Placement   PlacementConfig   `json:"placement"`
+	Billing     BillingConfig     `json:"billing"`
+	Expiry      ExpiryConfig      `json:"expiry"`
+	Auth        AuthConfig        `json:"auth"`
+	HTTP        HTTPConfig        `json:"http"`
+	Gateway     GatewayConfig     `json:"gateway"`
+	Health      HealthConfig      `json:"health"`
+	Shutdown    ShutdownConfig    `json:"shutdown"`
+	Idempotency IdempotencyConfig `json:"idempotency"`
+	Outbox      OutboxConfig      `json:"outbox"`
+	Webhooks    WebhooksConfig    `json:"webhooks"`
+}
+
+// redactedValue подставляется в лог вместо заданного секрета
+const redactedValue = "[REDACTED]"
+
+// loggedConfig Config без LogValue, чтобы slog не раскрывал значение повторно
+type loggedConfig Config
+
+// LogValue скрывает секреты при логировании конфигурации
+func (c Config) LogValue() slog.Value {
+	c.Database.Password = redact(c.Database.Password)
+	c.Proxmox.TokenSecret = redact(c.Proxmox.TokenSecret)
+	c.Billing.ServiceToken = redact(c.Billing.ServiceToken)
+	if u, err := url.Parse(c.Outbox.WebhookURL); err == nil {
+		c.Outbox.WebhookURL = u.Redacted()
+	}
+	return slog.AnyValue(loggedConfig(c))
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redactedValue
 }
 
 type SSOConfig struct {
 	Address  string `json:"address"`
 	Timeout  string `json:"timeout"`
 	Insecure bool   `json:"insecure"`
+	// TokenCacheSize максимальное количество проверенных токенов в кэше. 0 - значение по умолчанию
+	TokenCacheSize int `json:"token_cache_size"`
+	// TokenCacheTTL сколько проверенный токен живёт в кэше (не дольше exp токена). "0s" выключает кэш.
+	// SSO не сообщает о logout, поэтому это и верхняя граница, сколько отозванный токен ещё принимается.
+	// Не больше MaxTokenCacheTTL
+	TokenCacheTTL string `json:"token_cache_ttl"`
+}
+
+type AuthConfig struct {
+	// Mode способ проверки токенов: sso (SSO, при его недоступности - локально) или local (только локально)
+	Mode string `json:"mode"`
+	// PublicKeyFile PEM файл с открытыми ключами SSO для локальной проверки подписи
+	PublicKeyFile string `json:"public_key_file"`
+	// JWKSFile файл с открытыми ключами SSO в формате JWKS
+	JWKSFile string `json:"jwks_file"`
+	// JWKSURL endpoint, с которого загружаются открытые ключи SSO
+	JWKSURL string `json:"jwks_url"`
+	// Issuer ожидаемый iss токена. Пусто - не проверяется
+	Issuer string `json:"issuer"`
+	// Audience ожидаемый aud токена. Пусто - не проверяется
+	Audience string `json:"audience"`
+	// Leeway допустимое расхождение часов при проверке срока действия токена
+	Leeway string `json:"leeway"`
+	// JWKSRefreshInterval минимальная пауза между перезагрузками jwks_url
+	JWKSRefreshInterval string `json:"jwks_refresh_interval"`
 }
 
 type RateLimiterConfig struct {
@@ -36,11 +102,153 @@ type RateLimiterConfig struct {
 	CleanupInterval string `json:"cleanup_interval"`
 }
 
+type WorkerConfig struct {
+	// Concurrency количество задач, обрабатываемых одновременно одной репликой
+	Concurrency int `json:"concurrency"`
+	// PollInterval пауза между опросами очереди задач
+	PollInterval string `json:"poll_interval"`
+	// TaskTimeout максимальное время выполнения одной задачи
+	TaskTimeout string `json:"task_timeout"`
+}
+
+type ProxmoxConfig struct {
+	// TokenID идентификатор API токена в формате USER@REALM!TOKENID
+	TokenID     string `json:"token_id"`
+	TokenSecret string `json:"token_secret"`
+	Timeout     string `json:"timeout"`
+	Insecure    bool   `json:"insecure"`
+	// Endpoint переопределяет api_url всех нод
+	Endpoint string `json:"endpoint"`
+	// TemplateID vmid шаблона, из которого клонируются VM
+	TemplateID int32  `json:"template_id"`
+	Storage    string `json:"storage"`
+	Disk       string `json:"disk"`
+	// TaskPollInterval интервал опроса задач Proxmox (UPID)
+	TaskPollInterval string `json:"task_poll_interval"`
+	// Fake запускает встроенный fake Proxmox API вместо обращения к нодам (для локальной разработки).
+	// Требует сборки с тегом fakeproxmox
+	Fake bool `json:"fake"`
+}
+
+type PlacementConfig struct {
+	// Strategy стратегия выбора ноды для VDS без node_id: bin-packing, spread, least-loaded
+	Strategy string `json:"strategy"`
+}
+
+type BillingConfig struct {
+	// Enabled включает оплату VDS с баланса пользователя в SSO
+	Enabled bool `json:"enabled"`
+	// AppID приложение в SSO, на балансе которого ведутся операции
+	AppID int32 `json:"app_id"`
+	// ServiceToken токен для подтверждения и отмены резервирований вне запроса пользователя
+	ServiceToken string `json:"service_token"`
+	// ReconcileInterval интервал повторной отправки неподтверждённых итогов оплаты
+	ReconcileInterval string `json:"reconcile_interval"`
+	// AutoRenewKey ключ AES-256 в base64 для хранения авторизаций автопродления. Пусто - автопродление недоступно
+	AutoRenewKey string `json:"auto_renew_key"`
+}
+
+type ExpiryConfig struct {
+	// Enabled включает автопродление, приостановку и удаление VDS по сроку подписки
+	Enabled bool `json:"enabled"`
+	// Interval пауза между проходами планировщика подписок
+	Interval string `json:"interval"`
+	// RenewBefore за сколько до окончания подписки начинаются попытки автопродления
+	RenewBefore string `json:"renew_before"`
+	// RetryAfter пауза перед повторным автопродлением после неудачной попытки
+	RetryAfter string `json:"retry_after"`
+	// GracePeriod сколько VDS работает после окончания подписки до остановки
+	GracePeriod string `json:"grace_period"`
+	// Retention сколько остановленная за неоплату VDS хранится до удаления
+	Retention string `json:"retention"`
+	// BatchSize сколько VDS обрабатывается на каждом шаге за один проход
+	BatchSize int `json:"batch_size"`
+}
+
 type GRPCConfig struct {
 	//Address string `json:"address"`
 	Port int
 }
 
+type HTTPConfig struct {
+	// Port порт служебного HTTP сервера (/metrics, /healthz, /readyz). 0 - сервер не запускается
+	Port int `json:"port"`
+}
+
+type GatewayConfig struct {
+	// Port порт REST/JSON gateway к Management API. 0 - gateway не запускается
+	Port int `json:"port"`
+}
+
+type HealthConfig struct {
+	// CheckInterval пауза между проверками зависимостей для grpc.health.v1
+	CheckInterval string `json:"check_interval"`
+	// CheckTimeout время на одну проверку всех зависимостей
+	CheckTimeout string `json:"check_timeout"`
+	// DrainDelay сколько сервис отвечает NOT_SERVING перед остановкой серверов,
+	// чтобы балансировщики успели снять трафик
+	DrainDelay string `json:"drain_delay"`
+}
+
+type ShutdownConfig struct {
+	// Timeout общий дедлайн остановки приложения, после которого активные запросы обрываются
+	Timeout string `json:"timeout"`
+}
+
+type IdempotencyConfig struct {
+	// TTL сколько хранится ответ по ключу идемпотентности
+	TTL string `json:"ttl"`
+	// LockTimeout через сколько незавершённый запрос с ключом (например, реплика упала)
+	// перестаёт блокировать повтор. Не меньше 11m: вызов с ожиданием задачи питания идёт до 10 минут
+	LockTimeout string `json:"lock_timeout"`
+	// CleanupInterval пауза между удалениями просроченных ключей
+	CleanupInterval string `json:"cleanup_interval"`
+}
+
+type OutboxConfig struct {
+	// Enabled включает публикацию доменных событий. Выключенный relay не останавливает запись:
+	// события копятся в таблице outbox до включения
+	Enabled bool `json:"enabled"`
+	// Sink куда публикуются события: stdout, file или webhook
+	Sink string `json:"sink"`
+	// FilePath файл для sink file
+	FilePath string `json:"file_path"`
+	// WebhookURL адрес для sink webhook
+	WebhookURL string `json:"webhook_url"`
+	// WebhookTimeout время на один запрос webhook
+	WebhookTimeout string `json:"webhook_timeout"`
+	// Interval пауза между проверками outbox
+	Interval string `json:"interval"`
+	// BatchSize сколько событий публикуется за один запрос к outbox
+	BatchSize int `json:"batch_size"`
+	// MaxBackoff наибольшая пауза между повторами неудачной публикации
+	MaxBackoff string `json:"max_backoff"`
+	// Retention сколько хранятся опубликованные события
+	Retention string `json:"retention"`
+}
+
+type WebhooksConfig struct {
+	// Enabled включает доставку событий подпискам. События раскладываются в доставки
+	// outbox relay, поэтому без outbox.enabled новые доставки не появляются
+	Enabled bool `json:"enabled"`
+	// Interval пауза между проверками очереди доставок
+	Interval string `json:"interval"`
+	// BatchSize сколько доставок берётся за один запрос
+	BatchSize int `json:"batch_size"`
+	// Concurrency сколько доставок отправляется одновременно
+	Concurrency int `json:"concurrency"`
+	// Timeout время на один запрос к получателю
+	Timeout string `json:"timeout"`
+	// MaxAttempts после стольких неудачных попыток доставка переходит в dead
+	MaxAttempts int32 `json:"max_attempts"`
+	// BaseBackoff пауза перед первым повтором, дальше она удваивается
+	BaseBackoff string `json:"base_backoff"`
+	// MaxBackoff наибольшая пауза между повторами
+	MaxBackoff string `json:"max_backoff"`
+	// Retention сколько хранятся завершённые доставки
+	Retention string `json:"retention"`
+}
+
 type DatabaseConfig struct {
 	Host              string `json:"host"`
 	Port              string `json:"port"`
@@ -134,6 +342,45 @@ func (c *SSOConfig) ToSSOClientConfig() (address string, timeout time.Duration,
 	return c.Address, parseDuration(c.Timeout, 5*time.Second), c.Insecure
 }
 
+func (c *SSOConfig) GetTokenCacheSize() int {
+	if c.TokenCacheSize <= 0 {
+		return 10000
+	}
+	return c.TokenCacheSize
+}
+
+// MaxTokenCacheTTL наибольший срок кэширования токена, то есть наибольшая задержка отзыва
+const MaxTokenCacheTTL = 5 * time.Minute
+
+func (c *SSOConfig) GetTokenCacheTTL() time.Duration {
+	return min(parseDuration(c.TokenCacheTTL, 30*time.Second), MaxTokenCacheTTL)
+}
+
+func (c *AuthConfig) GetMode() string {
+	if c.Mode == "" {
+		return "sso"
+	}
+	return c.Mode
+}
+
+// HasKeys проверяет, что задан хотя бы один источник открытых ключей
+func (c *AuthConfig) HasKeys() bool {
+	return c.PublicKeyFile != "" || c.JWKSFile != "" || c.JWKSURL != ""
+}
+
+// ToVerifierConfig преобразует AuthConfig в конфигурацию локального верификатора токенов
+func (c *AuthConfig) ToVerifierConfig() jwtverify.Config {
+	return jwtverify.Config{
+		PublicKeyFile:   c.PublicKeyFile,
+		JWKSFile:        c.JWKSFile,
+		JWKSURL:         c.JWKSURL,
+		Issuer:          c.Issuer,
+		Audience:        c.Audience,
+		Leeway:          parseDuration(c.Leeway, 30*time.Second),
+		RefreshInterval: parseDuration(c.JWKSRefreshInterval, time.Minute),
+	}
+}
+
 // ToRateLimiterConfig преобразует RateLimiterConfig для пакета ratelimiter
 func (c *RateLimiterConfig) GetRate() int {
 	if c.Rate <= 0 {
@@ -152,3 +399,192 @@ func (c *RateLimiterConfig) GetCapacity() int {
 func (c *RateLimiterConfig) GetCleanupInterval() time.Duration {
 	return parseDuration(c.CleanupInterval, 5*time.Minute)
 }
+
+func (c *WorkerConfig) GetConcurrency() int {
+	if c.Concurrency <= 0 {
+		return 4
+	}
+	return c.Concurrency
+}
+
+func (c *WorkerConfig) GetPollInterval() time.Duration {
+	return parseDuration(c.PollInterval, 2*time.Second)
+}
+
+func (c *WorkerConfig) GetTaskTimeout() time.Duration {
+	return parseDuration(c.TaskTimeout, 10*time.Minute)
+}
+
+// ToProxmoxClientConfig преобразует ProxmoxConfig в конфигурацию клиента Proxmox
+func (c *ProxmoxConfig) ToProxmoxClientConfig() proxmox.Config {
+	return proxmox.Config{
+		TokenID:            c.TokenID,
+		TokenSecret:        c.TokenSecret,
+		Timeout:            parseDuration(c.Timeout, 30*time.Second),
+		InsecureSkipVerify: c.Insecure,
+		Endpoint:           c.Endpoint,
+	}
+}
+
+func (c *ProxmoxConfig) GetTemplateID() int32 {
+	if c.TemplateID <= 0 {
+		return 9000
+	}
+	return c.TemplateID
+}
+
+func (c *ProxmoxConfig) GetTaskPollInterval() time.Duration {
+	return parseDuration(c.TaskPollInterval, 2*time.Second)
+}
+
+func (c *PlacementConfig) GetStrategy() string {
+	if c.Strategy == "" {
+		return "least-loaded"
+	}
+	return c.Strategy
+}
+
+func (c *BillingConfig) GetReconcileInterval() time.Duration {
+	return parseDuration(c.ReconcileInterval, time.Minute)
+}
+
+// GetAutoRenewKey декодирует ключ автопродления. Пустой ключ - nil без ошибки
+func (c *BillingConfig) GetAutoRenewKey() ([]byte, error) {
+	if c.AutoRenewKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(c.AutoRenewKey)
+	if err != nil {
+		return nil, fmt.Errorf("auto_renew_key is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("auto_renew_key must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+func (c *ExpiryConfig) GetInterval() time.Duration {
+	return parseDuration(c.Interval, 5*time.Minute)
+}
+
+func (c *ExpiryConfig) GetRenewBefore() time.Duration {
+	return parseDuration(c.RenewBefore, 72*time.Hour)
+}
+
+func (c *ExpiryConfig) GetRetryAfter() time.Duration {
+	return parseDuration(c.RetryAfter, 6*time.Hour)
+}
+
+func (c *ExpiryConfig) GetGracePeriod() time.Duration {
+	return parseDuration(c.GracePeriod, 72*time.Hour)
+}
+
+func (c *ExpiryConfig) GetRetention() time.Duration {
+	return parseDuration(c.Retention, 7*24*time.Hour)
+}
+
+func (c *ExpiryConfig) GetBatchSize() int {
+	if c.BatchSize <= 0 {
+		return 100
+	}
+	return c.BatchSize
+}
+
+func (c *HealthConfig) GetCheckInterval() time.Duration {
+	return parseDuration(c.CheckInterval, 10*time.Second)
+}
+
+func (c *HealthConfig) GetCheckTimeout() time.Duration {
+	return parseDuration(c.CheckTimeout, 3*time.Second)
+}
+
+func (c *HealthConfig) GetDrainDelay() time.Duration {
+	return parseDuration(c.DrainDelay, 5*time.Second)
+}
+
+func (c *ShutdownConfig) GetTimeout() time.Duration {
+	return parseDuration(c.Timeout, 30*time.Second)
+}
+
+func (c *IdempotencyConfig) GetTTL() time.Duration {
+	return parseDuration(c.TTL, 24*time.Hour)
+}
+
+func (c *IdempotencyConfig) GetLockTimeout() time.Duration {
+	return parseDuration(c.LockTimeout, 15*time.Minute)
+}
+
+func (c *IdempotencyConfig) GetCleanupInterval() time.Duration {
+	return parseDuration(c.CleanupInterval, 10*time.Minute)
+}
+
+func (c *OutboxConfig) GetSink() string {
+	if c.Sink == "" {
+		return "stdout"
+	}
+	return c.Sink
+}
+
+func (c *OutboxConfig) GetWebhookTimeout() time.Duration {
+	return parseDuration(c.WebhookTimeout, 10*time.Second)
+}
+
+func (c *OutboxConfig) GetInterval() time.Duration {
+	return parseDuration(c.Interval, time.Second)
+}
+
+func (c *OutboxConfig) GetBatchSize() int {
+	if c.BatchSize <= 0 {
+		return 100
+	}
+	return c.BatchSize
+}
+
+func (c *OutboxConfig) GetMaxBackoff() time.Duration {
+	return parseDuration(c.MaxBackoff, 5*time.Minute)
+}
+
+func (c *OutboxConfig) GetRetention() time.Duration {
+	return parseDuration(c.Retention, 7*24*time.Hour)
+}
+
+func (c *WebhooksConfig) GetInterval() time.Duration {
+	return parseDuration(c.Interval, time.Second)
+}
+
+func (c *WebhooksConfig) GetBatchSize() int {
+	if c.BatchSize <= 0 {
+		return 100
+	}
+	return c.BatchSize
+}
+
+func (c *WebhooksConfig) GetConcurrency() int {
+	if c.Concurrency <= 0 {
+		return 8
+	}
+	return c.Concurrency
+}
+
+func (c *WebhooksConfig) GetTimeout() time.Duration {
+	return parseDuration(c.Timeout, 10*time.Second)
+}
+
+func (c *WebhooksConfig) GetMaxAttempts() int32 {
+	if c.MaxAttempts <= 0 {
+		return 10
+	}
+	return c.MaxAttempts
+}
+
+func (c *WebhooksConfig) GetBaseBackoff() time.Duration {
+	return parseDuration(c.BaseBackoff, 10*time.Second)
+}
+
+func (c *WebhooksConfig) GetMaxBackoff() time.Duration {
+	return parseDuration(c.MaxBackoff, time.Hour)
+}
+
+func (c *WebhooksConfig) GetRetention() time.Duration {
+	return parseDuration(c.Retention, 30*24*time.Hour)
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry - запись журнала аудита изменяющего вызова API
+type AuditEntry struct {
+	ID int64
+	// ActorID пользователь из токена, nil - вызов без аутентификации
+	ActorID   *int64
+	ActorName string
+	ActorRole string
+	// Method полное имя gRPC метода
+	Method     string
+	EntityType string
+	// EntityID nil, если цель вызова неизвестна (например, создание завершилось ошибкой)
+	EntityID *int64
+	// Diff изменённые поля: {"field": {"old": ..., "new": ...}}
+	Diff      json.RawMessage
+	RequestID string
+	ClientIP  string
+	// Code код gRPC статуса вызова
+	Code      string
+	CreatedAt time.Time
+}
+
+// AuditFilter - фильтр журнала аудита
+type AuditFilter struct {
+	ActorID    *int64
+	EntityType string
+	EntityID   *int64
+	Method     string
+	Created    TimeRange
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// IdempotencyStatus - состояние вызова с ключом идемпотентности
+type IdempotencyStatus string
+
+const (
+	// IdempotencyInProgress вызов выполняется, ответа ещё нет
+	IdempotencyInProgress IdempotencyStatus = "in_progress"
+	// IdempotencyCompleted вызов завершён успешно, ответ сохранён
+	IdempotencyCompleted IdempotencyStatus = "completed"
+)
+
+// IdempotencyKey - ключ идемпотентности изменяющего вызова
+type IdempotencyKey struct {
+	// UserID владелец ключа: ключи разных пользователей не пересекаются
+	UserID int64
+	Key    string
+	Method string
+	// RequestHash SHA-256 метода и запроса в hex
+	RequestHash string
+	Status      IdempotencyStatus
+	// Response сериализованный protobuf ответ, nil пока вызов выполняется
+	Response  []byte
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
@@ -0,0 +1,225 @@
+package models
+
+import (
+	"math"
+	"math/big"
+	"net/netip"
+	"time"
+)
+
+// IP семейства адресов пула
+const (
+	IPFamilyV4 int32 = 4
+	IPFamilyV6 int32 = 6
+)
+
+// IPRange - диапазон адресов [Start, End] включительно
+type IPRange struct {
+	Start       netip.Addr
+	End         netip.Addr
+	Description string
+}
+
+// Contains проверяет, что адрес входит в диапазон
+func (r IPRange) Contains(addr netip.Addr) bool {
+	return r.Start.Compare(addr) <= 0 && addr.Compare(r.End) <= 0
+}
+
+// IPPool - доменная модель пула адресов ноды
+type IPPool struct {
+	ID             int32
+	NodeID         int32
+	Family         int32
+	Subnet         netip.Prefix
+	Gateway        netip.Addr
+	ReservedRanges []IPRange
+	IsActive       bool
+	CreatedAt      time.Time
+}
+
+// CreateIPPoolRequest - запрос на создание пула. Адреса в текстовом виде, их разбирает сервис
+type CreateIPPoolRequest struct {
+	NodeID         int32
+	Subnet         string
+	Gateway        string
+	ReservedRanges []IPRangeRequest
+}
+
+// IPRangeRequest - зарезервированный диапазон в запросе на создание пула
+type IPRangeRequest struct {
+	Start       string
+	End         string
+	Description string
+}
+
+// IPPoolUsage - статистика использования пула
+type IPPoolUsage struct {
+	Pool *IPPool
+	// Total количество адресов, пригодных для выдачи (без адреса сети и broadcast)
+	Total uint64
+	// Reserved шлюз и зарезервированные диапазоны
+	Reserved  uint64
+	Allocated uint64
+	Free      uint64
+}
+
+// UsagePct процент занятых адресов среди доступных для выдачи
+func (u *IPPoolUsage) UsagePct() float64 {
+	available := u.Total - min(u.Reserved, u.Total)
+	if available == 0 {
+		return 100
+	}
+	return float64(u.Allocated) / float64(available) * 100
+}
+
+// FirstUsable возвращает первый адрес подсети, который может быть выдан.
+// Адрес сети не выдаётся (для IPv6 это subnet-router anycast)
+func (p *IPPool) FirstUsable() netip.Addr {
+	first := p.Subnet.Masked().Addr()
+	if p.Subnet.Bits() < first.BitLen()-1 {
+		first = first.Next()
+	}
+	return first
+}
+
+// LastUsable возвращает последний адрес подсети, который может быть выдан.
+// Для IPv4 broadcast адрес не выдаётся
+func (p *IPPool) LastUsable() netip.Addr {
+	last := lastAddr(p.Subnet)
+	if last.Is4() && p.Subnet.Bits() < 31 {
+		last = last.Prev()
+	}
+	return last
+}
+
+// NextFree возвращает наименьший свободный адрес пула: не шлюз, не в зарезервированном
+// диапазоне и не среди allocated. Если свободных адресов нет, ok = false
+func (p *IPPool) NextFree(allocated map[netip.Addr]bool) (addr netip.Addr, ok bool) {
+	last := p.LastUsable()
+
+	// Каждая итерация либо возвращает адрес, либо пропускает шлюз, диапазон
+	// или выданный адрес, поэтому цикл ограничен их количеством
+	for addr = p.FirstUsable(); addr.IsValid() && addr.Compare(last) <= 0; {
+		if addr == p.Gateway || allocated[addr] {
+			addr = addr.Next()
+			continue
+		}
+		if r, reserved := p.reservedRange(addr); reserved {
+			addr = r.End.Next()
+			continue
+		}
+		return addr, true
+	}
+
+	return netip.Addr{}, false
+}
+
+// IsAssignable проверяет, что адрес можно выдать вручную: он внутри пула,
+// не является шлюзом, адресом сети/broadcast и не зарезервирован
+func (p *IPPool) IsAssignable(addr netip.Addr) bool {
+	if !p.Subnet.Contains(addr) || addr == p.Gateway {
+		return false
+	}
+	if addr.Compare(p.FirstUsable()) < 0 || addr.Compare(p.LastUsable()) > 0 {
+		return false
+	}
+	_, reserved := p.reservedRange(addr)
+	return !reserved
+}
+
+// Usage считает статистику пула по количеству выданных адресов
+func (p *IPPool) Usage(allocated uint64) *IPPoolUsage {
+	first, last := p.FirstUsable(), p.LastUsable()
+	total := rangeSize(first, last)
+
+	reserved := uint64(0)
+	gatewayReserved := false
+	for _, r := range p.ReservedRanges {
+		start, end := maxAddr(r.Start, first), minAddr(r.End, last)
+		if start.Compare(end) > 0 {
+			continue
+		}
+		reserved = saturatingAdd(reserved, rangeSize(start, end))
+		if r.Contains(p.Gateway) {
+			gatewayReserved = true
+		}
+	}
+	if !gatewayReserved && p.Gateway.Compare(first) >= 0 && p.Gateway.Compare(last) <= 0 {
+		reserved = saturatingAdd(reserved, 1)
+	}
+
+	free := uint64(0)
+	if used := saturatingAdd(reserved, allocated); total > used {
+		free = total - used
+	}
+
+	return &IPPoolUsage{
+		Pool:      p,
+		Total:     total,
+		Reserved:  reserved,
+		Allocated: allocated,
+		Free:      free,
+	}
+}
+
+func (p *IPPool) reservedRange(addr netip.Addr) (IPRange, bool) {
+	for _, r := range p.ReservedRanges {
+		if r.Contains(addr) {
+			return r, true
+		}
+	}
+	return IPRange{}, false
+}
+
+// lastAddr возвращает последний адрес подсети (все биты хоста единицы)
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr()
+	b := addr.AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+	last, _ := netip.AddrFromSlice(b)
+	return last
+}
+
+// rangeSize количество адресов в [start, end], с насыщением до MaxUint64 для больших IPv6 подсетей
+func rangeSize(start, end netip.Addr) uint64 {
+	if start.Compare(end) > 0 {
+		return 0
+	}
+	size := new(big.Int).Sub(new(big.Int).SetBytes(end.AsSlice()), new(big.Int).SetBytes(start.AsSlice()))
+	size.Add(size, big.NewInt(1))
+	if !size.IsUint64() {
+		return math.MaxUint64
+	}
+	return size.Uint64()
+}
+
+func saturatingAdd(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
+func minAddr(a, b netip.Addr) netip.Addr {
+	if a.Compare(b) < 0 {
+		return a
+	}
+	return b
+}
+
+func maxAddr(a, b netip.Addr) netip.Addr {
+	if a.Compare(b) > 0 {
+		return a
+	}
+	return b
+}
+
+// IPPoolFilter - фильтр списка пулов
+type IPPoolFilter struct {
+	// NodeID nil - пулы всех нод
+	NodeID *int32
+	// Family IPFamilyV4 или IPFamilyV6, 0 - оба семейства
+	Family int32
+}
@@ -0,0 +1,185 @@
+package models
+
+import (
+	"math"
+	"net/netip"
+	"testing"
+)
+
+func testPool(subnet, gateway string, reserved ...string) *IPPool {
+	p := &IPPool{Subnet: netip.MustParsePrefix(subnet)}
+	if gateway != "" {
+		p.Gateway = netip.MustParseAddr(gateway)
+	}
+	for i := 0; i+1 < len(reserved); i += 2 {
+		p.ReservedRanges = append(p.ReservedRanges, IPRange{
+			Start: netip.MustParseAddr(reserved[i]),
+			End:   netip.MustParseAddr(reserved[i+1]),
+		})
+	}
+	return p
+}
+
+func addrSet(addrs ...string) map[netip.Addr]bool {
+	set := make(map[netip.Addr]bool, len(addrs))
+	for _, a := range addrs {
+		set[netip.MustParseAddr(a)] = true
+	}
+	return set
+}
+
+func TestIPPoolNextFree(t *testing.T) {
+	tests := []struct {
+		name      string
+		pool      *IPPool
+		allocated map[netip.Addr]bool
+		want      string
+	}{
+		{"gateway first", testPool("10.0.0.0/24", "10.0.0.1"), nil, "10.0.0.2"},
+		{"gateway inside the range", testPool("10.0.0.0/24", "10.0.0.3"), addrSet("10.0.0.1", "10.0.0.2"), "10.0.0.4"},
+		{"reserved at subnet start", testPool("10.0.0.0/24", "10.0.0.1", "10.0.0.0", "10.0.0.10"), nil, "10.0.0.11"},
+		{"gateway after reserved range", testPool("10.0.0.0/24", "10.0.0.11", "10.0.0.1", "10.0.0.10"), nil, "10.0.0.12"},
+		{"reserved up to broadcast", testPool("10.0.0.0/29", "10.0.0.1", "10.0.0.4", "10.0.0.7"), addrSet("10.0.0.2"), "10.0.0.3"},
+		{"adjacent reserved ranges", testPool("10.0.0.0/24", "", "10.0.0.1", "10.0.0.5", "10.0.0.6", "10.0.0.9"), nil, "10.0.0.10"},
+		{"ipv4 /31 uses both addresses", testPool("10.0.0.0/31", "10.0.0.0"), nil, "10.0.0.1"},
+		{"ipv4 /32", testPool("10.0.0.7/32", ""), nil, "10.0.0.7"},
+		{"ipv6 skips subnet-router anycast", testPool("2001:db8::/64", "2001:db8::1"), nil, "2001:db8::2"},
+		{"ipv6 /127 uses both addresses", testPool("2001:db8::/127", "2001:db8::"), nil, "2001:db8::1"},
+		{"ipv6 /128", testPool("2001:db8::5/128", ""), nil, "2001:db8::5"},
+		{"ipv6 reserved at subnet end", testPool("2001:db8::/126", "", "2001:db8::2", "2001:db8::3"), addrSet("2001:db8::1"), ""},
+		{"full pool", testPool("10.0.0.0/30", "10.0.0.1"), addrSet("10.0.0.2"), ""},
+		{"all reserved", testPool("10.0.0.0/24", "", "10.0.0.0", "10.0.0.255"), nil, ""},
+		{"reserved to end of address space", testPool("255.255.255.252/30", "", "255.255.255.253", "255.255.255.255"), nil, ""},
+		{"ipv6 /128 taken", testPool("2001:db8::5/128", ""), addrSet("2001:db8::5"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.pool.NextFree(tt.allocated)
+			if tt.want == "" {
+				if ok {
+					t.Fatalf("NextFree() = %s, want no free address", got)
+				}
+				return
+			}
+			if !ok || got != netip.MustParseAddr(tt.want) {
+				t.Fatalf("NextFree() = %s, %v, want %s", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestIPPoolIsAssignable(t *testing.T) {
+	v4 := testPool("10.0.0.0/24", "10.0.0.1", "10.0.0.200", "10.0.0.255")
+	v6 := testPool("2001:db8::/64", "2001:db8::1", "2001:db8::100", "2001:db8::1ff")
+
+	tests := []struct {
+		pool *IPPool
+		addr string
+		want bool
+	}{
+		{v4, "10.0.0.2", true},
+		{v4, "10.0.0.199", true},
+		{v4, "10.0.0.0", false},
+		{v4, "10.0.0.1", false},
+		{v4, "10.0.0.200", false},
+		{v4, "10.0.0.254", false},
+		{v4, "10.0.0.255", false},
+		{v4, "10.0.1.2", false},
+		{v4, "2001:db8::2", false},
+		{v6, "2001:db8::2", true},
+		{v6, "2001:db8::ffff:ffff:ffff:ffff", true},
+		{v6, "2001:db8::", false},
+		{v6, "2001:db8::1", false},
+		{v6, "2001:db8::150", false},
+		{v6, "2001:db8:1::2", false},
+		{testPool("10.0.0.0/31", ""), "10.0.0.0", true},
+		{testPool("2001:db8::/127", ""), "2001:db8::", true},
+		{testPool("2001:db8::5/128", ""), "2001:db8::5", true},
+		{testPool("2001:db8::5/128", "2001:db8::5"), "2001:db8::5", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.pool.IsAssignable(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("IsAssignable(%s) in %s = %v, want %v", tt.addr, tt.pool.Subnet, got, tt.want)
+		}
+	}
+}
+
+func TestIPPoolUsage(t *testing.T) {
+	tests := []struct {
+		name      string
+		pool      *IPPool
+		allocated uint64
+		want      IPPoolUsage
+		wantPct   float64
+	}{
+		{
+			name:      "gateway only",
+			pool:      testPool("10.0.0.0/24", "10.0.0.1"),
+			allocated: 10,
+			want:      IPPoolUsage{Total: 254, Reserved: 1, Allocated: 10, Free: 243},
+			wantPct:   10.0 / 253 * 100,
+		},
+		{
+			name: "reserved ranges clipped to usable addresses",
+			// .0 и .255 не выдаются и не считаются зарезервированными, шлюз .1 внутри диапазона
+			pool:    testPool("10.0.0.0/24", "10.0.0.1", "10.0.0.0", "10.0.0.9", "10.0.0.250", "10.0.0.255"),
+			want:    IPPoolUsage{Total: 254, Reserved: 9 + 5, Free: 240},
+			wantPct: 0,
+		},
+		{
+			name:    "gateway inside reserved range counted once",
+			pool:    testPool("10.0.0.0/24", "10.0.0.5", "10.0.0.1", "10.0.0.10"),
+			want:    IPPoolUsage{Total: 254, Reserved: 10, Free: 244},
+			wantPct: 0,
+		},
+		{
+			name:      "full pool",
+			pool:      testPool("10.0.0.0/30", "10.0.0.1"),
+			allocated: 1,
+			want:      IPPoolUsage{Total: 2, Reserved: 1, Allocated: 1, Free: 0},
+			wantPct:   100,
+		},
+		{
+			name:    "all reserved",
+			pool:    testPool("10.0.0.0/30", "10.0.0.1", "10.0.0.2", "10.0.0.2"),
+			want:    IPPoolUsage{Total: 2, Reserved: 2, Free: 0},
+			wantPct: 100,
+		},
+		{
+			name:    "ipv6 /127",
+			pool:    testPool("2001:db8::/127", "2001:db8::"),
+			want:    IPPoolUsage{Total: 2, Reserved: 1, Free: 1},
+			wantPct: 0,
+		},
+		{
+			name:      "ipv6 /128",
+			pool:      testPool("2001:db8::5/128", ""),
+			allocated: 1,
+			want:      IPPoolUsage{Total: 1, Allocated: 1, Free: 0},
+			wantPct:   100,
+		},
+		{
+			name:      "ipv6 /64 saturates",
+			pool:      testPool("2001:db8::/64", "2001:db8::1"),
+			allocated: 3,
+			want:      IPPoolUsage{Total: math.MaxUint64, Reserved: 1, Allocated: 3, Free: math.MaxUint64 - 4},
+			wantPct:   3 / float64(math.MaxUint64-1) * 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pool.Usage(tt.allocated)
+			if got.Total != tt.want.Total || got.Reserved != tt.want.Reserved ||
+				got.Allocated != tt.want.Allocated || got.Free != tt.want.Free {
+				t.Fatalf("Usage() = total %d, reserved %d, allocated %d, free %d, want %+v",
+					got.Total, got.Reserved, got.Allocated, got.Free, tt.want)
+			}
+			if pct := got.UsagePct(); math.Abs(pct-tt.wantPct) > 1e-9 {
+				t.Fatalf("UsagePct() = %v, want %v", pct, tt.wantPct)
+			}
+		})
+	}
+}
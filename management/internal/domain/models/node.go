@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// Node - доменная модель Proxmox ноды (физического сервера)
+type Node struct {
+	ID        int32
+	Name      string
+	APIURL    string
+	MaxCPU    int32
+	MaxRAM    int32
+	MaxDisk   int32
+	Region    string
+	Labels    map[string]string
+	IsActive  bool
+	CreatedAt time.Time
+}
+
+// CreateNodeRequest - запрос на создание ноды
+type CreateNodeRequest struct {
+	Name    string
+	APIURL  string
+	MaxCPU  int32
+	MaxRAM  int32
+	MaxDisk int32
+	Region  string
+	Labels  map[string]string
+}
+
+// UpdateNodeRequest - запрос на обновление ноды
+type UpdateNodeRequest struct {
+	ID      int32
+	APIURL  *string
+	MaxCPU  *int32
+	MaxRAM  *int32
+	MaxDisk *int32
+	Region  *string
+	// Labels заменяет метки ноды целиком. nil - не менять
+	Labels   map[string]string
+	IsActive *bool
+}
+
+// NodeUtilization - статистика использования ресурсов ноды (view node_utilization)
+type NodeUtilization struct {
+	NodeID       int32
+	NodeName     string
+	MaxCPU       int32
+	MaxRAM       int32
+	MaxDisk      int32
+	VDSCount     int32
+	UsedCPU      int32
+	UsedRAM      int32
+	UsedDisk     int32
+	CPUUsagePct  float64
+	RAMUsagePct  float64
+	DiskUsagePct float64
+}
+
+// FreeCPU количество свободных vCPU ноды
+func (u *NodeUtilization) FreeCPU() int32 { return u.MaxCPU - u.UsedCPU }
+
+// FreeRAM свободная память ноды в MB
+func (u *NodeUtilization) FreeRAM() int32 { return u.MaxRAM - u.UsedRAM }
+
+// FreeDisk свободный диск ноды в GB
+func (u *NodeUtilization) FreeDisk() int32 { return u.MaxDisk - u.UsedDisk }
+
+// NodeFilter - фильтр списка нод
+type NodeFilter struct {
+	ActiveOnly bool
+	// Region пусто - все регионы
+	Region  string
+	Created TimeRange
+}
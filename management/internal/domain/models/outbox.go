@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// Типы доменных событий outbox. События пишут триггеры БД, см. миграцию 000013_outbox
+const (
+	EventVDSCreated        = "VDSCreated"
+	EventVDSStatusChanged  = "VDSStatusChanged"
+	EventVDSRenewed        = "VDSRenewed"
+	EventVDSRenewalFailed  = "VDSRenewalFailed"
+	EventVDSSuspended      = "VDSSuspended"
+	EventVDSResumed        = "VDSResumed"
+	EventVDSDeletionQueued = "VDSDeletionQueued"
+
+	EventTaskCreated   = "TaskCreated"
+	EventTaskStarted   = "TaskStarted"
+	EventTaskCompleted = "TaskCompleted"
+	EventTaskFailed    = "TaskFailed"
+
+	EventPlanCreated      = "PlanCreated"
+	EventPlanUpdated      = "PlanUpdated"
+	EventPlanPriceChanged = "PlanPriceChanged"
+	EventPlanArchived     = "PlanArchived"
+	EventPlanDeleted      = "PlanDeleted"
+)
+
+// EventTypes все типы доменных событий
+var EventTypes = []string{
+	EventVDSCreated, EventVDSStatusChanged, EventVDSRenewed, EventVDSRenewalFailed,
+	EventVDSSuspended, EventVDSResumed, EventVDSDeletionQueued,
+	EventTaskCreated, EventTaskStarted, EventTaskCompleted, EventTaskFailed,
+	EventPlanCreated, EventPlanUpdated, EventPlanPriceChanged, EventPlanArchived, EventPlanDeleted,
+}
+
+// IsKnownEvent проверяет, что тип события существует
+func IsKnownEvent(eventType string) bool {
+	return slices.Contains(EventTypes, eventType)
+}
+
+// OutboxEvent - доменное событие, ожидающее публикации
+type OutboxEvent struct {
+	ID            int64
+	Type          string
+	AggregateType string
+	AggregateID   int64
+	// OrderingKey события с одним ключом публикуются строго по порядку ID:
+	// vds:<id> для VDS и её задач, plan:<id> для тарифов
+	OrderingKey string
+	// Payload состояние сущности после изменения в JSON
+	Payload   json.RawMessage
+	CreatedAt time.Time
+	// Attempts число неудачных попыток публикации
+	Attempts int32
+}
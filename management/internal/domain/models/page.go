@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	// DefaultPageSize размер страницы списка, если клиент его не указал
+	DefaultPageSize = 50
+	// MaxPageSize наибольший допустимый размер страницы
+	MaxPageSize = 500
+)
+
+// PageRequest параметры страницы списка
+type PageRequest struct {
+	// Size размер страницы, 0 - DefaultPageSize
+	Size int32
+	// Token непрозрачный курсор из NextPageToken предыдущей страницы, пусто - первая страница
+	Token string
+	// OrderBy поле сортировки и направление, например "created_at desc". Пусто - порядок по умолчанию
+	OrderBy string
+}
+
+// TimeRange фильтр по времени: From включительно, To не включительно. nil - без границы
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
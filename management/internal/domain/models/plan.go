@@ -12,6 +12,13 @@ type Plan struct {
 	PriceMonth float64
 	IsActive   bool
 	CreatedAt  time.Time
+	// DeletedAt время архивации. nil - план не в архиве
+	DeletedAt *time.Time
+}
+
+// IsArchived план снят с продажи и скрыт из списка, но хранится для истории биллинга
+func (p *Plan) IsArchived() bool {
+	return p.DeletedAt != nil
 }
 
 // CreatePlanRequest - запрос на создание плана
@@ -33,3 +40,12 @@ type UpdatePlanRequest struct {
 	PriceMonth *float64
 	IsActive   *bool
 }
+
+// PlanFilter - фильтр списка планов. Архивные планы в список не попадают
+type PlanFilter struct {
+	ActiveOnly bool
+	// MinPrice, MaxPrice диапазон цены за месяц включительно. nil - без границы
+	MinPrice *float64
+	MaxPrice *float64
+	Created  TimeRange
+}
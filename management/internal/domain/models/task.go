@@ -0,0 +1,109 @@
+package models
+
+import "time"
+
+// TaskType - тип фоновой задачи над VDS
+type TaskType string
+
+const (
+	TaskTypeCreate  TaskType = "create"
+	TaskTypeDelete  TaskType = "delete"
+	TaskTypeStart   TaskType = "start"
+	TaskTypeStop    TaskType = "stop"
+	TaskTypeRestart TaskType = "restart"
+	// TaskTypePowerOff немедленно останавливает VM без корректного выключения ОС
+	TaskTypePowerOff TaskType = "poweroff"
+)
+
+// IsValid проверяет, что тип задачи известен
+func (t TaskType) IsValid() bool {
+	switch t {
+	case TaskTypeCreate, TaskTypeDelete, TaskTypeStart, TaskTypeStop, TaskTypeRestart, TaskTypePowerOff:
+		return true
+	}
+	return false
+}
+
+// IsPowerAction проверяет, что задача только включает, выключает или перезагружает VM
+func (t TaskType) IsPowerAction() bool {
+	_, ok := vdsPowerStates[t]
+	return ok
+}
+
+// TaskStatus - статус фоновой задачи
+type TaskStatus string
+
+const (
+	TaskStatusPending TaskStatus = "pending"
+	TaskStatusRunning TaskStatus = "running"
+	TaskStatusDone    TaskStatus = "done"
+	TaskStatusError   TaskStatus = "error"
+)
+
+// IsValid проверяет, что статус известен
+func (s TaskStatus) IsValid() bool {
+	switch s {
+	case TaskStatusPending, TaskStatusRunning, TaskStatusDone, TaskStatusError:
+		return true
+	}
+	return false
+}
+
+// TaskErrorCode - класс ошибки, с которой завершилась задача
+type TaskErrorCode string
+
+const (
+	TaskErrorCodeInternal TaskErrorCode = "internal"
+	TaskErrorCodeProxmox  TaskErrorCode = "proxmox_error"
+)
+
+// taskTransitions допустимые переходы статусов задачи:
+// pending -> running | error (отмена до запуска), running -> done | error
+var taskTransitions = map[TaskStatus][]TaskStatus{
+	TaskStatusPending: {TaskStatusRunning, TaskStatusError},
+	TaskStatusRunning: {TaskStatusDone, TaskStatusError},
+}
+
+// CanTransitionTo проверяет, допустим ли переход задачи из статуса s в next
+func (s TaskStatus) CanTransitionTo(next TaskStatus) bool {
+	for _, allowed := range taskTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal проверяет, что задача завершена (успешно или с ошибкой)
+func (s TaskStatus) IsFinal() bool {
+	return s == TaskStatusDone || s == TaskStatusError
+}
+
+// Task - доменная модель фоновой задачи
+type Task struct {
+	ID          int32
+	VDSID       int32
+	Type        TaskType
+	Status      TaskStatus
+	Error       string
+	ErrorCode   TaskErrorCode
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+// UpdateTaskStatusRequest - запрос на смену статуса задачи
+type UpdateTaskStatusRequest struct {
+	ID        int32
+	Status    TaskStatus
+	Error     *string
+	ErrorCode TaskErrorCode
+}
+
+// TaskFilter - фильтр списка задач VDS
+type TaskFilter struct {
+	VDSID   int32
+	Status  *TaskStatus
+	Type    *TaskType
+	Created TimeRange
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// VDSStatus - статус VDS (значения совпадают с CHECK в таблице vds)
+type VDSStatus string
+
+const (
+	VDSStatusCreating VDSStatus = "creating"
+	VDSStatusRunning  VDSStatus = "running"
+	VDSStatusStopped  VDSStatus = "stopped"
+	VDSStatusError    VDSStatus = "error"
+	VDSStatusDeleting VDSStatus = "deleting"
+	// VDSStatusDeleted VM удалена, строка хранится для истории
+	VDSStatusDeleted VDSStatus = "deleted"
+)
+
+// IsValid проверяет, что статус известен
+func (s VDSStatus) IsValid() bool {
+	switch s {
+	case VDSStatusCreating, VDSStatusRunning, VDSStatusStopped, VDSStatusError, VDSStatusDeleting, VDSStatusDeleted:
+		return true
+	}
+	return false
+}
+
+// vdsPowerStates статусы VDS, из которых допустима операция питания. Из error можно
+// запустить и выключить VM, чтобы вернуть её в известное состояние. Создаваемая,
+// удаляемая и удалённая VDS питанием не управляются
+var vdsPowerStates = map[TaskType][]VDSStatus{
+	TaskTypeStart:    {VDSStatusStopped, VDSStatusError},
+	TaskTypeStop:     {VDSStatusRunning},
+	TaskTypeRestart:  {VDSStatusRunning},
+	TaskTypePowerOff: {VDSStatusRunning, VDSStatusError},
+}
+
+// CanPower проверяет, допустима ли операция питания action для VDS в статусе s
+func (s VDSStatus) CanPower(action TaskType) bool {
+	return slices.Contains(vdsPowerStates[action], s)
+}
+
+// BillingStatus - состояние резервирования оплаты VDS в SSO
+type BillingStatus string
+
+const (
+	// BillingStatusNone VDS создана без оплаты
+	BillingStatusNone      BillingStatus = ""
+	BillingStatusReserved  BillingStatus = "reserved"
+	BillingStatusCommitted BillingStatus = "committed"
+	BillingStatusCancelled BillingStatus = "cancelled"
+)
+
+// VDS - доменная модель виртуального сервера
+type VDS struct {
+	ID            int32
+	UserID        int32
+	PlanID        int32
+	NodeID        int32
+	ProxmoxVMID   int32
+	Status        VDSStatus
+	IPv4          string
+	IPv6          string
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	ReservationID string
+	// BillingAmount зарезервированная сумма в копейках
+	BillingAmount int64
+	BillingStatus BillingStatus
+	// SuspendedAt время остановки VDS из-за неоплаты, nil - VDS не приостановлена
+	SuspendedAt *time.Time
+	// AutoRenew подписка продлевается планировщиком по сохранённой авторизации владельца
+	AutoRenew bool
+}
+
+// CreateVDSRequest - запрос на создание VDS
+type CreateVDSRequest struct {
+	UserID int32
+	PlanID int32
+	// NodeID 0 - нода выбирается автоматически с учётом Region и NodeLabels
+	NodeID     int32
+	ExpiresAt  time.Time
+	Region     string
+	NodeLabels map[string]string
+	// RequestID клиентский ключ запроса, из которого выводится ключ идемпотентности оплаты
+	RequestID string
+	// AccessToken токен пользователя, с баланса которого резервируется оплата
+	AccessToken string
+	// PayerID пользователь, которому принадлежит AccessToken
+	PayerID int32
+	// ReservationID и BillingAmount заполняются сервисом после резервирования оплаты
+	ReservationID string
+	BillingAmount int64
+}
+
+// RenewVDSRequest - запрос на продление подписки VDS
+type RenewVDSRequest struct {
+	VDSID int32
+	// AccessToken токен пользователя, с баланса которого резервируется оплата
+	AccessToken string
+	// PayerID пользователь, которому принадлежит AccessToken
+	PayerID int32
+}
+
+// SetAutoRenewRequest - запрос на включение или отключение автопродления подписки VDS
+type SetAutoRenewRequest struct {
+	VDSID   int32
+	Enabled bool
+	// RefreshToken refresh token владельца VDS, обязателен при включении
+	RefreshToken string
+}
+
+// BillingSettlement - резервирование VDS, итог которого ещё не отправлен в SSO
+type BillingSettlement struct {
+	VDSID         int32
+	ReservationID string
+	// Commit true - задача create завершилась успешно и оплату нужно списать, иначе отменить
+	Commit bool
+	// Renewal резервирование оплачивает продление подписки, а не создание VDS
+	Renewal bool
+}
+
+// AllocateIPRequest - запрос на назначение IP адресов VDS
+type AllocateIPRequest struct {
+	VDSID int32
+	IPv4  string
+	IPv6  string
+}
+
+// VDSFilter - фильтр списка VDS пользователя. Без Status удалённые VDS в список не попадают
+type VDSFilter struct {
+	UserID  int32
+	Status  *VDSStatus
+	NodeID  *int32
+	PlanID  *int32
+	Created TimeRange
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// VDSEventType - тип события жизненного цикла подписки VDS
+type VDSEventType string
+
+const (
+	// VDSEventRenewed подписка продлена, оплата списана
+	VDSEventRenewed VDSEventType = "renewed"
+	// VDSEventRenewalFailed продлить подписку не удалось (например, не хватило средств)
+	VDSEventRenewalFailed VDSEventType = "renewal_failed"
+	// VDSEventSuspended VDS остановлена из-за неоплаты после grace периода
+	VDSEventSuspended VDSEventType = "suspended"
+	// VDSEventResumed приостановленная VDS запущена после продления
+	VDSEventResumed VDSEventType = "resumed"
+	// VDSEventDeletionQueued VDS поставлена на удаление после окончания срока хранения
+	VDSEventDeletionQueued VDSEventType = "deletion_queued"
+)
+
+// VDSEvent - запись журнала жизненного цикла VDS
+type VDSEvent struct {
+	ID        int64
+	VDSID     int32
+	Type      VDSEventType
+	Message   string
+	Details   map[string]string
+	CreatedAt time.Time
+}
+
+// VDSEventFilter - фильтр журнала событий VDS
+type VDSEventFilter struct {
+	VDSID   int32
+	Type    *VDSEventType
+	Created TimeRange
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook - подписка на доменные события
+type Webhook struct {
+	ID int32
+	// UserID владелец: получает события своих VDS и их задач и публичные события тарифов
+	UserID int32
+	URL    string
+	// Secret ключ HMAC подписи доставок
+	Secret string
+	// EventTypes типы событий, пусто - все события
+	EventTypes []string
+	IsActive   bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// CreateWebhookRequest - запрос на создание подписки. Пустой Secret генерируется
+type CreateWebhookRequest struct {
+	UserID     int32
+	URL        string
+	Secret     string
+	EventTypes []string
+}
+
+// UpdateWebhookRequest - запрос на изменение подписки, nil поля не меняются
+type UpdateWebhookRequest struct {
+	ID     int32
+	URL    *string
+	Secret *string
+	// EventTypes новый список типов, пустой не nil список - все события
+	EventTypes []string
+	IsActive   *bool
+}
+
+// WebhookFilter - фильтр списка подписок
+type WebhookFilter struct {
+	UserID     *int32
+	ActiveOnly bool
+}
+
+// WebhookDeliveryStatus - статус доставки события
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending доставка ждёт первой или очередной попытки
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryDelivered получатель ответил 2xx
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryDead попытки исчерпаны
+	WebhookDeliveryDead WebhookDeliveryStatus = "dead"
+)
+
+// IsValid проверяет, что статус доставки известен
+func (s WebhookDeliveryStatus) IsValid() bool {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryDead:
+		return true
+	}
+	return false
+}
+
+// WebhookDelivery - доставка события подписке
+type WebhookDelivery struct {
+	ID        int64
+	WebhookID int32
+	EventID   int64
+	EventType string
+	// Payload тело запроса
+	Payload  json.RawMessage
+	Status   WebhookDeliveryStatus
+	Attempts int32
+	// LastStatusCode HTTP статус последней попытки, 0 - ответа не было
+	LastStatusCode int32
+	LastError      string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// WebhookDispatch - доставка, взятая в работу, с адресом и ключом подписки
+type WebhookDispatch struct {
+	Delivery *WebhookDelivery
+	URL      string
+	Secret   string
+}
+
+// WebhookDeliveryFilter - фильтр журнала доставок подписки
+type WebhookDeliveryFilter struct {
+	WebhookID int32
+	Status    *WebhookDeliveryStatus
+	Created   TimeRange
+}
@@ -0,0 +1,260 @@
+package expiry
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/makhtech/management/internal/billing"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// Config конфигурация планировщика подписок
+type Config struct {
+	// Interval пауза между проходами планировщика
+	Interval time.Duration
+	// RenewBefore за сколько до окончания подписки начинаются попытки автопродления
+	RenewBefore time.Duration
+	// RetryAfter пауза перед повторным автопродлением после неудачной попытки
+	RetryAfter time.Duration
+	// GracePeriod сколько VDS работает после окончания подписки до остановки
+	GracePeriod time.Duration
+	// Retention сколько остановленная за неоплату VDS хранится до удаления
+	Retention time.Duration
+	// BatchSize сколько VDS обрабатывается на каждом шаге за один проход
+	BatchSize int
+}
+
+// Renewer продлевает подписку VDS от имени владельца по сохранённой авторизации автопродления
+type Renewer interface {
+	AutoRenew(ctx context.Context, vds *models.VDS) (*models.VDS, error)
+}
+
+// Scheduler продлевает подписку VDS с включённым автопродлением, приостанавливает VDS
+// с неоплаченной подпиской и удаляет их после срока хранения. SSO резервирует средства только
+// на балансе владельца токена, поэтому оплата продления резервируется по refresh token,
+// который владелец сохранил через SetVDSAutoRenew. Все переходы записываются в журнал событий VDS
+type Scheduler struct {
+	vdsRepo repository.VDSRepository
+	renewer Renewer
+	cfg     Config
+	log     *slog.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New создаёт планировщик подписок
+func New(vdsRepo repository.VDSRepository, renewer Renewer, cfg Config, log *slog.Logger) *Scheduler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Minute
+	}
+	if cfg.RenewBefore < 0 {
+		cfg.RenewBefore = 0
+	}
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = cfg.Interval
+	}
+	if cfg.GracePeriod < 0 {
+		cfg.GracePeriod = 0
+	}
+	if cfg.Retention < 0 {
+		cfg.Retention = 0
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+
+	return &Scheduler{
+		vdsRepo: vdsRepo,
+		renewer: renewer,
+		cfg:     cfg,
+		log:     log,
+	}
+}
+
+// Start запускает периодическую обработку подписок
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(1)
+	go s.run(ctx)
+
+	s.log.Info("subscription scheduler started",
+		slog.Duration("interval", s.cfg.Interval),
+		slog.Duration("renew_before", s.cfg.RenewBefore),
+		slog.Duration("retry_after", s.cfg.RetryAfter),
+		slog.Duration("grace_period", s.cfg.GracePeriod),
+		slog.Duration("retention", s.cfg.Retention),
+	)
+}
+
+// Stop останавливает планировщик и дожидается текущего прохода
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+
+	s.log.Info("subscription scheduler stopped")
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+// tick выполняет один проход: автопродление, приостановка, затем удаление
+func (s *Scheduler) tick(ctx context.Context) {
+	now := time.Now()
+
+	s.renewAll(ctx, now)
+	s.suspendAll(ctx, now)
+	s.deleteAll(ctx, now)
+}
+
+func (s *Scheduler) renewAll(ctx context.Context, now time.Time) {
+	list, err := s.vdsRepo.ClaimRenewable(ctx, now.Add(s.cfg.RenewBefore), s.cfg.RetryAfter, s.cfg.BatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.Error("failed to list vds for auto-renewal", slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	for _, vds := range list {
+		if ctx.Err() != nil {
+			return
+		}
+
+		log := s.log.With(slog.Int("vds_id", int(vds.ID)), slog.Time("expires_at", vds.ExpiresAt))
+
+		renewed, err := s.renewer.AutoRenew(ctx, vds)
+		if err != nil {
+			switch {
+			case errors.Is(err, repository.ErrInsufficientFunds):
+				log.Warn("insufficient funds for auto-renewal", slog.Duration("retry_after", s.cfg.RetryAfter))
+			case errors.Is(err, billing.ErrAutoRenewRevoked):
+				log.Warn("auto-renewal disabled: owner authorization is no longer valid")
+			case errors.Is(err, repository.ErrSubscriptionChanged):
+				// Подписку успели продлить или удалить - автопродление больше не нужно
+				log.Debug("vds auto-renewal skipped", slog.String("reason", err.Error()))
+			default:
+				log.Error("failed to auto-renew vds",
+					slog.String("error", err.Error()),
+					slog.Duration("retry_after", s.cfg.RetryAfter),
+				)
+			}
+			continue
+		}
+
+		log.Info("vds subscription auto-renewed", slog.Time("renewed_until", renewed.ExpiresAt))
+	}
+}
+
+func (s *Scheduler) suspendAll(ctx context.Context, now time.Time) {
+	expiredBefore := now.Add(-s.cfg.GracePeriod)
+
+	list, err := s.vdsRepo.ListSuspendable(ctx, expiredBefore, s.cfg.BatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.Error("failed to list suspendable vds", slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	for _, vds := range list {
+		if ctx.Err() != nil {
+			return
+		}
+
+		log := s.log.With(slog.Int("vds_id", int(vds.ID)), slog.Time("expires_at", vds.ExpiresAt))
+
+		message := "subscription expired and was not paid within the grace period"
+		if vds.Status == models.VDSStatusRunning {
+			message += ", vds is being stopped"
+		}
+
+		task, err := s.vdsRepo.Suspend(ctx, vds.ID, expiredBefore, &models.VDSEvent{
+			VDSID:   vds.ID,
+			Type:    models.VDSEventSuspended,
+			Message: message,
+			Details: map[string]string{
+				"expires_at":   vds.ExpiresAt.UTC().Format(time.RFC3339),
+				"grace_period": s.cfg.GracePeriod.String(),
+			},
+		})
+		if err != nil {
+			// Задача по VDS ещё выполняется или подписку успели продлить - вернёмся на следующем проходе
+			if errors.Is(err, repository.ErrTaskInProgress) || errors.Is(err, repository.ErrSubscriptionChanged) {
+				log.Debug("vds suspension skipped", slog.String("reason", err.Error()))
+				continue
+			}
+			log.Error("failed to suspend vds", slog.String("error", err.Error()))
+			continue
+		}
+
+		if task != nil {
+			log = log.With(slog.Int("stop_task_id", int(task.ID)))
+		}
+		log.Info("vds suspended for non-payment")
+	}
+}
+
+func (s *Scheduler) deleteAll(ctx context.Context, now time.Time) {
+	expiredBefore := now.Add(-s.cfg.GracePeriod - s.cfg.Retention)
+
+	list, err := s.vdsRepo.ListDeletable(ctx, expiredBefore, s.cfg.BatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.Error("failed to list expired vds", slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	for _, vds := range list {
+		if ctx.Err() != nil {
+			return
+		}
+
+		log := s.log.With(slog.Int("vds_id", int(vds.ID)), slog.Time("expires_at", vds.ExpiresAt))
+
+		task, err := s.vdsRepo.QueueExpiredDeletion(ctx, vds.ID, expiredBefore, &models.VDSEvent{
+			VDSID:   vds.ID,
+			Type:    models.VDSEventDeletionQueued,
+			Message: "subscription was not renewed within the retention period, vds is being deleted",
+			Details: map[string]string{
+				"expires_at": vds.ExpiresAt.UTC().Format(time.RFC3339),
+				"retention":  s.cfg.Retention.String(),
+			},
+		})
+		if err != nil {
+			if errors.Is(err, repository.ErrTaskInProgress) ||
+				errors.Is(err, repository.ErrSubscriptionChanged) ||
+				errors.Is(err, repository.ErrVDSNotFound) {
+				log.Debug("vds deletion skipped", slog.String("reason", err.Error()))
+				continue
+			}
+			log.Error("failed to queue expired vds deletion", slog.String("error", err.Error()))
+			continue
+		}
+
+		log.Info("expired vds queued for deletion", slog.Int("delete_task_id", int(task.ID)))
+	}
+}
@@ -0,0 +1,221 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	managementv1 "github.com/makhtech/proto/gen/go/management"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// requestIDHeader заголовок с ID запроса, попадает в журнал аудита
+	requestIDHeader = "x-request-id"
+	// idempotencyKeyHeader ключ идемпотентности изменяющего запроса
+	idempotencyKeyHeader = "idempotency-key"
+	// idempotentReplayedHeader признак ответа, отданного повторно по ключу идемпотентности
+	idempotentReplayedHeader = "idempotent-replayed"
+)
+
+// Gateway REST/JSON прокси к Management API. Запросы уходят на gRPC порт этого же сервиса,
+// поэтому аутентификация, политика доступа, rate limiting и метрики общие с gRPC.
+// Заголовок Authorization передаётся в gRPC metadata как есть
+type Gateway struct {
+	conn    *grpc.ClientConn
+	handler http.Handler
+}
+
+// New создаёт gateway к gRPC серверу по адресу grpcAddr
+func New(ctx context.Context, grpcAddr string, log *slog.Logger) (*Gateway, error) {
+	const op = "gateway.New"
+
+	conn, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+		runtime.WithErrorHandler(errorHandler(log)),
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+	)
+
+	if err := managementv1.RegisterManagementHandler(ctx, mux, conn); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Gateway{conn: conn, handler: mux}, nil
+}
+
+// Handler возвращает HTTP обработчик REST API
+func (g *Gateway) Handler() http.Handler {
+	return g.handler
+}
+
+// Close закрывает соединение с gRPC сервером
+func (g *Gateway) Close() error {
+	return g.conn.Close()
+}
+
+// incomingHeader пробрасывает в gRPC metadata стандартные заголовки, X-Request-Id и Idempotency-Key
+func incomingHeader(key string) (string, bool) {
+	switch {
+	case strings.EqualFold(key, requestIDHeader):
+		return requestIDHeader, true
+	case strings.EqualFold(key, idempotencyKeyHeader):
+		return idempotencyKeyHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeader возвращает x-request-id и idempotent-replayed как есть,
+// остальную metadata - с префиксом Grpc-Metadata-
+func outgoingHeader(key string) (string, bool) {
+	switch key {
+	case requestIDHeader:
+		return "X-Request-Id", true
+	case idempotentReplayedHeader:
+		return "Idempotent-Replayed", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// errorBody тело ответа с ошибкой
+type errorBody struct {
+	// Code HTTP статус
+	Code int `json:"code"`
+	// ErrorCode код ошибки Management API (ERROR_CODE_*)
+	ErrorCode string `json:"error_code"`
+	Message   string `json:"message"`
+	Details   string `json:"details,omitempty"`
+}
+
+// errorHandler отдаёт ошибки gRPC в виде JSON. HTTP статус определяется по ErrorCode
+// из ErrorDetails, а без него - по коду gRPC
+func errorHandler(log *slog.Logger) runtime.ErrorHandlerFunc {
+	return func(
+		ctx context.Context,
+		_ *runtime.ServeMux,
+		_ runtime.Marshaler,
+		w http.ResponseWriter,
+		r *http.Request,
+		err error,
+	) {
+		st := status.Convert(err)
+
+		body := errorBody{
+			Code:      runtime.HTTPStatusFromCode(st.Code()),
+			ErrorCode: errorCodeFromStatus(st.Code()).String(),
+			Message:   st.Message(),
+		}
+		for _, d := range st.Details() {
+			if details, ok := d.(*managementv1.ErrorDetails); ok {
+				body.Code = httpStatusFromErrorCode(details.GetCode(), body.Code)
+				body.ErrorCode = details.GetCode().String()
+				body.Details = details.GetDetails()
+				break
+			}
+		}
+
+		// Ошибки маршрутизации gateway (неизвестный путь, неподдерживаемый метод)
+		var httpErr *runtime.HTTPStatusError
+		if errors.As(err, &httpErr) {
+			body.Code = httpErr.HTTPStatus
+		}
+
+		if body.Code >= http.StatusInternalServerError {
+			log.Warn("gateway request failed",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("code", st.Code().String()),
+				slog.String("error", st.Message()),
+			)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if body.Code == http.StatusUnauthorized {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+		}
+		w.WriteHeader(body.Code)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+// httpStatusFromErrorCode уточняет HTTP статус по коду ошибки Management API
+func httpStatusFromErrorCode(code managementv1.ErrorCode, fallback int) int {
+	switch code {
+	case managementv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT:
+		return http.StatusBadRequest
+	case managementv1.ErrorCode_ERROR_CODE_NOT_FOUND:
+		return http.StatusNotFound
+	case managementv1.ErrorCode_ERROR_CODE_ALREADY_EXISTS,
+		managementv1.ErrorCode_ERROR_CODE_PLAN_IN_USE,
+		managementv1.ErrorCode_ERROR_CODE_NODE_IN_USE,
+		managementv1.ErrorCode_ERROR_CODE_IP_POOL_IN_USE,
+		managementv1.ErrorCode_ERROR_CODE_TASK_IN_PROGRESS,
+		managementv1.ErrorCode_ERROR_CODE_INVALID_STATE,
+		managementv1.ErrorCode_ERROR_CODE_IDEMPOTENCY_CONFLICT:
+		return http.StatusConflict
+	case managementv1.ErrorCode_ERROR_CODE_UNAUTHENTICATED:
+		return http.StatusUnauthorized
+	case managementv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED:
+		return http.StatusForbidden
+	case managementv1.ErrorCode_ERROR_CODE_INSUFFICIENT_FUNDS,
+		managementv1.ErrorCode_ERROR_CODE_VDS_SUSPENDED:
+		return http.StatusPaymentRequired
+	case managementv1.ErrorCode_ERROR_CODE_RATE_LIMITED:
+		return http.StatusTooManyRequests
+	case managementv1.ErrorCode_ERROR_CODE_INSUFFICIENT_RESOURCES,
+		managementv1.ErrorCode_ERROR_CODE_IP_ALLOCATION_FAILED,
+		managementv1.ErrorCode_ERROR_CODE_UNAVAILABLE:
+		return http.StatusServiceUnavailable
+	case managementv1.ErrorCode_ERROR_CODE_PROXMOX_ERROR:
+		return http.StatusBadGateway
+	case managementv1.ErrorCode_ERROR_CODE_INTERNAL:
+		return http.StatusInternalServerError
+	default:
+		return fallback
+	}
+}
+
+// errorCodeFromStatus код ошибки для ответов без ErrorDetails
+func errorCodeFromStatus(code codes.Code) managementv1.ErrorCode {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return managementv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT
+	case codes.NotFound:
+		return managementv1.ErrorCode_ERROR_CODE_NOT_FOUND
+	case codes.AlreadyExists:
+		return managementv1.ErrorCode_ERROR_CODE_ALREADY_EXISTS
+	case codes.Unauthenticated:
+		return managementv1.ErrorCode_ERROR_CODE_UNAUTHENTICATED
+	case codes.PermissionDenied:
+		return managementv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED
+	case codes.Unavailable, codes.ResourceExhausted:
+		return managementv1.ErrorCode_ERROR_CODE_UNAVAILABLE
+	case codes.Internal, codes.DataLoss:
+		return managementv1.ErrorCode_ERROR_CODE_INTERNAL
+	default:
+		return managementv1.ErrorCode_ERROR_CODE_UNKNOWN
+	}
+}
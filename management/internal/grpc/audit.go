@@ -0,0 +1,363 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/netip"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/service"
+	managementv1 "github.com/makhtech/proto/gen/go/management"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Типы сущностей журнала аудита
+const (
+	auditEntityPlan    = "plan"
+	auditEntityNode    = "node"
+	auditEntityVDS     = "vds"
+	auditEntityTask    = "task"
+	auditEntityIPPool  = "ip_pool"
+	auditEntityWebhook = "webhook"
+)
+
+// requestIDHeader заголовок с ID запроса. Если клиент его не передал, ID генерируется
+// и возвращается в заголовке ответа
+const requestIDHeader = "x-request-id"
+
+// auditTimeout время на снимок состояния и запись в журнал после завершения вызова
+const auditTimeout = 5 * time.Second
+
+// auditedMethods изменяющие методы и тип сущности, которую они меняют
+var auditedMethods = map[string]string{
+	managementv1.Management_CreatePlan_FullMethodName:       auditEntityPlan,
+	managementv1.Management_UpdatePlan_FullMethodName:       auditEntityPlan,
+	managementv1.Management_DeletePlan_FullMethodName:       auditEntityPlan,
+	managementv1.Management_CreateNode_FullMethodName:       auditEntityNode,
+	managementv1.Management_UpdateNode_FullMethodName:       auditEntityNode,
+	managementv1.Management_DeleteNode_FullMethodName:       auditEntityNode,
+	managementv1.Management_CreateVDS_FullMethodName:        auditEntityVDS,
+	managementv1.Management_UpdateVDSStatus_FullMethodName:  auditEntityVDS,
+	managementv1.Management_AllocateIP_FullMethodName:       auditEntityVDS,
+	managementv1.Management_DeleteVDS_FullMethodName:        auditEntityVDS,
+	managementv1.Management_StartVDS_FullMethodName:         auditEntityVDS,
+	managementv1.Management_StopVDS_FullMethodName:          auditEntityVDS,
+	managementv1.Management_RebootVDS_FullMethodName:        auditEntityVDS,
+	managementv1.Management_PowerOffVDS_FullMethodName:      auditEntityVDS,
+	managementv1.Management_RenewVDS_FullMethodName:         auditEntityVDS,
+	managementv1.Management_SetVDSAutoRenew_FullMethodName:  auditEntityVDS,
+	managementv1.Management_CreateTask_FullMethodName:       auditEntityTask,
+	managementv1.Management_UpdateTaskStatus_FullMethodName: auditEntityTask,
+	managementv1.Management_CreateIPPool_FullMethodName:     auditEntityIPPool,
+	managementv1.Management_DeleteIPPool_FullMethodName:     auditEntityIPPool,
+	managementv1.Management_CreateWebhook_FullMethodName:    auditEntityWebhook,
+	managementv1.Management_UpdateWebhook_FullMethodName:    auditEntityWebhook,
+	managementv1.Management_DeleteWebhook_FullMethodName:    auditEntityWebhook,
+}
+
+// AuditInterceptor записывает в журнал аудита каждый изменяющий вызов: кто его сделал,
+// над какой сущностью, какие поля изменились и чем закончился вызов.
+// Ошибка записи в журнал не влияет на ответ клиенту: изменение уже выполнено
+type AuditInterceptor struct {
+	audit service.AuditService
+	api   *ServerAPI
+	log   *slog.Logger
+}
+
+// NewAuditInterceptor создаёт interceptor журнала аудита. Состояние сущностей до и после
+// вызова читается через сервисы api
+func NewAuditInterceptor(audit service.AuditService, api *ServerAPI, log *slog.Logger) *AuditInterceptor {
+	return &AuditInterceptor{
+		audit: audit,
+		api:   api,
+		log:   log,
+	}
+}
+
+// UnaryInterceptor возвращает unary interceptor журнала аудита
+func (i *AuditInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		entity, ok := auditedMethods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		requestID := requestIDFromContext(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestID))
+
+		targetID, hasTarget := auditTargetID(req)
+		var before proto.Message
+		if hasTarget {
+			before = i.snapshot(ctx, entity, targetID)
+		}
+
+		resp, err := handler(ctx, req)
+
+		// Вызов уже завершён, журнал пишем даже если клиент отключился
+		actx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditTimeout)
+		defer cancel()
+
+		var after proto.Message
+		if err == nil {
+			if m, ok := resp.(interface {
+				proto.Message
+				GetId() int32
+			}); ok {
+				after = m
+				targetID, hasTarget = m.GetId(), true
+			} else if hasTarget {
+				// Удаление и архивация возвращают Empty: состояние после читаем заново
+				after = i.snapshot(actx, entity, targetID)
+			}
+		}
+
+		entry := &models.AuditEntry{
+			Method:     info.FullMethod,
+			EntityType: entity,
+			RequestID:  requestID,
+			ClientIP:   clientIP(ctx),
+			Code:       status.Code(err).String(),
+		}
+		if user, ok := GetUserFromContext(ctx); ok {
+			entry.ActorID = &user.UserID
+			entry.ActorName = user.Username
+			entry.ActorRole = user.Role.String()
+		}
+		if hasTarget {
+			id := int64(targetID)
+			entry.EntityID = &id
+		}
+
+		diff, derr := auditDiff(before, after)
+		if derr != nil {
+			i.log.Warn("failed to build audit diff",
+				slog.String("method", info.FullMethod),
+				slog.String("error", derr.Error()),
+			)
+		}
+		entry.Diff = diff
+
+		// Ошибка уже залогирована сервисом
+		_ = i.audit.Record(actx, entry)
+
+		return resp, err
+	}
+}
+
+// snapshot текущее состояние сущности или nil, если его не удалось прочитать
+func (i *AuditInterceptor) snapshot(ctx context.Context, entity string, id int32) proto.Message {
+	msg, err := i.api.auditSnapshot(ctx, entity, id)
+	if err != nil {
+		return nil
+	}
+	return msg
+}
+
+// auditSnapshot состояние сущности в proto представлении для diff журнала аудита
+func (s *ServerAPI) auditSnapshot(ctx context.Context, entity string, id int32) (proto.Message, error) {
+	switch entity {
+	case auditEntityPlan:
+		plan, err := s.planService.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return planToProto(plan), nil
+	case auditEntityNode:
+		node, err := s.nodeService.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return nodeToProto(node), nil
+	case auditEntityVDS:
+		vds, err := s.vdsService.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return vdsToProto(vds), nil
+	case auditEntityTask:
+		task, err := s.taskService.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return taskToProto(task), nil
+	case auditEntityIPPool:
+		usage, err := s.poolService.GetUsage(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return ipPoolToProto(usage.Pool), nil
+	case auditEntityWebhook:
+		webhook, err := s.webhookService.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return webhookToProto(webhook), nil
+	default:
+		return nil, nil
+	}
+}
+
+// auditTargetID ID изменяемой сущности из запроса. У AllocateIP это vds_id,
+// у остальных методов - id. Запросы на создание цели не содержат
+func auditTargetID(req any) (int32, bool) {
+	switch r := req.(type) {
+	case *managementv1.AllocateIPRequest:
+		return r.GetVdsId(), r.GetVdsId() > 0
+	case interface{ GetId() int32 }:
+		return r.GetId(), r.GetId() > 0
+	default:
+		return 0, false
+	}
+}
+
+// fieldChange изменение поля в diff журнала аудита
+type fieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// auditDiff изменённые поля между состояниями до и после вызова.
+// Для создания все поля новые, для удаления - все старые
+func auditDiff(before, after proto.Message) (json.RawMessage, error) {
+	oldFields, err := protoFields(before)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := protoFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]fieldChange)
+	for name, value := range oldFields {
+		if !reflect.DeepEqual(value, newFields[name]) {
+			diff[name] = fieldChange{Old: value, New: newFields[name]}
+		}
+	}
+	for name, value := range newFields {
+		if _, ok := oldFields[name]; !ok && value != nil {
+			diff[name] = fieldChange{New: value}
+		}
+	}
+
+	return json.Marshal(diff)
+}
+
+// protoFields поля сообщения в JSON представлении. nil - пустой набор
+func protoFields(msg proto.Message) (map[string]any, error) {
+	if msg == nil {
+		return nil, nil
+	}
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	// Секрет подписки есть в ответе CreateWebhook, но в журнал попасть не должен
+	delete(fields, "secret")
+	return fields, nil
+}
+
+// requestIDFromContext ID запроса из заголовка x-request-id или новый случайный
+func requestIDFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(requestIDHeader); len(v) > 0 && v[0] != "" && len(v[0]) <= 128 {
+			return v[0]
+		}
+	}
+
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// clientIP адрес клиента. Запросы REST gateway приходят с loopback: для них берётся последний
+// адрес x-forwarded-for, который добавил сам gateway. Внешним клиентам этот заголовок не подменить
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host := p.Addr.String()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return ""
+	}
+
+	if ip.IsLoopback() {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get("x-forwarded-for"); len(v) > 0 {
+				parts := strings.Split(v[len(v)-1], ",")
+				if fwd, err := netip.ParseAddr(strings.TrimSpace(parts[len(parts)-1])); err == nil {
+					return fwd.Unmap().String()
+				}
+			}
+		}
+	}
+
+	return ip.Unmap().String()
+}
+
+// ListAuditLog возвращает страницу журнала аудита
+func (s *ServerAPI) ListAuditLog(ctx context.Context, req *managementv1.ListAuditLogRequest) (*managementv1.ListAuditLogResponse, error) {
+	entries, next, err := s.auditService.List(ctx, models.AuditFilter{
+		ActorID:    req.ActorId,
+		EntityType: req.GetEntityType(),
+		EntityID:   req.EntityId,
+		Method:     req.GetMethod(),
+		Created:    timeRangeFromProto(req.GetCreatedFrom(), req.GetCreatedTo()),
+	}, pageFromProto(req))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	protoEntries := make([]*managementv1.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		protoEntries = append(protoEntries, auditEntryToProto(entry))
+	}
+
+	return &managementv1.ListAuditLogResponse{
+		Entries:       protoEntries,
+		NextPageToken: next,
+	}, nil
+}
+
+// auditEntryToProto конвертирует domain модель в proto
+func auditEntryToProto(entry *models.AuditEntry) *managementv1.AuditEntry {
+	return &managementv1.AuditEntry{
+		Id:         entry.ID,
+		ActorId:    entry.ActorID,
+		ActorName:  entry.ActorName,
+		ActorRole:  entry.ActorRole,
+		Method:     entry.Method,
+		EntityType: entry.EntityType,
+		EntityId:   entry.EntityID,
+		Diff:       string(entry.Diff),
+		RequestId:  entry.RequestID,
+		ClientIp:   entry.ClientIP,
+		Code:       entry.Code,
+		CreatedAt:  timestamppb.New(entry.CreatedAt),
+	}
+}
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/makhtech/management/internal/billing"
+	"github.com/makhtech/management/internal/repository"
+	"github.com/makhtech/management/internal/service"
+	managementv1 "github.com/makhtech/proto/gen/go/management"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorWithCode создаёт gRPC ошибку с прикреплённым ErrorDetails,
+// чтобы клиент мог ветвиться по ErrorCode без разбора текста
+func errorWithCode(code codes.Code, errCode managementv1.ErrorCode, msg string) error {
+	st, err := status.New(code, msg).WithDetails(&managementv1.ErrorDetails{
+		Code:    errCode,
+		Message: msg,
+	})
+	if err != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+// errorMapping соответствие ошибки сервисов и репозиториев ответу gRPC
+type errorMapping struct {
+	target  error
+	code    codes.Code
+	errCode managementv1.ErrorCode
+	// message текст ответа вместо текста ошибки
+	message string
+}
+
+// errorMappings проверяются по порядку через errors.Is, первое совпадение побеждает
+var errorMappings = []errorMapping{
+	{target: service.ErrInvalidArgument, code: codes.InvalidArgument, errCode: managementv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT},
+	{target: repository.ErrInvalidPageToken, code: codes.InvalidArgument, errCode: managementv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT},
+	{target: repository.ErrInvalidOrderBy, code: codes.InvalidArgument, errCode: managementv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT},
+	{target: repository.ErrTaskTypeNotAllowed, code: codes.InvalidArgument, errCode: managementv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT,
+		message: "create and delete tasks are queued by CreateVDS and DeleteVDS"},
+
+	{target: repository.ErrPlanNotFound, code: codes.NotFound, errCode: managementv1.ErrorCode_ERROR_CODE_NOT_FOUND},
+	{target: repository.ErrNodeNotFound, code: codes.NotFound, errCode: managementv1.ErrorCode_ERROR_CODE_NOT_FOUND},
+	{target: repository.ErrVDSNotFound, code: codes.NotFound, errCode: managementv1.ErrorCode_ERROR_CODE_NOT_FOUND},
+	{target: repository.ErrTaskNotFound, code: codes.NotFound, errCode: managementv1.ErrorCode_ERROR_CODE_NOT_FOUND},
+	{target: repository.ErrIPPoolNotFound, code: codes.NotFound, errCode: managementv1.ErrorCode_ERROR_CODE_NOT_FOUND},
+	{target: repository.ErrWebhookNotFound, code: codes.NotFound, errCode: managementv1.ErrorCode_ERROR_CODE_NOT_FOUND},
+
+	{target: repository.ErrNodeExists, code: codes.AlreadyExists, errCode: managementv1.ErrorCode_ERROR_CODE_ALREADY_EXISTS},
+	{target: repository.ErrIPPoolOverlaps, code: codes.AlreadyExists, errCode: managementv1.ErrorCode_ERROR_CODE_ALREADY_EXISTS},
+
+	{target: repository.ErrPlanInUse, code: codes.FailedPrecondition, errCode: managementv1.ErrorCode_ERROR_CODE_PLAN_IN_USE,
+		message: "plan is used by vds, archive it instead"},
+	{target: repository.ErrPlanArchived, code: codes.FailedPrecondition, errCode: managementv1.ErrorCode_ERROR_CODE_INVALID_STATE,
+		message: "plan is archived and cannot be changed"},
+	{target: repository.ErrNodeInUse, code: codes.FailedPrecondition, errCode: managementv1.ErrorCode_ERROR_CODE_NODE_IN_USE,
+		message: "node still has vds attached"},
+	{target: repository.ErrIPPoolInUse, code: codes.FailedPrecondition, errCode: managementv1.ErrorCode_ERROR_CODE_IP_POOL_IN_USE},
+	{target: repository.ErrTaskInProgress, code: codes.FailedPrecondition, errCode: managementv1.ErrorCode_ERROR_CODE_TASK_IN_PROGRESS},
+	{target: repository.ErrInvalidTaskTransition, code: codes.FailedPrecondition, errCode: managementv1.ErrorCode_ERROR_CODE_INVALID_STATE},
+	{target: repository.ErrInvalidVDSState, code: codes.FailedPrecondition, errCode: managementv1.ErrorCode_ERROR_CODE_INVALID_STATE},
+	{target: repository.ErrVDSSuspended, code: codes.FailedPrecondition, errCode: managementv1.ErrorCode_ERROR_CODE_VDS_SUSPENDED,
+		message: "vds is suspended for non-payment, renew the subscription first"},
+	{target: repository.ErrSubscriptionChanged, code: codes.Aborted, errCode: managementv1.ErrorCode_ERROR_CODE_INVALID_STATE},
+
+	{target: repository.ErrIdempotentKeyMismatch, code: codes.AlreadyExists, errCode: managementv1.ErrorCode_ERROR_CODE_IDEMPOTENCY_CONFLICT},
+	{target: repository.ErrIdempotentKeyInProgress, code: codes.Aborted, errCode: managementv1.ErrorCode_ERROR_CODE_IDEMPOTENCY_CONFLICT},
+
+	{target: repository.ErrInsufficientResources, code: codes.ResourceExhausted, errCode: managementv1.ErrorCode_ERROR_CODE_INSUFFICIENT_RESOURCES,
+		message: "no node has enough free resources for the plan"},
+	{target: repository.ErrNoFreeIP, code: codes.ResourceExhausted, errCode: managementv1.ErrorCode_ERROR_CODE_IP_ALLOCATION_FAILED},
+	{target: repository.ErrIPUnavailable, code: codes.FailedPrecondition, errCode: managementv1.ErrorCode_ERROR_CODE_IP_ALLOCATION_FAILED},
+
+	{target: repository.ErrInsufficientFunds, code: codes.FailedPrecondition, errCode: managementv1.ErrorCode_ERROR_CODE_INSUFFICIENT_FUNDS,
+		message: "insufficient funds to pay for the plan"},
+	{target: billing.ErrAutoRenewUnavailable, code: codes.FailedPrecondition, errCode: managementv1.ErrorCode_ERROR_CODE_UNAVAILABLE,
+		message: "auto-renewal is not configured on this server"},
+	{target: billing.ErrUnavailable, code: codes.Unavailable, errCode: managementv1.ErrorCode_ERROR_CODE_UNAVAILABLE,
+		message: "billing is temporarily unavailable, try again later"},
+
+	{target: context.DeadlineExceeded, code: codes.DeadlineExceeded, errCode: managementv1.ErrorCode_ERROR_CODE_UNAVAILABLE},
+	{target: context.Canceled, code: codes.Canceled, errCode: managementv1.ErrorCode_ERROR_CODE_UNAVAILABLE},
+}
+
+// toStatus переводит ошибку сервиса в gRPC статус с ErrorDetails.
+// Известные ошибки отдаются без цепочки обёрток (op), неизвестные логируются
+// и возвращаются клиенту как Internal без подробностей
+func toStatus(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// Ошибка уже переведена, например проверкой прав. Статус, обёрнутый в цепочку (ответ SSO),
+	// наружу не отдаётся: его текст и код относятся к чужому сервису
+	if _, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return err
+	}
+
+	for _, m := range errorMappings {
+		if !errors.Is(err, m.target) {
+			continue
+		}
+		msg := m.message
+		if msg == "" {
+			msg = trimChain(err, m.target)
+		}
+		return errorWithCode(m.code, m.errCode, msg)
+	}
+
+	method, _ := grpc.Method(ctx)
+	slog.Error("internal error",
+		slog.String("method", method),
+		slog.String("error", err.Error()),
+	)
+
+	return errorWithCode(codes.Internal, managementv1.ErrorCode_ERROR_CODE_INTERNAL, "internal error")
+}
+
+// trimChain отрезает от текста ошибки префиксы обёрток до sentinel ошибки target:
+// "service.vds.Create: invalid argument: plan_id is required" -> "invalid argument: plan_id is required"
+func trimChain(err, target error) string {
+	msg := err.Error()
+	if i := strings.Index(msg, target.Error()); i >= 0 {
+		return msg[i:]
+	}
+	return target.Error()
+}
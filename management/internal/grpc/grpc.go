@@ -2,18 +2,42 @@ package grpc
 
 import (
 	"github.com/makhtech/management/internal/service"
+	"github.com/makhtech/management/internal/watch"
 	managementv1 "github.com/makhtech/proto/gen/go/management"
 )
 
 type ServerAPI struct {
 	managementv1.UnimplementedManagementServer
 
-	planService service.PlanService
+	planService    service.PlanService
+	nodeService    service.NodeService
+	vdsService     service.VDSService
+	taskService    service.TaskService
+	poolService    service.IPPoolService
+	auditService   service.AuditService
+	webhookService service.WebhookService
+	watchHub       *watch.Hub
 }
 
 // NewServerAPI создает новый ServerAPI с зависимостями
-func NewServerAPI(planSvc service.PlanService) *ServerAPI {
+func NewServerAPI(
+	planSvc service.PlanService,
+	nodeSvc service.NodeService,
+	vdsSvc service.VDSService,
+	taskSvc service.TaskService,
+	poolSvc service.IPPoolService,
+	auditSvc service.AuditService,
+	webhookSvc service.WebhookService,
+	watchHub *watch.Hub,
+) *ServerAPI {
 	return &ServerAPI{
-		planService: planSvc,
+		planService:    planSvc,
+		nodeService:    nodeSvc,
+		vdsService:     vdsSvc,
+		taskService:    taskSvc,
+		poolService:    poolSvc,
+		auditService:   auditSvc,
+		webhookService: webhookSvc,
+		watchHub:       watchHub,
 	}
 }
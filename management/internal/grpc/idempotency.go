@@ -0,0 +1,309 @@
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+	managementv1 "github.com/makhtech/proto/gen/go/management"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	// idempotencyKeyHeader заголовок с ключом идемпотентности изменяющего вызова
+	idempotencyKeyHeader = "idempotency-key"
+	// idempotentReplayedHeader заголовок ответа, который отдан из сохранённого по ключу
+	idempotentReplayedHeader = "idempotent-replayed"
+	// maxIdempotencyKeyLen наибольшая длина ключа (key VARCHAR(255))
+	maxIdempotencyKeyLen = 255
+	// minLockTimeout блокировка ключа переживает самый долгий вызов - ожидание задачи питания,
+	// иначе повтор с тем же ключом выполнит ещё идущий вызов второй раз
+	minLockTimeout = maxPowerWait + time.Minute
+)
+
+// IdempotencyConfig настройки ключей идемпотентности
+type IdempotencyConfig struct {
+	// TTL сколько хранится ответ по ключу
+	TTL time.Duration
+	// LockTimeout через сколько незавершённый вызов перестаёт блокировать повтор с тем же ключом.
+	// Не меньше minLockTimeout
+	LockTimeout time.Duration
+	// CleanupInterval пауза между удалениями просроченных ключей
+	CleanupInterval time.Duration
+}
+
+// IdempotencyInterceptor выполняет изменяющий вызов с заголовком idempotency-key не больше одного раза.
+// Повтор с тем же ключом и тем же запросом получает сохранённый ответ, с другим запросом - конфликт.
+// Сохраняются только успешные ответы: после ошибки ключ освобождается и вызов можно повторить
+type IdempotencyInterceptor struct {
+	repo repository.IdempotencyRepository
+	cfg  IdempotencyConfig
+	log  *slog.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewIdempotencyInterceptor создаёт interceptor ключей идемпотентности
+func NewIdempotencyInterceptor(repo repository.IdempotencyRepository, cfg IdempotencyConfig, log *slog.Logger) *IdempotencyInterceptor {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 24 * time.Hour
+	}
+	if cfg.LockTimeout <= 0 {
+		cfg.LockTimeout = minLockTimeout
+	}
+	if cfg.LockTimeout < minLockTimeout {
+		log.Warn("idempotency lock timeout is shorter than the longest call, raising it",
+			slog.Duration("lock_timeout", cfg.LockTimeout),
+			slog.Duration("min_lock_timeout", minLockTimeout),
+		)
+		cfg.LockTimeout = minLockTimeout
+	}
+	if cfg.CleanupInterval <= 0 {
+		cfg.CleanupInterval = 10 * time.Minute
+	}
+
+	return &IdempotencyInterceptor{
+		repo: repo,
+		cfg:  cfg,
+		log:  log,
+	}
+}
+
+// UnaryInterceptor возвращает unary interceptor ключей идемпотентности.
+// Должен стоять после аутентификации: ключи хранятся отдельно для каждого пользователя
+func (i *IdempotencyInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		// Ключ учитывается только для изменяющих методов, те же методы пишутся в журнал аудита
+		if _, ok := auditedMethods[info.FullMethod]; !ok {
+			return handler(ctx, req)
+		}
+
+		key := idempotencyKeyFromContext(ctx)
+		if key == "" {
+			return handler(ctx, req)
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			return nil, errorWithCode(codes.InvalidArgument, managementv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT,
+				fmt.Sprintf("%s must be at most %d characters", idempotencyKeyHeader, maxIdempotencyKeyLen))
+		}
+
+		user, ok := GetUserFromContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		hash, err := requestHash(info.FullMethod, msg)
+		if err != nil {
+			return nil, toStatus(ctx, err)
+		}
+
+		log := i.log.With(
+			slog.String("method", info.FullMethod),
+			slog.Int64("user_id", user.UserID),
+			slog.String("idempotency_key", key),
+		)
+
+		entry := &models.IdempotencyKey{
+			UserID:      user.UserID,
+			Key:         key,
+			Method:      info.FullMethod,
+			RequestHash: hash,
+			ExpiresAt:   time.Now().Add(i.cfg.TTL),
+		}
+		err = i.repo.Acquire(ctx, entry, i.cfg.LockTimeout)
+		if errors.Is(err, repository.ErrIdempotentKeyExists) {
+			return i.replay(ctx, entry, log)
+		}
+		if err != nil {
+			return nil, toStatus(ctx, err)
+		}
+
+		resp, err := handler(ctx, req)
+
+		// Вызов уже выполнен, результат сохраняем даже если клиент отключился
+		sctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditTimeout)
+		defer cancel()
+
+		if err != nil {
+			if rerr := i.repo.Release(sctx, user.UserID, key); rerr != nil {
+				log.Warn("failed to release idempotency key", slog.String("error", rerr.Error()))
+			}
+			return resp, err
+		}
+
+		data, merr := marshalResponse(resp)
+		if merr == nil {
+			merr = i.repo.Complete(sctx, user.UserID, key, data)
+		}
+		if merr != nil {
+			// Ответ не сохранён: повтор после LockTimeout выполнит вызов заново
+			log.Error("failed to store idempotent response", slog.String("error", merr.Error()))
+		}
+
+		return resp, nil
+	}
+}
+
+// replay отвечает на повтор вызова с уже занятым ключом
+func (i *IdempotencyInterceptor) replay(ctx context.Context, entry *models.IdempotencyKey, log *slog.Logger) (any, error) {
+	stored, err := i.repo.Get(ctx, entry.UserID, entry.Key)
+	if errors.Is(err, repository.IdempotentKeyNotFound) {
+		// Ключ освободили или он истёк между попытками: клиенту достаточно повторить
+		return nil, toStatus(ctx, repository.ErrIdempotentKeyInProgress)
+	}
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	if stored.Method != entry.Method || stored.RequestHash != entry.RequestHash {
+		log.Warn("idempotency key reused with a different request", slog.String("stored_method", stored.Method))
+		return nil, toStatus(ctx, repository.ErrIdempotentKeyMismatch)
+	}
+	if stored.Status != models.IdempotencyCompleted {
+		return nil, toStatus(ctx, repository.ErrIdempotentKeyInProgress)
+	}
+
+	resp, err := unmarshalResponse(entry.Method, stored.Response)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedHeader, "true"))
+	log.Info("idempotent response replayed")
+
+	return resp, nil
+}
+
+// Start запускает периодическое удаление просроченных ключей
+func (i *IdempotencyInterceptor) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	i.cancel = cancel
+
+	i.wg.Add(1)
+	go i.cleanup(ctx)
+
+	i.log.Info("idempotency keys enabled",
+		slog.Duration("ttl", i.cfg.TTL),
+		slog.Duration("lock_timeout", i.cfg.LockTimeout),
+	)
+}
+
+// Stop останавливает удаление просроченных ключей
+func (i *IdempotencyInterceptor) Stop() {
+	if i.cancel == nil {
+		return
+	}
+	i.cancel()
+	i.wg.Wait()
+}
+
+func (i *IdempotencyInterceptor) cleanup(ctx context.Context) {
+	defer i.wg.Done()
+
+	ticker := time.NewTicker(i.cfg.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := i.repo.DeleteExpired(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					i.log.Error("failed to delete expired idempotency keys", slog.String("error", err.Error()))
+				}
+				continue
+			}
+			if deleted > 0 {
+				i.log.Debug("expired idempotency keys deleted", slog.Int64("count", deleted))
+			}
+		}
+	}
+}
+
+// idempotencyKeyFromContext ключ идемпотентности из заголовка запроса, пусто - ключа нет
+func idempotencyKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(idempotencyKeyHeader); len(v) > 0 {
+		return strings.TrimSpace(v[0])
+	}
+	return ""
+}
+
+// requestHash SHA-256 метода и запроса. Сериализация детерминированная, поэтому
+// одинаковые запросы дают одинаковый хэш
+func requestHash(method string, req proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("grpc.requestHash: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func marshalResponse(resp any) ([]byte, error) {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("grpc.marshalResponse: unexpected response type %T", resp)
+	}
+	return proto.Marshal(msg)
+}
+
+// unmarshalResponse восстанавливает сохранённый ответ метода. Тип ответа берётся
+// из описания метода в proto
+func unmarshalResponse(method string, data []byte) (proto.Message, error) {
+	const op = "grpc.unmarshalResponse"
+
+	service, name, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if !ok {
+		return nil, fmt.Errorf("%s: invalid method %q", op, method)
+	}
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s: %q is not a service", op, service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(name))
+	if md == nil {
+		return nil, fmt.Errorf("%s: unknown method %q", op, method)
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(md.Output().FullName())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msg := mt.New().Interface()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return msg, nil
+}
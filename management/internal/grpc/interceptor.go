@@ -2,11 +2,20 @@ package grpc
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/makhtech/management/internal/clients/sso"
+	"github.com/makhtech/management/internal/jwtverify"
 	"github.com/makhtech/management/pkg/ratelimiter"
+	"github.com/makhtech/management/pkg/ttlcache"
+	managementv1 "github.com/makhtech/proto/gen/go/management"
 	ssov1 "github.com/makhtech/proto/gen/go/sso"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -24,6 +33,19 @@ const (
 	AccessTokenContextKey contextKey = "access_token"
 )
 
+// AuthMode способ проверки access token
+type AuthMode string
+
+const (
+	// AuthModeSSO токен проверяет SSO. Если SSO недоступен, токен проверяется локально
+	AuthModeSSO AuthMode = "sso"
+	// AuthModeLocal токен проверяется только локально по открытым ключам SSO
+	AuthModeLocal AuthMode = "local"
+)
+
+// errAuthUnavailable токен нечем проверить: нет ни SSO, ни локального верификатора
+var errAuthUnavailable = errors.New("no token verifier available")
+
 // UserInfo информация о пользователе, извлечённая из JWT
 type UserInfo struct {
 	UserID   int64
@@ -35,12 +57,17 @@ type UserInfo struct {
 	Balance  int64
 }
 
-// AuthInterceptor interceptor для аутентификации и авторизации
+// AuthInterceptor interceptor для аутентификации и авторизации.
+// Без SSO и локального верификатора отклоняет все непубличные методы
 type AuthInterceptor struct {
 	ssoClient   *sso.Client
+	verifier    *jwtverify.Verifier
+	mode        AuthMode
 	rateLimiter *ratelimiter.TokenBucket
 	// Методы, которые не требуют аутентификации
 	publicMethods map[string]bool
+	// cache проверенные SSO токены по sha256 токена. nil - каждый запрос проверяется в SSO
+	cache *ttlcache.Cache[*UserInfo]
 }
 
 // NewAuthInterceptor создаёт новый AuthInterceptor
@@ -49,9 +76,16 @@ func NewAuthInterceptor(ssoClient *sso.Client, rateLimiter *ratelimiter.TokenBuc
 		ssoClient:     ssoClient,
 		rateLimiter:   rateLimiter,
 		publicMethods: make(map[string]bool),
+		mode:          AuthModeSSO,
 	}
 }
 
+// SetVerifier включает локальную проверку подписи токенов открытыми ключами SSO
+func (i *AuthInterceptor) SetVerifier(verifier *jwtverify.Verifier, mode AuthMode) {
+	i.verifier = verifier
+	i.mode = mode
+}
+
 // SetPublicMethods устанавливает методы, которые не требуют аутентификации
 func (i *AuthInterceptor) SetPublicMethods(methods ...string) {
 	for _, method := range methods {
@@ -59,6 +93,41 @@ func (i *AuthInterceptor) SetPublicMethods(methods ...string) {
 	}
 }
 
+// EnableCache включает кэш проверенных токенов. Запись живёт не дольше ttl и не дольше exp токена
+func (i *AuthInterceptor) EnableCache(size int, ttl time.Duration) {
+	i.cache = ttlcache.New[*UserInfo](ttlcache.Config{
+		Capacity: size,
+		TTL:      ttl,
+	})
+}
+
+// EvictToken удаляет токен из кэша, например после logout. Следующий запрос с ним снова проверит SSO.
+// Без вызова отозванный токен принимается до истечения записи, не дольше TTL кэша
+func (i *AuthInterceptor) EvictToken(accessToken string) {
+	if i.cache == nil {
+		return
+	}
+	i.cache.Delete(tokenKey(accessToken))
+}
+
+// EvictUser удаляет из кэша все токены пользователя, например после смены роли или блокировки
+func (i *AuthInterceptor) EvictUser(userID int64) int {
+	if i.cache == nil {
+		return 0
+	}
+	return i.cache.DeleteFunc(func(_ string, user *UserInfo) bool {
+		return user.UserID == userID
+	})
+}
+
+// CacheStats возвращает счётчики кэша токенов. ok=false - кэш выключен
+func (i *AuthInterceptor) CacheStats() (stats ttlcache.Stats, ok bool) {
+	if i.cache == nil {
+		return ttlcache.Stats{}, false
+	}
+	return i.cache.Stats(), true
+}
+
 // UnaryInterceptor возвращает gRPC UnaryServerInterceptor
 func (i *AuthInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
 	return func(
@@ -89,7 +158,8 @@ func (i *AuthInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
 				slog.Warn("rate limit exceeded",
 					slog.String("method", info.FullMethod),
 				)
-				return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded, please try again later")
+				return nil, errorWithCode(codes.ResourceExhausted, managementv1.ErrorCode_ERROR_CODE_RATE_LIMITED,
+					"rate limit exceeded, please try again later")
 			}
 			slog.Debug("rate limit check passed",
 				slog.String("method", info.FullMethod),
@@ -97,25 +167,14 @@ func (i *AuthInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
 			)
 		}
 
-		// Валидируем JWT через SSO сервис
-		userResp, err := i.ssoClient.ValidateJWT(ctx, accessToken)
+		// Валидируем JWT через SSO сервис или берём из кэша
+		userInfo, err := i.authenticate(ctx, accessToken)
 		if err != nil {
 			slog.Warn("JWT validation failed",
 				slog.String("method", info.FullMethod),
 				slog.String("error", err.Error()),
 			)
-			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
-		}
-
-		// Создаём UserInfo из ответа SSO
-		userInfo := &UserInfo{
-			UserID:   userResp.UserId,
-			Username: userResp.Username,
-			Email:    userResp.Email,
-			PhotoURL: userResp.PhotoUrl,
-			Role:     userResp.Role,
-			AppID:    userResp.AppId,
-			Balance:  userResp.Balance,
+			return nil, authError(err)
 		}
 
 		// Добавляем информацию о пользователе в context
@@ -157,25 +216,15 @@ func (i *AuthInterceptor) StreamInterceptor() grpc.StreamServerInterceptor {
 		if i.rateLimiter != nil {
 			allowed, _ := i.rateLimiter.Allow(accessToken)
 			if !allowed {
-				return status.Error(codes.ResourceExhausted, "rate limit exceeded, please try again later")
+				return errorWithCode(codes.ResourceExhausted, managementv1.ErrorCode_ERROR_CODE_RATE_LIMITED,
+					"rate limit exceeded, please try again later")
 			}
 		}
 
-		// Валидируем JWT через SSO сервис
-		userResp, err := i.ssoClient.ValidateJWT(ctx, accessToken)
+		// Валидируем JWT через SSO сервис или берём из кэша
+		userInfo, err := i.authenticate(ctx, accessToken)
 		if err != nil {
-			return status.Error(codes.Unauthenticated, "invalid or expired token")
-		}
-
-		// Создаём UserInfo из ответа SSO
-		userInfo := &UserInfo{
-			UserID:   userResp.UserId,
-			Username: userResp.Username,
-			Email:    userResp.Email,
-			PhotoURL: userResp.PhotoUrl,
-			Role:     userResp.Role,
-			AppID:    userResp.AppId,
-			Balance:  userResp.Balance,
+			return authError(err)
 		}
 
 		// Оборачиваем stream с новым context
@@ -188,21 +237,145 @@ func (i *AuthInterceptor) StreamInterceptor() grpc.StreamServerInterceptor {
 	}
 }
 
+// authenticate проверяет токен в SSO или локально. Успешный результат кэшируется до exp токена
+func (i *AuthInterceptor) authenticate(ctx context.Context, accessToken string) (*UserInfo, error) {
+	var key string
+	if i.cache != nil {
+		key = tokenKey(accessToken)
+		if userInfo, ok := i.cache.Get(key); ok {
+			return userInfo, nil
+		}
+	}
+
+	userInfo, exp, err := i.validate(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.cache != nil {
+		// Токен без exp живёт в кэше TTL
+		i.cache.Set(key, userInfo, exp)
+	}
+
+	return userInfo, nil
+}
+
+// validate выбирает способ проверки токена. Локальная проверка используется в режиме local,
+// без SSO клиента и при недоступности SSO. Если проверить нечем - errAuthUnavailable
+func (i *AuthInterceptor) validate(ctx context.Context, accessToken string) (*UserInfo, time.Time, error) {
+	if i.ssoClient != nil && i.mode != AuthModeLocal {
+		userResp, err := i.ssoClient.ValidateJWT(ctx, accessToken)
+		if err == nil {
+			// Создаём UserInfo из ответа SSO
+			userInfo := &UserInfo{
+				UserID:   userResp.UserId,
+				Username: userResp.Username,
+				Email:    userResp.Email,
+				PhotoURL: userResp.PhotoUrl,
+				Role:     userResp.Role,
+				AppID:    userResp.AppId,
+				Balance:  userResp.Balance,
+			}
+			exp, _ := tokenExpiry(accessToken)
+			return userInfo, exp, nil
+		}
+		if i.verifier == nil || !isSSOUnavailable(err) {
+			return nil, time.Time{}, err
+		}
+		slog.Warn("SSO is unavailable, verifying token locally", slog.String("error", err.Error()))
+	}
+
+	if i.verifier == nil {
+		return nil, time.Time{}, errAuthUnavailable
+	}
+
+	claims, err := i.verifier.Verify(ctx, accessToken)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	// Баланса в токене нет, при локальной проверке он неизвестен
+	return &UserInfo{
+		UserID:   claims.UserID,
+		Username: claims.Username,
+		Email:    claims.Email,
+		PhotoURL: claims.PhotoURL,
+		Role:     claims.Role,
+		AppID:    claims.AppID,
+	}, claims.ExpiresAt, nil
+}
+
+// isSSOUnavailable отличает сбой SSO от отказа в проверке токена
+func isSSOUnavailable(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled, codes.ResourceExhausted, codes.Internal:
+		return true
+	default:
+		return false
+	}
+}
+
+// authError приводит ошибку проверки токена к gRPC статусу
+func authError(err error) error {
+	if errors.Is(err, errAuthUnavailable) {
+		return errorWithCode(codes.Unavailable, managementv1.ErrorCode_ERROR_CODE_UNAVAILABLE,
+			"authentication is temporarily unavailable")
+	}
+	return errorWithCode(codes.Unauthenticated, managementv1.ErrorCode_ERROR_CODE_UNAUTHENTICATED, "invalid or expired token")
+}
+
+// tokenKey ключ кэша: сам токен в памяти не хранится
+func tokenKey(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenExpiry читает claim exp из payload JWT без проверки подписи.
+// Подпись к этому моменту уже проверил SSO
+func tokenExpiry(accessToken string) (time.Time, bool) {
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == "" {
+		return time.Time{}, false
+	}
+
+	exp, err := claims.Exp.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(exp), 0), true
+}
+
 // extractAccessToken извлекает access token из gRPC metadata
 func extractAccessToken(ctx context.Context) (string, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return "", status.Error(codes.Unauthenticated, "missing metadata")
+		return "", errorWithCode(codes.Unauthenticated, managementv1.ErrorCode_ERROR_CODE_UNAUTHENTICATED, "missing metadata")
 	}
 
 	values := md.Get("authorization")
 	if len(values) == 0 {
-		return "", status.Error(codes.Unauthenticated, "missing authorization header")
+		return "", errorWithCode(codes.Unauthenticated, managementv1.ErrorCode_ERROR_CODE_UNAUTHENTICATED, "missing authorization header")
 	}
 
 	authHeader := values[0]
 	if !strings.HasPrefix(authHeader, "Bearer ") {
-		return "", status.Error(codes.Unauthenticated, "invalid authorization format, expected 'Bearer <token>'")
+		return "", errorWithCode(codes.Unauthenticated, managementv1.ErrorCode_ERROR_CODE_UNAUTHENTICATED, "invalid authorization format, expected 'Bearer <token>'")
 	}
 
 	return strings.TrimPrefix(authHeader, "Bearer "), nil
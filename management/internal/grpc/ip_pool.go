@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/makhtech/management/internal/domain/models"
+	managementv1 "github.com/makhtech/proto/gen/go/management"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *ServerAPI) CreateIPPool(ctx context.Context, req *managementv1.CreateIPPoolRequest) (*managementv1.IPPool, error) {
+	domainReq := &models.CreateIPPoolRequest{
+		NodeID:  req.GetNodeId(),
+		Subnet:  req.GetSubnet(),
+		Gateway: req.GetGateway(),
+	}
+	for _, r := range req.GetReservedRanges() {
+		domainReq.ReservedRanges = append(domainReq.ReservedRanges, models.IPRangeRequest{
+			Start:       r.GetStart(),
+			End:         r.GetEnd(),
+			Description: r.GetDescription(),
+		})
+	}
+
+	pool, err := s.poolService.Create(ctx, domainReq)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return ipPoolToProto(pool), nil
+}
+
+func (s *ServerAPI) GetIPPoolUsage(ctx context.Context, req *managementv1.GetIPPoolRequest) (*managementv1.IPPoolUsage, error) {
+	usage, err := s.poolService.GetUsage(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return ipPoolUsageToProto(usage), nil
+}
+
+func (s *ServerAPI) ListIPPools(ctx context.Context, req *managementv1.ListIPPoolsRequest) (*managementv1.ListIPPoolsResponse, error) {
+	usage, next, err := s.poolService.ListUsage(ctx, models.IPPoolFilter{
+		NodeID: req.NodeId,
+		Family: req.GetFamily(),
+	}, pageFromProto(req))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	protoPools := make([]*managementv1.IPPoolUsage, 0, len(usage))
+	for _, u := range usage {
+		protoPools = append(protoPools, ipPoolUsageToProto(u))
+	}
+
+	return &managementv1.ListIPPoolsResponse{
+		Pools:         protoPools,
+		NextPageToken: next,
+	}, nil
+}
+
+func (s *ServerAPI) DeleteIPPool(ctx context.Context, req *managementv1.GetIPPoolRequest) (*emptypb.Empty, error) {
+	err := s.poolService.Delete(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ipPoolToProto конвертирует domain модель в proto
+func ipPoolToProto(pool *models.IPPool) *managementv1.IPPool {
+	ranges := make([]*managementv1.IPRange, 0, len(pool.ReservedRanges))
+	for _, r := range pool.ReservedRanges {
+		ranges = append(ranges, &managementv1.IPRange{
+			Start:       r.Start.String(),
+			End:         r.End.String(),
+			Description: r.Description,
+		})
+	}
+
+	return &managementv1.IPPool{
+		Id:             pool.ID,
+		NodeId:         pool.NodeID,
+		Family:         pool.Family,
+		Subnet:         pool.Subnet.String(),
+		Gateway:        pool.Gateway.String(),
+		ReservedRanges: ranges,
+		IsActive:       pool.IsActive,
+		CreatedAt:      timestamppb.New(pool.CreatedAt),
+	}
+}
+
+// ipPoolUsageToProto конвертирует статистику пула в proto
+func ipPoolUsageToProto(usage *models.IPPoolUsage) *managementv1.IPPoolUsage {
+	return &managementv1.IPPoolUsage{
+		Pool:      ipPoolToProto(usage.Pool),
+		Total:     usage.Total,
+		Reserved:  usage.Reserved,
+		Allocated: usage.Allocated,
+		Free:      usage.Free,
+		UsagePct:  usage.UsagePct(),
+	}
+}
@@ -2,26 +2,153 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
 	managementv1 "github.com/makhtech/proto/gen/go/management"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *ServerAPI) CreateNode(ctx context.Context, req *managementv1.CreateNodeRequest) (*managementv1.Node, error) {
-	panic("implement me")
+	domainReq := &models.CreateNodeRequest{
+		Name:    req.GetName(),
+		APIURL:  req.GetApiUrl(),
+		MaxCPU:  req.GetMaxCpu(),
+		MaxRAM:  req.GetMaxRam(),
+		MaxDisk: req.GetMaxDisk(),
+		Region:  req.GetRegion(),
+		Labels:  req.GetLabels(),
+	}
+
+	node, err := s.nodeService.Create(ctx, domainReq)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return nodeToProto(node), nil
 }
+
 func (s *ServerAPI) GetNode(ctx context.Context, req *managementv1.GetNodeRequest) (*managementv1.Node, error) {
-	panic("implement me")
+	node, err := s.nodeService.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return nodeToProto(node), nil
 }
+
 func (s *ServerAPI) UpdateNode(ctx context.Context, req *managementv1.UpdateNodeRequest) (*managementv1.Node, error) {
-	panic("implement me")
+	domainReq := &models.UpdateNodeRequest{
+		ID: req.GetId(),
+	}
+
+	if req.ApiUrl != nil {
+		domainReq.APIURL = req.ApiUrl
+	}
+	if req.MaxCpu != nil {
+		domainReq.MaxCPU = req.MaxCpu
+	}
+	if req.MaxRam != nil {
+		domainReq.MaxRAM = req.MaxRam
+	}
+	if req.MaxDisk != nil {
+		domainReq.MaxDisk = req.MaxDisk
+	}
+	if req.IsActive != nil {
+		domainReq.IsActive = req.IsActive
+	}
+	if req.Region != nil {
+		domainReq.Region = req.Region
+	}
+	if req.Labels != nil {
+		// Пустой NodeLabels очищает метки, поэтому nil заменяем пустой картой
+		domainReq.Labels = req.GetLabels().GetLabels()
+		if domainReq.Labels == nil {
+			domainReq.Labels = map[string]string{}
+		}
+	}
+
+	node, err := s.nodeService.Update(ctx, domainReq)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return nodeToProto(node), nil
 }
+
 func (s *ServerAPI) ListNodes(ctx context.Context, req *managementv1.ListNodesRequest) (*managementv1.ListNodesResponse, error) {
-	panic("implement me")
+	slog.Info("ListNodes called", slog.Bool("active_only", req.GetActiveOnly()))
+
+	nodes, next, err := s.nodeService.List(ctx, models.NodeFilter{
+		ActiveOnly: req.GetActiveOnly(),
+		Region:     req.GetRegion(),
+		Created:    timeRangeFromProto(req.GetCreatedFrom(), req.GetCreatedTo()),
+	}, pageFromProto(req))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	protoNodes := make([]*managementv1.Node, 0, len(nodes))
+	for _, node := range nodes {
+		protoNodes = append(protoNodes, nodeToProto(node))
+	}
+
+	return &managementv1.ListNodesResponse{
+		Nodes:         protoNodes,
+		NextPageToken: next,
+	}, nil
 }
+
 func (s *ServerAPI) DeleteNode(ctx context.Context, req *managementv1.GetNodeRequest) (*emptypb.Empty, error) {
-	panic("implement me")
+	err := s.nodeService.Delete(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
 }
+
 func (s *ServerAPI) GetNodeUtilization(ctx context.Context, req *managementv1.GetNodeRequest) (*managementv1.NodeUtilization, error) {
-	panic("implement me")
+	utilization, err := s.nodeService.GetUtilization(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, repository.ErrNodeNotFound) {
+			return nil, errorWithCode(codes.NotFound, managementv1.ErrorCode_ERROR_CODE_NOT_FOUND, "node not found or inactive")
+		}
+		return nil, toStatus(ctx, err)
+	}
+
+	return &managementv1.NodeUtilization{
+		NodeId:       utilization.NodeID,
+		NodeName:     utilization.NodeName,
+		MaxCpu:       utilization.MaxCPU,
+		MaxRam:       utilization.MaxRAM,
+		MaxDisk:      utilization.MaxDisk,
+		VdsCount:     utilization.VDSCount,
+		UsedCpu:      utilization.UsedCPU,
+		UsedRam:      utilization.UsedRAM,
+		UsedDisk:     utilization.UsedDisk,
+		CpuUsagePct:  utilization.CPUUsagePct,
+		RamUsagePct:  utilization.RAMUsagePct,
+		DiskUsagePct: utilization.DiskUsagePct,
+	}, nil
+}
+
+// nodeToProto конвертирует domain модель в proto
+func nodeToProto(node *models.Node) *managementv1.Node {
+	return &managementv1.Node{
+		Id:        node.ID,
+		Name:      node.Name,
+		ApiUrl:    node.APIURL,
+		MaxCpu:    node.MaxCPU,
+		MaxRam:    node.MaxRAM,
+		MaxDisk:   node.MaxDisk,
+		IsActive:  node.IsActive,
+		CreatedAt: timestamppb.New(node.CreatedAt),
+		Region:    node.Region,
+		Labels:    node.Labels,
+	}
 }
@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+
+	managementv1 "github.com/makhtech/proto/gen/go/management"
+	ssov1 "github.com/makhtech/proto/gen/go/sso"
+	"google.golang.org/grpc/codes"
+)
+
+// ownerScope возвращает ID пользователя, которым ограничен доступ к VDS.
+// ok=false - ограничения нет: модераторы, администраторы и сервисы работают с любыми VDS.
+// Без пользователя в context доступ закрыт: ID 0 не принадлежит ни одна VDS
+func ownerScope(ctx context.Context) (userID int64, ok bool) {
+	user, found := GetUserFromContext(ctx)
+	if !found {
+		return 0, true
+	}
+	if user.Role != ssov1.Role_USER {
+		return 0, false
+	}
+	return user.UserID, true
+}
+
+// authorizeOwner проверяет, что вызывающий пользователь может работать с ресурсами пользователя ownerID
+func authorizeOwner(ctx context.Context, ownerID int32) error {
+	userID, restricted := ownerScope(ctx)
+	if restricted && int64(ownerID) != userID {
+		return errorWithCode(codes.PermissionDenied, managementv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED,
+			"access to another user's vds is denied")
+	}
+	return nil
+}
+
+// authorizeVDS проверяет, что вызывающий пользователь владеет VDS vdsID.
+// Для ролей без ограничения VDS не загружается
+func (s *ServerAPI) authorizeVDS(ctx context.Context, vdsID int32) error {
+	if _, restricted := ownerScope(ctx); !restricted {
+		return nil
+	}
+
+	vds, err := s.vdsService.GetByID(ctx, vdsID)
+	if err != nil {
+		return toStatus(ctx, err)
+	}
+
+	return authorizeOwner(ctx, vds.UserID)
+}
@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"github.com/makhtech/management/internal/domain/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// pageRequest общие поля постраничных List запросов
+type pageRequest interface {
+	GetPageSize() int32
+	GetPageToken() string
+	GetOrderBy() string
+}
+
+// pageFromProto конвертирует параметры страницы из proto
+func pageFromProto(req pageRequest) models.PageRequest {
+	return models.PageRequest{
+		Size:    req.GetPageSize(),
+		Token:   req.GetPageToken(),
+		OrderBy: req.GetOrderBy(),
+	}
+}
+
+// timeRangeFromProto конвертирует границы диапазона времени. Незаданная граница - nil
+func timeRangeFromProto(from, to *timestamppb.Timestamp) models.TimeRange {
+	var r models.TimeRange
+	if from != nil {
+		t := from.AsTime()
+		r.From = &t
+	}
+	if to != nil {
+		t := to.AsTime()
+		r.To = &t
+	}
+	return r
+}
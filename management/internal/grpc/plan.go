@@ -2,14 +2,10 @@ package grpc
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 
 	"github.com/makhtech/management/internal/domain/models"
-	"github.com/makhtech/management/internal/repository"
 	managementv1 "github.com/makhtech/proto/gen/go/management"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -26,7 +22,7 @@ func (s *ServerAPI) CreatePlan(ctx context.Context, req *managementv1.CreatePlan
 
 	plan, err := s.planService.Create(ctx, domainReq)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create plan: %v", err)
+		return nil, toStatus(ctx, err)
 	}
 
 	return planToProto(plan), nil
@@ -58,22 +54,22 @@ func (s *ServerAPI) UpdatePlan(ctx context.Context, req *managementv1.UpdatePlan
 
 	plan, err := s.planService.Update(ctx, domainReq)
 	if err != nil {
-		if errors.Is(err, repository.ErrPlanNotFound) {
-			return nil, status.Errorf(codes.NotFound, "plan not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to update plan: %v", err)
+		return nil, toStatus(ctx, err)
 	}
 
 	return planToProto(plan), nil
 }
 
-func (s *ServerAPI) DeletePlan(ctx context.Context, req *managementv1.GetPlanRequest) (*emptypb.Empty, error) {
-	err := s.planService.Delete(ctx, req.GetId())
+// DeletePlan удаляет план или, при archive, переносит его в архив
+func (s *ServerAPI) DeletePlan(ctx context.Context, req *managementv1.DeletePlanRequest) (*emptypb.Empty, error) {
+	var err error
+	if req.GetArchive() {
+		_, err = s.planService.Archive(ctx, req.GetId())
+	} else {
+		err = s.planService.Delete(ctx, req.GetId())
+	}
 	if err != nil {
-		if errors.Is(err, repository.ErrPlanNotFound) {
-			return nil, status.Errorf(codes.NotFound, "plan not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to delete plan: %v", err)
+		return nil, toStatus(ctx, err)
 	}
 
 	return &emptypb.Empty{}, nil
@@ -83,10 +79,7 @@ func (s *ServerAPI) DeletePlan(ctx context.Context, req *managementv1.GetPlanReq
 func (s *ServerAPI) GetPlan(ctx context.Context, req *managementv1.GetPlanRequest) (*managementv1.Plan, error) {
 	plan, err := s.planService.GetByID(ctx, req.GetId())
 	if err != nil {
-		if errors.Is(err, repository.ErrPlanNotFound) {
-			return nil, status.Errorf(codes.NotFound, "plan not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get plan: %v", err)
+		return nil, toStatus(ctx, err)
 	}
 
 	return planToProto(plan), nil
@@ -95,9 +88,32 @@ func (s *ServerAPI) GetPlan(ctx context.Context, req *managementv1.GetPlanReques
 func (s *ServerAPI) ListPlans(ctx context.Context, req *managementv1.ListPlansRequest) (*managementv1.ListPlansResponse, error) {
 	slog.Info("ListPlans called", slog.Bool("active_only", req.GetActiveOnly()))
 
-	plans, err := s.planService.List(ctx, req.GetActiveOnly())
+	plans, next, err := s.planService.List(ctx, models.PlanFilter{
+		ActiveOnly: req.GetActiveOnly(),
+		MinPrice:   req.MinPrice,
+		MaxPrice:   req.MaxPrice,
+		Created:    timeRangeFromProto(req.GetCreatedFrom(), req.GetCreatedTo()),
+	}, pageFromProto(req))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	protoPlans := make([]*managementv1.Plan, 0, len(plans))
+	for _, plan := range plans {
+		protoPlans = append(protoPlans, planToProto(plan))
+	}
+
+	return &managementv1.ListPlansResponse{
+		Plans:         protoPlans,
+		NextPageToken: next,
+	}, nil
+}
+
+// ListArchivedPlans возвращает архивные планы
+func (s *ServerAPI) ListArchivedPlans(ctx context.Context, req *managementv1.ListArchivedPlansRequest) (*managementv1.ListPlansResponse, error) {
+	plans, next, err := s.planService.ListArchived(ctx, pageFromProto(req))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list plans: %v", err)
+		return nil, toStatus(ctx, err)
 	}
 
 	protoPlans := make([]*managementv1.Plan, 0, len(plans))
@@ -106,13 +122,14 @@ func (s *ServerAPI) ListPlans(ctx context.Context, req *managementv1.ListPlansRe
 	}
 
 	return &managementv1.ListPlansResponse{
-		Plans: protoPlans,
+		Plans:         protoPlans,
+		NextPageToken: next,
 	}, nil
 }
 
 // planToProto конвертирует domain модель в proto
 func planToProto(plan *models.Plan) *managementv1.Plan {
-	return &managementv1.Plan{
+	pb := &managementv1.Plan{
 		Id:         plan.ID,
 		Name:       plan.Name,
 		Cpu:        plan.CPU,
@@ -122,4 +139,8 @@ func planToProto(plan *models.Plan) *managementv1.Plan {
 		IsActive:   plan.IsActive,
 		CreatedAt:  timestamppb.New(plan.CreatedAt),
 	}
+	if plan.DeletedAt != nil {
+		pb.DeletedAt = timestamppb.New(*plan.DeletedAt)
+	}
+	return pb
 }
@@ -0,0 +1,231 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	managementv1 "github.com/makhtech/proto/gen/go/management"
+	ssov1 "github.com/makhtech/proto/gen/go/sso"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Наборы ролей для политики доступа
+var (
+	allRoles      = []ssov1.Role{ssov1.Role_USER, ssov1.Role_MODERATOR, ssov1.Role_ADMIN, ssov1.Role_SERVICE}
+	adminRoles    = []ssov1.Role{ssov1.Role_ADMIN}
+	operatorRoles = []ssov1.Role{ssov1.Role_ADMIN, ssov1.Role_SERVICE}
+	staffRoles    = []ssov1.Role{ssov1.Role_MODERATOR, ssov1.Role_ADMIN, ssov1.Role_SERVICE}
+)
+
+// Policy описывает, каким ролям разрешён вызов каждого gRPC метода.
+// Метод, отсутствующий в политике, запрещён всем
+type Policy struct {
+	public map[string]bool
+	roles  map[string]map[ssov1.Role]bool
+}
+
+// NewPolicy создаёт пустую политику, запрещающую все методы
+func NewPolicy() *Policy {
+	return &Policy{
+		public: make(map[string]bool),
+		roles:  make(map[string]map[ssov1.Role]bool),
+	}
+}
+
+// DefaultPolicy политика доступа к Management API.
+// Права на конкретную VDS дополнительно проверяются в обработчиках (см. authorizeVDS)
+func DefaultPolicy() *Policy {
+	p := NewPolicy()
+
+	// Тарифы видны всем, менять их могут только администраторы
+	p.Public(
+		managementv1.Management_ListPlans_FullMethodName,
+		managementv1.Management_GetPlan_FullMethodName,
+	)
+	p.Allow(adminRoles,
+		managementv1.Management_CreatePlan_FullMethodName,
+		managementv1.Management_UpdatePlan_FullMethodName,
+		managementv1.Management_DeletePlan_FullMethodName,
+		managementv1.Management_ListArchivedPlans_FullMethodName,
+	)
+
+	// Ноды и пулы адресов - инфраструктура, пользователям недоступна
+	p.Allow(staffRoles,
+		managementv1.Management_GetNode_FullMethodName,
+		managementv1.Management_ListNodes_FullMethodName,
+		managementv1.Management_GetNodeUtilization_FullMethodName,
+		managementv1.Management_GetIPPoolUsage_FullMethodName,
+		managementv1.Management_ListIPPools_FullMethodName,
+	)
+	p.Allow(adminRoles,
+		managementv1.Management_CreateNode_FullMethodName,
+		managementv1.Management_UpdateNode_FullMethodName,
+		managementv1.Management_DeleteNode_FullMethodName,
+		managementv1.Management_CreateIPPool_FullMethodName,
+		managementv1.Management_DeleteIPPool_FullMethodName,
+	)
+
+	// Журнал аудита видят только администраторы
+	p.Allow(adminRoles,
+		managementv1.Management_ListAuditLog_FullMethodName,
+	)
+
+	// Пользователь работает только со своими VDS и их задачами
+	p.Allow(allRoles,
+		managementv1.Management_CreateVDS_FullMethodName,
+		managementv1.Management_GetVDS_FullMethodName,
+		managementv1.Management_ListVDSByUser_FullMethodName,
+		managementv1.Management_DeleteVDS_FullMethodName,
+		managementv1.Management_ListVDSEvents_FullMethodName,
+		managementv1.Management_WatchVDS_FullMethodName,
+		managementv1.Management_StartVDS_FullMethodName,
+		managementv1.Management_StopVDS_FullMethodName,
+		managementv1.Management_RebootVDS_FullMethodName,
+		managementv1.Management_PowerOffVDS_FullMethodName,
+		managementv1.Management_RenewVDS_FullMethodName,
+		managementv1.Management_SetVDSAutoRenew_FullMethodName,
+		managementv1.Management_GetTask_FullMethodName,
+		managementv1.Management_WatchTask_FullMethodName,
+		managementv1.Management_ListTasksByVDS_FullMethodName,
+		managementv1.Management_GetPendingTasksCount_FullMethodName,
+	)
+	// Подписки на события: пользователь управляет только своими (см. authorizeWebhook)
+	p.Allow(allRoles,
+		managementv1.Management_CreateWebhook_FullMethodName,
+		managementv1.Management_GetWebhook_FullMethodName,
+		managementv1.Management_UpdateWebhook_FullMethodName,
+		managementv1.Management_ListWebhooks_FullMethodName,
+		managementv1.Management_DeleteWebhook_FullMethodName,
+		managementv1.Management_ListWebhookDeliveries_FullMethodName,
+	)
+	// Прямое изменение статусов, адресов и очереди задач - для администраторов и внутренних сервисов
+	p.Allow(operatorRoles,
+		managementv1.Management_UpdateVDSStatus_FullMethodName,
+		managementv1.Management_AllocateIP_FullMethodName,
+		managementv1.Management_CreateTask_FullMethodName,
+		managementv1.Management_UpdateTaskStatus_FullMethodName,
+	)
+
+	// Проверка готовности нужна балансировщикам без токена
+	p.Public(
+		healthpb.Health_Check_FullMethodName,
+		healthpb.Health_List_FullMethodName,
+		healthpb.Health_Watch_FullMethodName,
+	)
+
+	// Рефлексия раскрывает схему API, оставляем её для отладки администраторам
+	p.Allow(adminRoles,
+		"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+		"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+	)
+
+	return p
+}
+
+// Public разрешает вызов методов без аутентификации
+func (p *Policy) Public(methods ...string) {
+	for _, method := range methods {
+		p.public[method] = true
+	}
+}
+
+// Allow разрешает вызов методов пользователям с ролями roles
+func (p *Policy) Allow(roles []ssov1.Role, methods ...string) {
+	for _, method := range methods {
+		allowed, ok := p.roles[method]
+		if !ok {
+			allowed = make(map[ssov1.Role]bool, len(roles))
+			p.roles[method] = allowed
+		}
+		for _, role := range roles {
+			allowed[role] = true
+		}
+	}
+}
+
+// PublicMethods возвращает методы, не требующие аутентификации
+func (p *Policy) PublicMethods() []string {
+	methods := make([]string, 0, len(p.public))
+	for method := range p.public {
+		methods = append(methods, method)
+	}
+	return methods
+}
+
+// IsPublic проверяет, что метод доступен без аутентификации
+func (p *Policy) IsPublic(method string) bool {
+	return p.public[method]
+}
+
+// Allowed проверяет, что пользователю с ролью role разрешён вызов метода
+func (p *Policy) Allowed(method string, role ssov1.Role) bool {
+	return p.roles[method][role]
+}
+
+// PolicyInterceptor проверяет роль пользователя по политике доступа.
+// Должен идти в цепочке после AuthInterceptor, который кладёт UserInfo в context
+type PolicyInterceptor struct {
+	policy *Policy
+}
+
+// NewPolicyInterceptor создаёт interceptor, применяющий policy
+func NewPolicyInterceptor(policy *Policy) *PolicyInterceptor {
+	return &PolicyInterceptor{policy: policy}
+}
+
+// UnaryInterceptor возвращает gRPC UnaryServerInterceptor
+func (i *PolicyInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := i.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor возвращает gRPC StreamServerInterceptor
+func (i *PolicyInterceptor) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := i.authorize(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func (i *PolicyInterceptor) authorize(ctx context.Context, method string) error {
+	if i.policy.IsPublic(method) {
+		return nil
+	}
+
+	user, ok := GetUserFromContext(ctx)
+	if !ok {
+		// Непубличный метод без пользователя - ошибка конфигурации цепочки, не пропускаем
+		slog.Error("policy check without authenticated user", slog.String("method", method))
+		return errorWithCode(codes.PermissionDenied, managementv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED, "permission denied")
+	}
+
+	if !i.policy.Allowed(method, user.Role) {
+		slog.Warn("permission denied by policy",
+			slog.String("method", method),
+			slog.Int64("user_id", user.UserID),
+			slog.String("role", user.Role.String()),
+		)
+		return errorWithCode(codes.PermissionDenied, managementv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED,
+			"role "+user.Role.String()+" is not allowed to call this method")
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/watch"
+	managementv1 "github.com/makhtech/proto/gen/go/management"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// defaultPowerWait время ожидания задачи питания, если wait_timeout не задан
+	defaultPowerWait = time.Minute
+	// maxPowerWait наибольшее время ожидания задачи питания
+	maxPowerWait = 10 * time.Minute
+	// powerWaitMargin запас до дедлайна клиента: ответ с состоянием задачи должен успеть уйти
+	powerWaitMargin = time.Second
+)
+
+// StartVDS запускает остановленную VDS
+func (s *ServerAPI) StartVDS(ctx context.Context, req *managementv1.VDSPowerRequest) (*managementv1.VDSPowerResponse, error) {
+	return s.power(ctx, req, models.TaskTypeStart)
+}
+
+// StopVDS корректно выключает VDS
+func (s *ServerAPI) StopVDS(ctx context.Context, req *managementv1.VDSPowerRequest) (*managementv1.VDSPowerResponse, error) {
+	return s.power(ctx, req, models.TaskTypeStop)
+}
+
+// RebootVDS перезагружает VDS
+func (s *ServerAPI) RebootVDS(ctx context.Context, req *managementv1.VDSPowerRequest) (*managementv1.VDSPowerResponse, error) {
+	return s.power(ctx, req, models.TaskTypeRestart)
+}
+
+// PowerOffVDS немедленно останавливает VDS
+func (s *ServerAPI) PowerOffVDS(ctx context.Context, req *managementv1.VDSPowerRequest) (*managementv1.VDSPowerResponse, error) {
+	return s.power(ctx, req, models.TaskTypePowerOff)
+}
+
+// power ставит задачу питания action и при wait ждёт её завершения
+func (s *ServerAPI) power(ctx context.Context, req *managementv1.VDSPowerRequest, action models.TaskType) (*managementv1.VDSPowerResponse, error) {
+	timeout := defaultPowerWait
+	if req.GetWaitTimeout() != nil {
+		if err := req.GetWaitTimeout().CheckValid(); err != nil {
+			return nil, errorWithCode(codes.InvalidArgument, managementv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, "invalid wait_timeout")
+		}
+		d := req.GetWaitTimeout().AsDuration()
+		if d < 0 || d > maxPowerWait {
+			return nil, errorWithCode(codes.InvalidArgument, managementv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT,
+				"wait_timeout must be between 0 and "+maxPowerWait.String())
+		}
+		if d > 0 {
+			timeout = d
+		}
+	}
+
+	if err := s.authorizeVDS(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	task, err := s.vdsService.Power(ctx, req.GetId(), action)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	if req.GetWait() {
+		task, err = s.waitTask(ctx, task.ID, timeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vds, err := s.vdsService.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &managementv1.VDSPowerResponse{
+		Task: taskToProto(task),
+		Vds:  vdsToProto(vds),
+	}, nil
+}
+
+// waitTask ждёт завершения задачи не дольше timeout и дедлайна вызова. Если задача не успела
+// завершиться или сервер останавливается, возвращается её текущее состояние
+func (s *ServerAPI) waitTask(ctx context.Context, taskID int32, timeout time.Duration) (*models.Task, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = min(timeout, time.Until(deadline)-powerWaitMargin)
+	}
+
+	// Подписка до первого чтения: завершение между чтением и ожиданием не теряется
+	sub := s.watchHub.Subscribe(watch.KindTask, taskID)
+	defer sub.Close()
+
+	timer := time.NewTimer(max(timeout, 0))
+	defer timer.Stop()
+
+	for {
+		task, err := s.taskService.GetByID(ctx, taskID)
+		if err != nil {
+			return nil, toStatus(ctx, err)
+		}
+		if task.Status.IsFinal() {
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, toStatus(ctx, ctx.Err())
+		case <-sub.Done():
+			return task, nil
+		case <-timer.C:
+			return task, nil
+		case <-sub.C():
+		}
+	}
+}
@@ -3,21 +3,210 @@ package grpc
 import (
 	"context"
 
+	"github.com/makhtech/management/internal/domain/models"
 	managementv1 "github.com/makhtech/proto/gen/go/management"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *ServerAPI) CreateTask(ctx context.Context, req *managementv1.CreateTaskRequest) (*managementv1.Task, error) {
-	panic("implement me")
+	taskType := taskTypeFromProto(req.GetType())
+	// У операций питания свои RPC с ожиданием результата, второй путь к тем же задачам не нужен
+	if taskType.IsPowerAction() {
+		return nil, errorWithCode(codes.InvalidArgument, managementv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT,
+			"use StartVDS, StopVDS, RebootVDS or PowerOffVDS for power actions")
+	}
+
+	if err := s.authorizeVDS(ctx, req.GetVdsId()); err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskService.Create(ctx, req.GetVdsId(), taskType)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return taskToProto(task), nil
 }
+
 func (s *ServerAPI) GetTask(ctx context.Context, req *managementv1.GetTaskRequest) (*managementv1.Task, error) {
-	panic("implement me")
+	task, err := s.taskService.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	if err := s.authorizeVDS(ctx, task.VDSID); err != nil {
+		return nil, err
+	}
+
+	return taskToProto(task), nil
 }
+
 func (s *ServerAPI) ListTasksByVDS(ctx context.Context, req *managementv1.ListTasksByVDSRequest) (*managementv1.ListTasksResponse, error) {
-	panic("implement me")
+	if err := s.authorizeVDS(ctx, req.GetVdsId()); err != nil {
+		return nil, err
+	}
+
+	filter := models.TaskFilter{
+		VDSID:   req.GetVdsId(),
+		Created: timeRangeFromProto(req.GetCreatedFrom(), req.GetCreatedTo()),
+	}
+	if req.Status != nil {
+		status := taskStatusFromProto(req.GetStatus())
+		filter.Status = &status
+	}
+	if req.Type != nil {
+		taskType := taskTypeFromProto(req.GetType())
+		filter.Type = &taskType
+	}
+
+	tasks, next, err := s.taskService.ListByVDS(ctx, filter, pageFromProto(req))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	protoTasks := make([]*managementv1.Task, 0, len(tasks))
+	for _, task := range tasks {
+		protoTasks = append(protoTasks, taskToProto(task))
+	}
+
+	return &managementv1.ListTasksResponse{
+		Tasks:         protoTasks,
+		NextPageToken: next,
+	}, nil
 }
+
 func (s *ServerAPI) UpdateTaskStatus(ctx context.Context, req *managementv1.UpdateTaskStatusRequest) (*managementv1.Task, error) {
-	panic("implement me")
+	task, err := s.taskService.UpdateStatus(ctx, &models.UpdateTaskStatusRequest{
+		ID:     req.GetId(),
+		Status: taskStatusFromProto(req.GetStatus()),
+		Error:  req.Error,
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return taskToProto(task), nil
 }
+
 func (s *ServerAPI) GetPendingTasksCount(ctx context.Context, req *managementv1.GetPendingTasksCountRequest) (*managementv1.GetPendingTasksCountResponse, error) {
-	panic("implement me")
+	if err := s.authorizeVDS(ctx, req.GetVdsId()); err != nil {
+		return nil, err
+	}
+
+	count, err := s.taskService.GetPendingCount(ctx, req.GetVdsId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &managementv1.GetPendingTasksCountResponse{
+		Count: count,
+	}, nil
+}
+
+// taskToProto конвертирует domain модель в proto
+func taskToProto(task *models.Task) *managementv1.Task {
+	protoTask := &managementv1.Task{
+		Id:        task.ID,
+		VdsId:     task.VDSID,
+		Type:      taskTypeToProto(task.Type),
+		Status:    taskStatusToProto(task.Status),
+		Error:     task.Error,
+		ErrorCode: taskErrorCodeToProto(task),
+		CreatedAt: timestamppb.New(task.CreatedAt),
+	}
+	if task.StartedAt != nil {
+		protoTask.StartedAt = timestamppb.New(*task.StartedAt)
+	}
+	if task.CompletedAt != nil {
+		protoTask.CompletedAt = timestamppb.New(*task.CompletedAt)
+	}
+	return protoTask
+}
+
+// taskTypeToProto конвертирует тип задачи в proto enum
+func taskTypeToProto(taskType models.TaskType) managementv1.TaskType {
+	switch taskType {
+	case models.TaskTypeCreate:
+		return managementv1.TaskType_TASK_TYPE_CREATE
+	case models.TaskTypeDelete:
+		return managementv1.TaskType_TASK_TYPE_DELETE
+	case models.TaskTypeStart:
+		return managementv1.TaskType_TASK_TYPE_START
+	case models.TaskTypeStop:
+		return managementv1.TaskType_TASK_TYPE_STOP
+	case models.TaskTypeRestart:
+		return managementv1.TaskType_TASK_TYPE_RESTART
+	case models.TaskTypePowerOff:
+		return managementv1.TaskType_TASK_TYPE_POWEROFF
+	default:
+		return managementv1.TaskType_TASK_TYPE_UNKNOWN
+	}
+}
+
+// taskTypeFromProto конвертирует proto enum в тип задачи
+func taskTypeFromProto(taskType managementv1.TaskType) models.TaskType {
+	switch taskType {
+	case managementv1.TaskType_TASK_TYPE_CREATE:
+		return models.TaskTypeCreate
+	case managementv1.TaskType_TASK_TYPE_DELETE:
+		return models.TaskTypeDelete
+	case managementv1.TaskType_TASK_TYPE_START:
+		return models.TaskTypeStart
+	case managementv1.TaskType_TASK_TYPE_STOP:
+		return models.TaskTypeStop
+	case managementv1.TaskType_TASK_TYPE_RESTART:
+		return models.TaskTypeRestart
+	case managementv1.TaskType_TASK_TYPE_POWEROFF:
+		return models.TaskTypePowerOff
+	default:
+		return ""
+	}
+}
+
+// taskStatusToProto конвертирует статус задачи в proto enum
+func taskStatusToProto(taskStatus models.TaskStatus) managementv1.TaskStatus {
+	switch taskStatus {
+	case models.TaskStatusPending:
+		return managementv1.TaskStatus_TASK_STATUS_PENDING
+	case models.TaskStatusRunning:
+		return managementv1.TaskStatus_TASK_STATUS_RUNNING
+	case models.TaskStatusDone:
+		return managementv1.TaskStatus_TASK_STATUS_DONE
+	case models.TaskStatusError:
+		return managementv1.TaskStatus_TASK_STATUS_ERROR
+	default:
+		return managementv1.TaskStatus_TASK_STATUS_UNKNOWN
+	}
+}
+
+// taskStatusFromProto конвертирует proto enum в статус задачи
+func taskStatusFromProto(taskStatus managementv1.TaskStatus) models.TaskStatus {
+	switch taskStatus {
+	case managementv1.TaskStatus_TASK_STATUS_PENDING:
+		return models.TaskStatusPending
+	case managementv1.TaskStatus_TASK_STATUS_RUNNING:
+		return models.TaskStatusRunning
+	case managementv1.TaskStatus_TASK_STATUS_DONE:
+		return models.TaskStatusDone
+	case managementv1.TaskStatus_TASK_STATUS_ERROR:
+		return models.TaskStatusError
+	default:
+		return ""
+	}
+}
+
+// taskErrorCodeToProto конвертирует класс ошибки задачи в proto ErrorCode.
+// Для задач, завершившихся успешно или ещё не завершённых, возвращается ERROR_CODE_OK
+func taskErrorCodeToProto(task *models.Task) managementv1.ErrorCode {
+	if task.Status != models.TaskStatusError {
+		return managementv1.ErrorCode_ERROR_CODE_OK
+	}
+	switch task.ErrorCode {
+	case models.TaskErrorCodeProxmox:
+		return managementv1.ErrorCode_ERROR_CODE_PROXMOX_ERROR
+	case models.TaskErrorCodeInternal:
+		return managementv1.ErrorCode_ERROR_CODE_INTERNAL
+	default:
+		return managementv1.ErrorCode_ERROR_CODE_UNKNOWN
+	}
 }
@@ -3,25 +3,330 @@ package grpc
 import (
 	"context"
 
+	"github.com/makhtech/management/internal/domain/models"
 	managementv1 "github.com/makhtech/proto/gen/go/management"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *ServerAPI) CreateVDS(ctx context.Context, req *managementv1.CreateVDSRequest) (*managementv1.VDS, error) {
-	panic("implement me")
+	if err := authorizeOwner(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	domainReq := &models.CreateVDSRequest{
+		UserID:     req.GetUserId(),
+		PlanID:     req.GetPlanId(),
+		Region:     req.GetRegion(),
+		NodeLabels: req.GetNodeLabels(),
+		RequestID:  req.GetRequestId(),
+	}
+	// Без request_id резервирование оплаты привязывается к ключу идемпотентности вызова
+	if domainReq.RequestID == "" {
+		domainReq.RequestID = idempotencyKeyFromContext(ctx)
+	}
+	// Оплата резервируется с баланса вызывающего пользователя
+	if token, ok := GetAccessTokenFromContext(ctx); ok {
+		domainReq.AccessToken = token
+	}
+	if user, ok := GetUserFromContext(ctx); ok {
+		domainReq.PayerID = int32(user.UserID)
+	}
+	if req.NodeId != nil {
+		// Явный node_id = 0 не означает автоматический выбор
+		if req.GetNodeId() <= 0 {
+			return nil, errorWithCode(codes.InvalidArgument, managementv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, "invalid node id")
+		}
+		domainReq.NodeID = req.GetNodeId()
+	}
+	if req.GetExpiresAt() != nil {
+		domainReq.ExpiresAt = req.GetExpiresAt().AsTime()
+	}
+
+	vds, err := s.vdsService.Create(ctx, domainReq)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return vdsToProto(vds), nil
 }
+
 func (s *ServerAPI) GetVDS(ctx context.Context, req *managementv1.GetVDSRequest) (*managementv1.VDS, error) {
-	panic("implement me")
+	vds, err := s.vdsService.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	if err := authorizeOwner(ctx, vds.UserID); err != nil {
+		return nil, err
+	}
+
+	return vdsToProto(vds), nil
 }
+
 func (s *ServerAPI) ListVDSByUser(ctx context.Context, req *managementv1.ListVDSByUserRequest) (*managementv1.ListVDSResponse, error) {
-	panic("implement me")
+	if err := authorizeOwner(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	filter := models.VDSFilter{
+		UserID:  req.GetUserId(),
+		NodeID:  req.NodeId,
+		PlanID:  req.PlanId,
+		Created: timeRangeFromProto(req.GetCreatedFrom(), req.GetCreatedTo()),
+	}
+	if req.Status != nil {
+		status := vdsStatusFromProto(req.GetStatus())
+		filter.Status = &status
+	}
+
+	list, next, err := s.vdsService.ListByUser(ctx, filter, pageFromProto(req))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	protoVDS := make([]*managementv1.VDS, 0, len(list))
+	for _, vds := range list {
+		protoVDS = append(protoVDS, vdsToProto(vds))
+	}
+
+	return &managementv1.ListVDSResponse{
+		Vds:           protoVDS,
+		NextPageToken: next,
+	}, nil
 }
+
 func (s *ServerAPI) UpdateVDSStatus(ctx context.Context, req *managementv1.UpdateVDSStatusRequest) (*managementv1.VDS, error) {
-	panic("implement me")
+	vds, err := s.vdsService.UpdateStatus(ctx, req.GetId(), vdsStatusFromProto(req.GetStatus()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return vdsToProto(vds), nil
 }
+
 func (s *ServerAPI) AllocateIP(ctx context.Context, req *managementv1.AllocateIPRequest) (*managementv1.VDS, error) {
-	panic("implement me")
+	vds, err := s.vdsService.AllocateIP(ctx, &models.AllocateIPRequest{
+		VDSID: req.GetVdsId(),
+		IPv4:  req.GetIpv4(),
+		IPv6:  req.GetIpv6(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return vdsToProto(vds), nil
 }
+
 func (s *ServerAPI) DeleteVDS(ctx context.Context, req *managementv1.DeleteVDSRequest) (*emptypb.Empty, error) {
-	panic("implement me")
+	if err := s.authorizeVDS(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	err := s.vdsService.Delete(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ServerAPI) ListVDSEvents(ctx context.Context, req *managementv1.ListVDSEventsRequest) (*managementv1.ListVDSEventsResponse, error) {
+	if err := s.authorizeVDS(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	filter := models.VDSEventFilter{
+		VDSID:   req.GetId(),
+		Created: timeRangeFromProto(req.GetCreatedFrom(), req.GetCreatedTo()),
+	}
+	if req.Type != nil {
+		eventType := vdsEventTypeFromProto(req.GetType())
+		filter.Type = &eventType
+	}
+
+	events, next, err := s.vdsService.ListEvents(ctx, filter, pageFromProto(req))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	protoEvents := make([]*managementv1.VDSEvent, 0, len(events))
+	for _, event := range events {
+		protoEvents = append(protoEvents, vdsEventToProto(event))
+	}
+
+	return &managementv1.ListVDSEventsResponse{
+		Events:        protoEvents,
+		NextPageToken: next,
+	}, nil
+}
+
+// RenewVDS продлевает подписку VDS за счёт вызывающего пользователя
+func (s *ServerAPI) RenewVDS(ctx context.Context, req *managementv1.RenewVDSRequest) (*managementv1.VDS, error) {
+	if err := s.authorizeVDS(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	domainReq := &models.RenewVDSRequest{VDSID: req.GetId()}
+	if token, ok := GetAccessTokenFromContext(ctx); ok {
+		domainReq.AccessToken = token
+	}
+	if user, ok := GetUserFromContext(ctx); ok {
+		domainReq.PayerID = int32(user.UserID)
+	}
+
+	vds, err := s.vdsService.Renew(ctx, domainReq)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return vdsToProto(vds), nil
+}
+
+// SetVDSAutoRenew включает или отключает автопродление подписки VDS
+func (s *ServerAPI) SetVDSAutoRenew(ctx context.Context, req *managementv1.SetVDSAutoRenewRequest) (*managementv1.VDS, error) {
+	if err := s.authorizeVDS(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	vds, err := s.vdsService.SetAutoRenew(ctx, &models.SetAutoRenewRequest{
+		VDSID:        req.GetId(),
+		Enabled:      req.GetEnabled(),
+		RefreshToken: req.GetRefreshToken(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return vdsToProto(vds), nil
+}
+
+// vdsToProto конвертирует domain модель в proto
+func vdsToProto(vds *models.VDS) *managementv1.VDS {
+	var suspendedAt *timestamppb.Timestamp
+	if vds.SuspendedAt != nil {
+		suspendedAt = timestamppb.New(*vds.SuspendedAt)
+	}
+
+	return &managementv1.VDS{
+		Id:            vds.ID,
+		UserId:        vds.UserID,
+		PlanId:        vds.PlanID,
+		NodeId:        vds.NodeID,
+		ProxmoxVmId:   vds.ProxmoxVMID,
+		Status:        vdsStatusToProto(vds.Status),
+		Ipv4:          vds.IPv4,
+		Ipv6:          vds.IPv6,
+		CreatedAt:     timestamppb.New(vds.CreatedAt),
+		ExpiresAt:     timestamppb.New(vds.ExpiresAt),
+		ReservationId: vds.ReservationID,
+		BillingAmount: vds.BillingAmount,
+		BillingStatus: billingStatusToProto(vds.BillingStatus),
+		SuspendedAt:   suspendedAt,
+		AutoRenew:     vds.AutoRenew,
+	}
+}
+
+// vdsEventToProto конвертирует событие жизненного цикла VDS в proto
+func vdsEventToProto(event *models.VDSEvent) *managementv1.VDSEvent {
+	return &managementv1.VDSEvent{
+		Id:        event.ID,
+		VdsId:     event.VDSID,
+		Type:      vdsEventTypeToProto(event.Type),
+		Message:   event.Message,
+		Details:   event.Details,
+		CreatedAt: timestamppb.New(event.CreatedAt),
+	}
+}
+
+// vdsEventTypeToProto конвертирует тип события VDS в proto enum
+func vdsEventTypeToProto(eventType models.VDSEventType) managementv1.VDSEventType {
+	switch eventType {
+	case models.VDSEventRenewed:
+		return managementv1.VDSEventType_VDS_EVENT_TYPE_RENEWED
+	case models.VDSEventRenewalFailed:
+		return managementv1.VDSEventType_VDS_EVENT_TYPE_RENEWAL_FAILED
+	case models.VDSEventSuspended:
+		return managementv1.VDSEventType_VDS_EVENT_TYPE_SUSPENDED
+	case models.VDSEventResumed:
+		return managementv1.VDSEventType_VDS_EVENT_TYPE_RESUMED
+	case models.VDSEventDeletionQueued:
+		return managementv1.VDSEventType_VDS_EVENT_TYPE_DELETION_QUEUED
+	default:
+		return managementv1.VDSEventType_VDS_EVENT_TYPE_UNKNOWN
+	}
+}
+
+// vdsEventTypeFromProto конвертирует proto enum в тип события VDS.
+// Для неизвестного значения возвращает пустой тип, который не пройдёт валидацию сервиса
+func vdsEventTypeFromProto(eventType managementv1.VDSEventType) models.VDSEventType {
+	switch eventType {
+	case managementv1.VDSEventType_VDS_EVENT_TYPE_RENEWED:
+		return models.VDSEventRenewed
+	case managementv1.VDSEventType_VDS_EVENT_TYPE_RENEWAL_FAILED:
+		return models.VDSEventRenewalFailed
+	case managementv1.VDSEventType_VDS_EVENT_TYPE_SUSPENDED:
+		return models.VDSEventSuspended
+	case managementv1.VDSEventType_VDS_EVENT_TYPE_RESUMED:
+		return models.VDSEventResumed
+	case managementv1.VDSEventType_VDS_EVENT_TYPE_DELETION_QUEUED:
+		return models.VDSEventDeletionQueued
+	default:
+		return ""
+	}
+}
+
+// billingStatusToProto конвертирует состояние оплаты VDS в proto enum
+func billingStatusToProto(status models.BillingStatus) managementv1.BillingStatus {
+	switch status {
+	case models.BillingStatusReserved:
+		return managementv1.BillingStatus_BILLING_STATUS_RESERVED
+	case models.BillingStatusCommitted:
+		return managementv1.BillingStatus_BILLING_STATUS_COMMITTED
+	case models.BillingStatusCancelled:
+		return managementv1.BillingStatus_BILLING_STATUS_CANCELLED
+	default:
+		return managementv1.BillingStatus_BILLING_STATUS_NONE
+	}
+}
+
+// vdsStatusToProto конвертирует статус VDS в proto enum
+func vdsStatusToProto(status models.VDSStatus) managementv1.VDSStatus {
+	switch status {
+	case models.VDSStatusCreating:
+		return managementv1.VDSStatus_VDS_STATUS_CREATING
+	case models.VDSStatusRunning:
+		return managementv1.VDSStatus_VDS_STATUS_RUNNING
+	case models.VDSStatusStopped:
+		return managementv1.VDSStatus_VDS_STATUS_STOPPED
+	case models.VDSStatusError:
+		return managementv1.VDSStatus_VDS_STATUS_ERROR
+	case models.VDSStatusDeleting:
+		return managementv1.VDSStatus_VDS_STATUS_DELETING
+	case models.VDSStatusDeleted:
+		return managementv1.VDSStatus_VDS_STATUS_DELETED
+	default:
+		return managementv1.VDSStatus_VDS_STATUS_UNKNOWN
+	}
+}
+
+// vdsStatusFromProto конвертирует proto enum в статус VDS.
+// Для неизвестного значения возвращает пустой статус, который не пройдёт валидацию сервиса
+func vdsStatusFromProto(status managementv1.VDSStatus) models.VDSStatus {
+	switch status {
+	case managementv1.VDSStatus_VDS_STATUS_CREATING:
+		return models.VDSStatusCreating
+	case managementv1.VDSStatus_VDS_STATUS_RUNNING:
+		return models.VDSStatusRunning
+	case managementv1.VDSStatus_VDS_STATUS_STOPPED:
+		return models.VDSStatusStopped
+	case managementv1.VDSStatus_VDS_STATUS_ERROR:
+		return models.VDSStatusError
+	case managementv1.VDSStatus_VDS_STATUS_DELETING:
+		return models.VDSStatusDeleting
+	case managementv1.VDSStatus_VDS_STATUS_DELETED:
+		return models.VDSStatusDeleted
+	default:
+		return ""
+	}
 }
@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/makhtech/management/internal/watch"
+	managementv1 "github.com/makhtech/proto/gen/go/management"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+// WatchTask отправляет текущее состояние задачи и затем каждое изменение статуса
+// до DONE или ERROR
+func (s *ServerAPI) WatchTask(req *managementv1.WatchTaskRequest, stream grpc.ServerStreamingServer[managementv1.Task]) error {
+	ctx := stream.Context()
+
+	task, err := s.taskService.GetByID(ctx, req.GetId())
+	if err != nil {
+		return toStatus(ctx, err)
+	}
+	if err := s.authorizeVDS(ctx, task.VDSID); err != nil {
+		return err
+	}
+
+	sub := s.watchHub.Subscribe(watch.KindTask, req.GetId())
+	defer sub.Close()
+
+	return watchStream(ctx, sub,
+		func(ctx context.Context) (*managementv1.Task, error) {
+			task, err := s.taskService.GetByID(ctx, req.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return taskToProto(task), nil
+		},
+		stream.Send,
+		func(task *managementv1.Task) bool {
+			return task.GetStatus() == managementv1.TaskStatus_TASK_STATUS_DONE ||
+				task.GetStatus() == managementv1.TaskStatus_TASK_STATUS_ERROR
+		},
+	)
+}
+
+// WatchVDS отправляет текущее состояние VDS и затем каждое изменение статуса до DELETED
+func (s *ServerAPI) WatchVDS(req *managementv1.WatchVDSRequest, stream grpc.ServerStreamingServer[managementv1.VDS]) error {
+	ctx := stream.Context()
+
+	vds, err := s.vdsService.GetByID(ctx, req.GetId())
+	if err != nil {
+		return toStatus(ctx, err)
+	}
+	if err := authorizeOwner(ctx, vds.UserID); err != nil {
+		return err
+	}
+
+	sub := s.watchHub.Subscribe(watch.KindVDS, req.GetId())
+	defer sub.Close()
+
+	return watchStream(ctx, sub,
+		func(ctx context.Context) (*managementv1.VDS, error) {
+			vds, err := s.vdsService.GetByID(ctx, req.GetId())
+			if err != nil {
+				return nil, err
+			}
+			return vdsToProto(vds), nil
+		},
+		stream.Send,
+		func(vds *managementv1.VDS) bool {
+			return vds.GetStatus() == managementv1.VDSStatus_VDS_STATUS_DELETED
+		},
+	)
+}
+
+// watchStream отправляет состояние, прочитанное load, при подписке и после каждого сигнала sub,
+// если оно изменилось. Подписка оформляется до первого чтения, поэтому изменение между
+// чтением и ожиданием не теряется. Поток завершается, когда done возвращает true,
+// а при остановке сервера - с Unavailable
+func watchStream[T proto.Message](
+	ctx context.Context,
+	sub *watch.Subscription,
+	load func(ctx context.Context) (T, error),
+	send func(T) error,
+	done func(T) bool,
+) error {
+	var last T
+	sent := false
+
+	for {
+		current, err := load(ctx)
+		if err != nil {
+			return toStatus(ctx, err)
+		}
+
+		if !sent || !proto.Equal(last, current) {
+			if err := send(current); err != nil {
+				return err
+			}
+			last, sent = current, true
+		}
+		if done(current) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return toStatus(ctx, ctx.Err())
+		case <-sub.Done():
+			return errorWithCode(codes.Unavailable, managementv1.ErrorCode_ERROR_CODE_UNAVAILABLE,
+				"server is shutting down, reconnect to continue watching")
+		case <-sub.C():
+		}
+	}
+}
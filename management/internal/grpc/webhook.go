@@ -0,0 +1,218 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/makhtech/management/internal/domain/models"
+	managementv1 "github.com/makhtech/proto/gen/go/management"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *ServerAPI) CreateWebhook(ctx context.Context, req *managementv1.CreateWebhookRequest) (*managementv1.Webhook, error) {
+	if err := authorizeOwner(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.webhookService.Create(ctx, &models.CreateWebhookRequest{
+		UserID:     req.GetUserId(),
+		URL:        req.GetUrl(),
+		Secret:     req.GetSecret(),
+		EventTypes: req.GetEventTypes(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	// Секрет показывается один раз: при создании
+	resp := webhookToProto(webhook)
+	resp.Secret = webhook.Secret
+	return resp, nil
+}
+
+func (s *ServerAPI) GetWebhook(ctx context.Context, req *managementv1.GetWebhookRequest) (*managementv1.Webhook, error) {
+	webhook, err := s.authorizeWebhook(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookToProto(webhook), nil
+}
+
+func (s *ServerAPI) UpdateWebhook(ctx context.Context, req *managementv1.UpdateWebhookRequest) (*managementv1.Webhook, error) {
+	if _, err := s.authorizeWebhook(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	domainReq := &models.UpdateWebhookRequest{
+		ID:       req.GetId(),
+		URL:      req.Url,
+		Secret:   req.Secret,
+		IsActive: req.IsActive,
+	}
+	if req.GetUpdateEventTypes() {
+		// Пустой не nil список - подписка на все события
+		domainReq.EventTypes = append([]string{}, req.GetEventTypes()...)
+	}
+
+	webhook, err := s.webhookService.Update(ctx, domainReq)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return webhookToProto(webhook), nil
+}
+
+func (s *ServerAPI) ListWebhooks(ctx context.Context, req *managementv1.ListWebhooksRequest) (*managementv1.ListWebhooksResponse, error) {
+	filter := models.WebhookFilter{
+		UserID:     req.UserId,
+		ActiveOnly: req.GetActiveOnly(),
+	}
+	if userID, restricted := ownerScope(ctx); restricted {
+		if req.UserId != nil {
+			if err := authorizeOwner(ctx, req.GetUserId()); err != nil {
+				return nil, err
+			}
+		}
+		own := int32(userID)
+		filter.UserID = &own
+	}
+
+	webhooks, next, err := s.webhookService.List(ctx, filter, pageFromProto(req))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	protoWebhooks := make([]*managementv1.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		protoWebhooks = append(protoWebhooks, webhookToProto(webhook))
+	}
+
+	return &managementv1.ListWebhooksResponse{
+		Webhooks:      protoWebhooks,
+		NextPageToken: next,
+	}, nil
+}
+
+func (s *ServerAPI) DeleteWebhook(ctx context.Context, req *managementv1.DeleteWebhookRequest) (*emptypb.Empty, error) {
+	if _, err := s.authorizeWebhook(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookService.Delete(ctx, req.GetId()); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListWebhookDeliveries возвращает страницу журнала доставок подписки
+func (s *ServerAPI) ListWebhookDeliveries(ctx context.Context, req *managementv1.ListWebhookDeliveriesRequest) (*managementv1.ListWebhookDeliveriesResponse, error) {
+	if _, err := s.authorizeWebhook(ctx, req.GetWebhookId()); err != nil {
+		return nil, err
+	}
+
+	filter := models.WebhookDeliveryFilter{
+		WebhookID: req.GetWebhookId(),
+		Created:   timeRangeFromProto(req.GetCreatedFrom(), req.GetCreatedTo()),
+	}
+	if req.Status != nil {
+		status := webhookDeliveryStatusFromProto(req.GetStatus())
+		filter.Status = &status
+	}
+
+	deliveries, next, err := s.webhookService.ListDeliveries(ctx, filter, pageFromProto(req))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	protoDeliveries := make([]*managementv1.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		protoDeliveries = append(protoDeliveries, webhookDeliveryToProto(delivery))
+	}
+
+	return &managementv1.ListWebhookDeliveriesResponse{
+		Deliveries:    protoDeliveries,
+		NextPageToken: next,
+	}, nil
+}
+
+// authorizeWebhook загружает подписку и проверяет, что вызывающий пользователь ей владеет
+func (s *ServerAPI) authorizeWebhook(ctx context.Context, id int32) (*models.Webhook, error) {
+	webhook, err := s.webhookService.GetByID(ctx, id)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	if userID, restricted := ownerScope(ctx); restricted && int64(webhook.UserID) != userID {
+		return nil, errorWithCode(codes.PermissionDenied, managementv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED,
+			"access to another user's webhook is denied")
+	}
+
+	return webhook, nil
+}
+
+// webhookToProto конвертирует domain модель в proto. Секрет не заполняется
+func webhookToProto(webhook *models.Webhook) *managementv1.Webhook {
+	return &managementv1.Webhook{
+		Id:         webhook.ID,
+		UserId:     webhook.UserID,
+		Url:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		IsActive:   webhook.IsActive,
+		CreatedAt:  timestamppb.New(webhook.CreatedAt),
+		UpdatedAt:  timestamppb.New(webhook.UpdatedAt),
+	}
+}
+
+// webhookDeliveryToProto конвертирует domain модель в proto
+func webhookDeliveryToProto(delivery *models.WebhookDelivery) *managementv1.WebhookDelivery {
+	resp := &managementv1.WebhookDelivery{
+		Id:             delivery.ID,
+		WebhookId:      delivery.WebhookID,
+		EventId:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        string(delivery.Payload),
+		Status:         webhookDeliveryStatusToProto(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      timestamppb.New(delivery.CreatedAt),
+	}
+	if delivery.Status == models.WebhookDeliveryPending {
+		resp.NextAttemptAt = timestamppb.New(delivery.NextAttemptAt)
+	}
+	if delivery.DeliveredAt != nil {
+		resp.DeliveredAt = timestamppb.New(*delivery.DeliveredAt)
+	}
+	return resp
+}
+
+func webhookDeliveryStatusToProto(status models.WebhookDeliveryStatus) managementv1.WebhookDeliveryStatus {
+	switch status {
+	case models.WebhookDeliveryPending:
+		return managementv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING
+	case models.WebhookDeliveryDelivered:
+		return managementv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED
+	case models.WebhookDeliveryDead:
+		return managementv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD
+	default:
+		return managementv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNKNOWN
+	}
+}
+
+// webhookDeliveryStatusFromProto конвертирует proto enum в статус доставки.
+// Для неизвестного значения возвращает пустой статус, который не пройдёт валидацию сервиса
+func webhookDeliveryStatusFromProto(status managementv1.WebhookDeliveryStatus) models.WebhookDeliveryStatus {
+	switch status {
+	case managementv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING:
+		return models.WebhookDeliveryPending
+	case managementv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED:
+		return models.WebhookDeliveryDelivered
+	case managementv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD:
+		return models.WebhookDeliveryDead
+	default:
+		return ""
+	}
+}
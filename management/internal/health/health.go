@@ -0,0 +1,238 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ErrShuttingDown сервис останавливается и не принимает новый трафик
+var ErrShuttingDown = errors.New("shutting down")
+
+// CheckFunc проверяет доступность зависимости. nil - зависимость готова
+type CheckFunc func(ctx context.Context) error
+
+// Config конфигурация проверок готовности
+type Config struct {
+	// Interval пауза между проверками для gRPC health
+	Interval time.Duration
+	// Timeout время на одну проверку всех зависимостей
+	Timeout time.Duration
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+	// critical - при ошибке сервис не готов. Ошибки остальных проверок только видны в /readyz
+	critical bool
+}
+
+// CheckResult результат проверки одной зависимости
+type CheckResult struct {
+	Name     string `json:"name"`
+	Critical bool   `json:"critical"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Report результат проверки готовности сервиса
+type Report struct {
+	Ready  bool          `json:"ready"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// Checker проверяет готовность зависимостей сервиса и публикует её через
+// grpc.health.v1 и HTTP /healthz, /readyz
+type Checker struct {
+	cfg      Config
+	services []string
+	server   *grpchealth.Server
+	log      *slog.Logger
+
+	mu     sync.RWMutex
+	checks []check
+
+	shuttingDown atomic.Bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New создаёт Checker. services - имена gRPC сервисов, статус которых публикуется
+// наравне с общим статусом сервера (пустое имя)
+func New(cfg Config, log *slog.Logger, services ...string) *Checker {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 3 * time.Second
+	}
+
+	c := &Checker{
+		cfg:      cfg,
+		services: append([]string{""}, services...),
+		server:   grpchealth.NewServer(),
+		log:      log,
+	}
+	// До первой проверки сервис не готов
+	c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return c
+}
+
+// Add добавляет проверку зависимости. Ошибка critical проверки делает сервис неготовым
+func (c *Checker) Add(name string, fn CheckFunc, critical bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, check{name: name, fn: fn, critical: critical})
+}
+
+// GRPCServer возвращает реализацию grpc.health.v1.Health для регистрации на gRPC сервере
+func (c *Checker) GRPCServer() healthpb.HealthServer {
+	return c.server
+}
+
+// Start запускает периодическую проверку зависимостей для gRPC health
+func (c *Checker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.wg.Add(1)
+	go c.run(ctx)
+
+	c.log.Info("health checker started", slog.Duration("interval", c.cfg.Interval))
+}
+
+// Stop останавливает периодическую проверку
+func (c *Checker) Stop() {
+	if c.cancel == nil {
+		return
+	}
+	c.cancel()
+	c.wg.Wait()
+}
+
+// Shutdown переводит сервис в NOT_SERVING до конца работы процесса,
+// чтобы балансировщики успели снять трафик до остановки серверов
+func (c *Checker) Shutdown() {
+	if c.shuttingDown.Swap(true) {
+		return
+	}
+	c.server.Shutdown()
+
+	c.log.Info("health status set to NOT_SERVING for shutdown")
+}
+
+func (c *Checker) run(ctx context.Context) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	ready := c.update(ctx, false)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ready = c.update(ctx, ready)
+		}
+	}
+}
+
+// update проверяет зависимости и обновляет статус gRPC health. Смена готовности логируется
+func (c *Checker) update(ctx context.Context, wasReady bool) bool {
+	report := c.Check(ctx)
+
+	if report.Ready {
+		c.setStatus(healthpb.HealthCheckResponse_SERVING)
+	} else {
+		c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	if report.Ready != wasReady {
+		c.log.Info("readiness changed", slog.Bool("ready", report.Ready), slog.Any("checks", report.Checks))
+	}
+
+	return report.Ready
+}
+
+func (c *Checker) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	// После Shutdown grpc health сервер сам игнорирует обновления статуса
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
+
+// Check проверяет все зависимости параллельно
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := make([]check, len(c.checks))
+	copy(checks, c.checks)
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			results[i] = CheckResult{Name: ch.name, Critical: ch.critical}
+			if err := ch.fn(ctx); err != nil {
+				results[i].Error = err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Ready: true, Checks: results}
+	if c.shuttingDown.Load() {
+		report.Ready = false
+		report.Checks = append(report.Checks, CheckResult{Name: "shutdown", Critical: true, Error: ErrShuttingDown.Error()})
+	}
+	for _, r := range results {
+		if r.Critical && r.Error != "" {
+			report.Ready = false
+		}
+	}
+
+	return report
+}
+
+// LivenessHandler обработчик /healthz: процесс жив и отвечает на запросы
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("ok"))
+	})
+}
+
+// ReadinessHandler обработчик /readyz: 200, если все critical зависимости готовы, иначе 503
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Check(r.Context())
+
+		code := http.StatusOK
+		if !report.Ready {
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			c.log.Warn("failed to write readiness report", slog.String("error", err.Error()))
+		}
+	})
+}
@@ -0,0 +1,409 @@
+package jwtverify
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ssov1 "github.com/makhtech/proto/gen/go/sso"
+)
+
+var (
+	// ErrInvalidToken токен повреждён, подпись не сходится или claims не проходят проверку
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenExpired срок действия токена истёк
+	ErrTokenExpired = errors.New("token expired")
+	// ErrUnknownKey токен подписан ключом, которого нет в наборе
+	ErrUnknownKey = errors.New("unknown signing key")
+)
+
+// Config источники открытых ключей и требования к claims.
+// Должен быть задан хотя бы один из PublicKeyFile, JWKSFile, JWKSURL
+type Config struct {
+	// PublicKeyFile PEM файл с открытыми ключами или сертификатами SSO
+	PublicKeyFile string
+	// JWKSFile файл с набором ключей в формате JWKS
+	JWKSFile string
+	// JWKSURL endpoint, с которого загружается JWKS. Перечитывается при встрече неизвестного kid
+	JWKSURL string
+	// Issuer ожидаемый iss. Пусто - не проверяется
+	Issuer string
+	// Audience ожидаемое значение в aud. Пусто - не проверяется
+	Audience string
+	// Leeway допустимое расхождение часов при проверке exp и nbf
+	Leeway time.Duration
+	// RefreshInterval минимальная пауза между перезагрузками JWKSURL
+	RefreshInterval time.Duration
+	// Timeout таймаут загрузки JWKSURL
+	Timeout time.Duration
+}
+
+// Claims данные пользователя из проверенного токена.
+// ID пользователя берётся из uid, user_id или sub, роль - числом или именем ssov1.Role
+type Claims struct {
+	UserID    int64
+	Username  string
+	Email     string
+	PhotoURL  string
+	Role      ssov1.Role
+	AppID     int32
+	ExpiresAt time.Time
+}
+
+// Verifier проверяет подпись и срок действия JWT локально, без обращения к SSO
+type Verifier struct {
+	cfg    Config
+	client *http.Client
+	log    *slog.Logger
+
+	// static ключи из файлов, загружаются один раз
+	static []publicKey
+
+	mu sync.RWMutex
+	// remote ключи с JWKSURL, заменяются целиком при перезагрузке
+	remote      []publicKey
+	lastRefresh time.Time
+}
+
+// New загружает ключи из всех заданных источников. Без ключей верификатор не создаётся
+func New(ctx context.Context, cfg Config, log *slog.Logger) (*Verifier, error) {
+	const op = "jwtverify.New"
+
+	if cfg.PublicKeyFile == "" && cfg.JWKSFile == "" && cfg.JWKSURL == "" {
+		return nil, fmt.Errorf("%s: no key source configured", op)
+	}
+	if cfg.Leeway < 0 {
+		cfg.Leeway = 0
+	}
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = time.Minute
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	v := &Verifier{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		log:    log,
+	}
+
+	if cfg.PublicKeyFile != "" {
+		pemKeys, err := loadPEMFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: public key file: %w", op, err)
+		}
+		v.static = append(v.static, pemKeys...)
+	}
+	if cfg.JWKSFile != "" {
+		fileKeys, err := loadJWKSFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: jwks file: %w", op, err)
+		}
+		v.static = append(v.static, fileKeys...)
+	}
+	if cfg.JWKSURL != "" {
+		remote, err := fetchJWKS(ctx, v.client, cfg.JWKSURL)
+		if err != nil {
+			return nil, fmt.Errorf("%s: jwks url: %w", op, err)
+		}
+		v.remote = remote
+		v.lastRefresh = time.Now()
+	}
+
+	return v, nil
+}
+
+// Verify проверяет подпись, exp, nbf, iss и aud токена и возвращает его claims
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %s", ErrInvalidToken, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature encoding", ErrInvalidToken)
+	}
+
+	if err := v.verifySignature(ctx, header.Alg, header.Kid, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("%w: payload: %s", ErrInvalidToken, err)
+	}
+
+	return v.validateClaims(raw)
+}
+
+// verifySignature ищет ключ по kid и проверяет подпись. Неизвестный kid перечитывает JWKSURL
+func (v *Verifier) verifySignature(ctx context.Context, alg, kid, signingInput string, signature []byte) error {
+	hash, err := algHash(alg)
+	if err != nil {
+		return err
+	}
+
+	candidates := v.candidates(alg, kid)
+	if len(candidates) == 0 && kid != "" && v.refresh(ctx) {
+		candidates = v.candidates(alg, kid)
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf("%w: kid %q", ErrUnknownKey, kid)
+	}
+
+	var digest []byte
+	if hash != 0 {
+		h := hash.New()
+		h.Write([]byte(signingInput))
+		digest = h.Sum(nil)
+	}
+
+	for _, key := range candidates {
+		if verifyWithKey(alg, hash, key.key, signingInput, digest, signature) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+}
+
+// candidates ключи, подходящие под kid и алгоритм токена. Ключи без kid подходят к любому токену
+func (v *Verifier) candidates(alg, kid string) []publicKey {
+	v.mu.RLock()
+	keys := append(v.static[:len(v.static):len(v.static)], v.remote...)
+	v.mu.RUnlock()
+
+	var result []publicKey
+	for _, key := range keys {
+		if kid != "" && key.kid != "" && key.kid != kid {
+			continue
+		}
+		if key.alg != "" && key.alg != alg {
+			continue
+		}
+		if !keyMatchesAlg(key.key, alg) {
+			continue
+		}
+		result = append(result, key)
+	}
+	return result
+}
+
+// refresh перечитывает JWKSURL не чаще RefreshInterval. Возвращает true, если набор ключей обновлён
+func (v *Verifier) refresh(ctx context.Context) bool {
+	if v.cfg.JWKSURL == "" {
+		return false
+	}
+
+	v.mu.Lock()
+	if time.Since(v.lastRefresh) < v.cfg.RefreshInterval {
+		v.mu.Unlock()
+		return false
+	}
+	v.lastRefresh = time.Now()
+	v.mu.Unlock()
+
+	fetched, err := fetchJWKS(ctx, v.client, v.cfg.JWKSURL)
+	if err != nil {
+		v.log.Warn("failed to refresh jwks", slog.String("url", v.cfg.JWKSURL), slog.String("error", err.Error()))
+		return false
+	}
+
+	v.mu.Lock()
+	v.remote = fetched
+	v.mu.Unlock()
+
+	v.log.Info("jwks refreshed", slog.Int("keys", len(fetched)))
+	return true
+}
+
+func (v *Verifier) validateClaims(raw map[string]any) (*Claims, error) {
+	now := time.Now()
+
+	exp, ok := numericClaim(raw["exp"])
+	if !ok {
+		return nil, fmt.Errorf("%w: missing exp", ErrInvalidToken)
+	}
+	expiresAt := time.Unix(exp, 0)
+	if now.After(expiresAt.Add(v.cfg.Leeway)) {
+		return nil, ErrTokenExpired
+	}
+
+	if nbf, ok := numericClaim(raw["nbf"]); ok && now.Add(v.cfg.Leeway).Before(time.Unix(nbf, 0)) {
+		return nil, fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+
+	if v.cfg.Issuer != "" {
+		if iss, _ := raw["iss"].(string); iss != v.cfg.Issuer {
+			return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+		}
+	}
+	if v.cfg.Audience != "" && !hasAudience(raw["aud"], v.cfg.Audience) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+
+	claims := &Claims{ExpiresAt: expiresAt}
+
+	userID, ok := int64Claim(raw, "uid", "user_id", "sub")
+	if !ok || userID <= 0 {
+		return nil, fmt.Errorf("%w: missing user id", ErrInvalidToken)
+	}
+	claims.UserID = userID
+
+	claims.Username, _ = raw["username"].(string)
+	claims.Email, _ = raw["email"].(string)
+	claims.PhotoURL, _ = raw["photo_url"].(string)
+	if appID, ok := int64Claim(raw, "app_id"); ok {
+		claims.AppID = int32(appID)
+	}
+
+	role, err := roleClaim(raw["role"])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+	claims.Role = role
+
+	return claims, nil
+}
+
+func decodeSegment(segment string, dst any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// algHash хеш-функция алгоритма подписи. Для EdDSA хеш не нужен.
+// Симметричные алгоритмы и none не поддерживаются: секрет SSO сервису не раздаётся
+func algHash(alg string) (crypto.Hash, error) {
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		return crypto.SHA256, nil
+	case "RS384", "PS384", "ES384":
+		return crypto.SHA384, nil
+	case "RS512", "PS512", "ES512":
+		return crypto.SHA512, nil
+	case "EdDSA":
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("%w: unsupported alg %q", ErrInvalidToken, alg)
+	}
+}
+
+func keyMatchesAlg(key crypto.PublicKey, alg string) bool {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")
+	case *ecdsa.PublicKey:
+		return strings.HasPrefix(alg, "ES")
+	case ed25519.PublicKey:
+		return alg == "EdDSA"
+	default:
+		return false
+	}
+}
+
+func verifyWithKey(alg string, hash crypto.Hash, key crypto.PublicKey, signingInput string, digest, signature []byte) bool {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if strings.HasPrefix(alg, "PS") {
+			return rsa.VerifyPSS(k, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil
+		}
+		return rsa.VerifyPKCS1v15(k, hash, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		// Подпись ES* - r и s фиксированной длины подряд (RFC 7518, 3.4)
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(k, digest, r, s)
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, []byte(signingInput), signature)
+	default:
+		return false
+	}
+}
+
+func numericClaim(v any) (int64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int64(n), true
+	case string:
+		i, err := strconv.ParseInt(n, 10, 64)
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// int64Claim значение первого присутствующего claim из names
+func int64Claim(raw map[string]any, names ...string) (int64, bool) {
+	for _, name := range names {
+		if v, ok := raw[name]; ok {
+			return numericClaim(v)
+		}
+	}
+	return 0, false
+}
+
+// roleClaim роль числом (значение ssov1.Role) или именем. Без claim - USER
+func roleClaim(v any) (ssov1.Role, error) {
+	switch r := v.(type) {
+	case nil:
+		return ssov1.Role_USER, nil
+	case float64:
+		if _, ok := ssov1.Role_name[int32(r)]; !ok {
+			return 0, fmt.Errorf("unknown role %v", r)
+		}
+		return ssov1.Role(int32(r)), nil
+	case string:
+		value, ok := ssov1.Role_value[strings.ToUpper(r)]
+		if !ok {
+			return 0, fmt.Errorf("unknown role %q", r)
+		}
+		return ssov1.Role(value), nil
+	default:
+		return 0, fmt.Errorf("invalid role claim")
+	}
+}
+
+func hasAudience(v any, audience string) bool {
+	switch aud := v.(type) {
+	case string:
+		return aud == audience
+	case []any:
+		for _, a := range aud {
+			if s, ok := a.(string); ok && s == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
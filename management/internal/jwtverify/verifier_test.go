@@ -0,0 +1,221 @@
+package jwtverify
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ssov1 "github.com/makhtech/proto/gen/go/sso"
+)
+
+const testKid = "sso-1"
+
+// newTestVerifier поднимает JWKS endpoint с открытой частью key под kid testKid
+func newTestVerifier(t *testing.T, key *rsa.PrivateKey) *Verifier {
+	t.Helper()
+
+	jwks, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": testKid,
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(jwks)
+	}))
+	t.Cleanup(srv.Close)
+
+	v, err := New(context.Background(), Config{JWKSURL: srv.URL, Issuer: "sso"}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return v
+}
+
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signRS256 собирает токен с подписью RS256 ключом key
+func signRS256(t *testing.T, key *rsa.PrivateKey, header, claims map[string]any) string {
+	t.Helper()
+
+	input := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(input))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"uid":      42,
+		"username": "alice",
+		"role":     "ADMIN",
+		"app_id":   1,
+		"iss":      "sso",
+		"exp":      time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestVerify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := newTestVerifier(t, key)
+
+	rs256 := map[string]any{"alg": "RS256", "typ": "JWT", "kid": testKid}
+
+	with := func(changes map[string]any) map[string]any {
+		claims := validClaims()
+		for k, val := range changes {
+			if val == nil {
+				delete(claims, k)
+				continue
+			}
+			claims[k] = val
+		}
+		return claims
+	}
+
+	// HS256 с открытым ключом в роли секрета: классическая подмена алгоритма
+	hsToken := func() string {
+		pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		input := encodeSegment(t, map[string]any{"alg": "HS256", "kid": testKid}) + "." + encodeSegment(t, validClaims())
+		mac := hmac.New(sha256.New, pub)
+		mac.Write([]byte(input))
+		return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "valid",
+			token: signRS256(t, key, rs256, validClaims()),
+		},
+		{
+			name:  "valid without kid",
+			token: signRS256(t, key, map[string]any{"alg": "RS256"}, validClaims()),
+		},
+		{
+			name:    "alg none",
+			token:   encodeSegment(t, map[string]any{"alg": "none"}) + "." + encodeSegment(t, validClaims()) + ".",
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "alg HS256 signed with public key",
+			token:   hsToken(),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "alg ES256 for rsa key",
+			token:   signRS256(t, key, map[string]any{"alg": "ES256", "kid": testKid}, validClaims()),
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:    "unknown kid",
+			token:   signRS256(t, key, map[string]any{"alg": "RS256", "kid": "rotated"}, validClaims()),
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:    "signed by another key with known kid",
+			token:   signRS256(t, otherKey, rs256, validClaims()),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "tampered payload",
+			token: func() string {
+				token := signRS256(t, key, rs256, validClaims())
+				parts := strings.Split(token, ".")
+				return parts[0] + "." + encodeSegment(t, with(map[string]any{"role": "SERVICE"})) + "." + parts[2]
+			}(),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "expired",
+			token:   signRS256(t, key, rs256, with(map[string]any{"exp": time.Now().Add(-time.Minute).Unix()})),
+			wantErr: ErrTokenExpired,
+		},
+		{
+			name:    "missing exp",
+			token:   signRS256(t, key, rs256, with(map[string]any{"exp": nil})),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "not valid yet",
+			token:   signRS256(t, key, rs256, with(map[string]any{"nbf": time.Now().Add(time.Hour).Unix()})),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "wrong issuer",
+			token:   signRS256(t, key, rs256, with(map[string]any{"iss": "someone-else"})),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "malformed",
+			token:   "not-a-jwt",
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := v.Verify(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() unexpected error: %v", err)
+			}
+			if claims.UserID != 42 || claims.Username != "alice" || claims.Role != ssov1.Role_ADMIN || claims.AppID != 1 {
+				t.Fatalf("Verify() claims = %+v", claims)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/makhtech/management/internal/repository"
+	"github.com/makhtech/management/internal/repository/postgres"
+	"github.com/makhtech/management/pkg/ratelimiter"
+	"github.com/makhtech/management/pkg/ttlcache"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectTimeout время на запросы к БД во время одного scrape
+const collectTimeout = 5 * time.Second
+
+const namespace = "management"
+
+var (
+	dbAcquiredConns = desc("db", "pool_acquired_connections", "Number of currently acquired connections in the pool.")
+	dbIdleConns     = desc("db", "pool_idle_connections", "Number of currently idle connections in the pool.")
+	dbTotalConns    = desc("db", "pool_total_connections", "Total number of connections in the pool.")
+	dbMaxConns      = desc("db", "pool_max_connections", "Maximum size of the pool.")
+	dbAcquires      = desc("db", "pool_acquires_total", "Cumulative count of successful acquires from the pool.")
+	dbEmptyAcquires = desc("db", "pool_empty_acquires_total", "Cumulative count of acquires that waited for a connection.")
+	dbCanceled      = desc("db", "pool_canceled_acquires_total", "Cumulative count of acquires canceled by context.")
+	dbAcquireWait   = desc("db", "pool_acquire_wait_seconds_total", "Total time spent waiting for a connection.")
+
+	rateLimiterBuckets = desc("rate_limiter", "active_buckets", "Number of access tokens with an active rate limit bucket.")
+
+	authCacheHits      = desc("auth_cache", "hits_total", "Number of tokens served from the auth cache.")
+	authCacheMisses    = desc("auth_cache", "misses_total", "Number of tokens validated because they were not cached.")
+	authCacheEvictions = desc("auth_cache", "evictions_total", "Number of cached tokens evicted because the cache was full.")
+	authCacheSize      = desc("auth_cache", "entries", "Number of tokens currently cached.")
+
+	taskQueueDepth = desc("tasks", "queue_depth", "Number of unfinished tasks by status.", "status")
+
+	outboxPending = desc("outbox", "pending_events", "Number of domain events waiting to be published.")
+
+	nodeLabels      = []string{"node_id", "node"}
+	nodeVDSCount    = desc("node", "vds_count", "Number of VDS placed on the node.", nodeLabels...)
+	nodeCPUUsage    = desc("node", "cpu_usage_ratio", "Share of node vCPUs allocated to VDS.", nodeLabels...)
+	nodeRAMUsage    = desc("node", "ram_usage_ratio", "Share of node RAM allocated to VDS.", nodeLabels...)
+	nodeDiskUsage   = desc("node", "disk_usage_ratio", "Share of node disk allocated to VDS.", nodeLabels...)
+	nodeFreeCPU     = desc("node", "free_cpu", "Number of unallocated vCPUs on the node.", nodeLabels...)
+	nodeFreeRAM     = desc("node", "free_ram_megabytes", "Unallocated RAM on the node in MB.", nodeLabels...)
+	nodeFreeDisk    = desc("node", "free_disk_gigabytes", "Unallocated disk on the node in GB.", nodeLabels...)
+	collectFailures = desc("metrics", "collect_errors", "Whether the last collection of a source failed.", "source")
+)
+
+func desc(subsystem, name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, name), help, labels, nil)
+}
+
+// Sources источники метрик состояния сервиса. Незаданные источники пропускаются
+type Sources struct {
+	DB          *postgres.Database
+	RateLimiter *ratelimiter.TokenBucket
+	TaskRepo    repository.TaskRepository
+	NodeRepo    repository.NodeRepository
+	OutboxRepo  repository.OutboxRepository
+	// AuthCache счётчики кэша токенов. ok=false - кэш выключен
+	AuthCache func() (ttlcache.Stats, bool)
+}
+
+// Collector собирает метрики пула соединений, rate limiter, очереди задач, outbox и загрузки нод в момент scrape
+type Collector struct {
+	src Sources
+	log *slog.Logger
+}
+
+// NewCollector создаёт collector. Регистрируется в prometheus.Registerer вызывающим кодом
+func NewCollector(src Sources, log *slog.Logger) *Collector {
+	return &Collector{src: src, log: log}
+}
+
+// Describe реализует prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		dbAcquiredConns, dbIdleConns, dbTotalConns, dbMaxConns,
+		dbAcquires, dbEmptyAcquires, dbCanceled, dbAcquireWait,
+		rateLimiterBuckets,
+		authCacheHits, authCacheMisses, authCacheEvictions, authCacheSize,
+		taskQueueDepth,
+		outboxPending,
+		nodeVDSCount, nodeCPUUsage, nodeRAMUsage, nodeDiskUsage, nodeFreeCPU, nodeFreeRAM, nodeFreeDisk,
+		collectFailures,
+	} {
+		ch <- d
+	}
+}
+
+// Collect реализует prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	if c.src.DB != nil {
+		c.collectDB(ch)
+	}
+	if c.src.RateLimiter != nil {
+		ch <- prometheus.MustNewConstMetric(rateLimiterBuckets, prometheus.GaugeValue, float64(c.src.RateLimiter.Stats()))
+	}
+	if c.src.AuthCache != nil {
+		c.collectAuthCache(ch)
+	}
+	if c.src.TaskRepo != nil {
+		c.failed(ch, "tasks", c.collectTasks(ctx, ch))
+	}
+	if c.src.NodeRepo != nil {
+		c.failed(ch, "nodes", c.collectNodes(ctx, ch))
+	}
+	if c.src.OutboxRepo != nil {
+		c.failed(ch, "outbox", c.collectOutbox(ctx, ch))
+	}
+}
+
+func (c *Collector) collectDB(ch chan<- prometheus.Metric) {
+	stat := c.src.DB.Stats()
+
+	ch <- prometheus.MustNewConstMetric(dbAcquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(dbIdleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(dbTotalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(dbMaxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(dbAcquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(dbEmptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(dbCanceled, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(dbAcquireWait, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
+
+func (c *Collector) collectAuthCache(ch chan<- prometheus.Metric) {
+	stats, ok := c.src.AuthCache()
+	if !ok {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(authCacheHits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(authCacheMisses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(authCacheEvictions, prometheus.CounterValue, float64(stats.Evictions))
+	ch <- prometheus.MustNewConstMetric(authCacheSize, prometheus.GaugeValue, float64(stats.Size))
+}
+
+func (c *Collector) collectTasks(ctx context.Context, ch chan<- prometheus.Metric) error {
+	counts, err := c.src.TaskRepo.CountQueued(ctx)
+	if err != nil {
+		return err
+	}
+
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(taskQueueDepth, prometheus.GaugeValue, float64(count), string(status))
+	}
+	return nil
+}
+
+func (c *Collector) collectOutbox(ctx context.Context, ch chan<- prometheus.Metric) error {
+	pending, err := c.src.OutboxRepo.CountPending(ctx)
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(outboxPending, prometheus.GaugeValue, float64(pending))
+	return nil
+}
+
+func (c *Collector) collectNodes(ctx context.Context, ch chan<- prometheus.Metric) error {
+	nodes, err := c.src.NodeRepo.ListUtilization(ctx, "", nil)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes {
+		labels := []string{formatID(n.NodeID), n.NodeName}
+
+		ch <- prometheus.MustNewConstMetric(nodeVDSCount, prometheus.GaugeValue, float64(n.VDSCount), labels...)
+		ch <- prometheus.MustNewConstMetric(nodeCPUUsage, prometheus.GaugeValue, n.CPUUsagePct/100, labels...)
+		ch <- prometheus.MustNewConstMetric(nodeRAMUsage, prometheus.GaugeValue, n.RAMUsagePct/100, labels...)
+		ch <- prometheus.MustNewConstMetric(nodeDiskUsage, prometheus.GaugeValue, n.DiskUsagePct/100, labels...)
+		ch <- prometheus.MustNewConstMetric(nodeFreeCPU, prometheus.GaugeValue, float64(n.FreeCPU()), labels...)
+		ch <- prometheus.MustNewConstMetric(nodeFreeRAM, prometheus.GaugeValue, float64(n.FreeRAM()), labels...)
+		ch <- prometheus.MustNewConstMetric(nodeFreeDisk, prometheus.GaugeValue, float64(n.FreeDisk()), labels...)
+	}
+	return nil
+}
+
+// failed отдаёт признак ошибки сбора источника, чтобы пропавшие метрики были видны на дашборде
+func (c *Collector) failed(ch chan<- prometheus.Metric, source string, err error) {
+	value := 0.0
+	if err != nil {
+		value = 1
+		c.log.Warn("failed to collect metrics", slog.String("source", source), slog.String("error", err.Error()))
+	}
+	ch <- prometheus.MustNewConstMetric(collectFailures, prometheus.GaugeValue, value, source)
+}
@@ -0,0 +1,192 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/makhtech/management/internal/repository"
+)
+
+// cleanupInterval как часто удаляются опубликованные события старше Retention
+const cleanupInterval = time.Hour
+
+// Config конфигурация relay
+type Config struct {
+	// Interval пауза между проверками outbox, когда публиковать нечего
+	Interval time.Duration
+	// BatchSize сколько событий берётся за один запрос
+	BatchSize int
+	// Lease на сколько событие закрепляется за репликой. Если реплика упала,
+	// событие после Lease публикует другая
+	Lease time.Duration
+	// MaxBackoff наибольшая пауза между повторами неудачной публикации
+	MaxBackoff time.Duration
+	// Retention сколько хранятся опубликованные события
+	Retention time.Duration
+}
+
+// Relay публикует события outbox в sink не меньше одного раза. События одного ordering_key
+// уходят строго по порядку: следующее ждёт, пока не будет опубликовано предыдущее,
+// в том числе между повторами после ошибки
+type Relay struct {
+	repo repository.OutboxRepository
+	sink Sink
+	cfg  Config
+	log  *slog.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New создаёт relay. Sink закрывается в Stop
+func New(repo repository.OutboxRepository, sink Sink, cfg Config, log *slog.Logger) *Relay {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = time.Minute
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 5 * time.Minute
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = 7 * 24 * time.Hour
+	}
+
+	return &Relay{
+		repo: repo,
+		sink: sink,
+		cfg:  cfg,
+		log:  log,
+	}
+}
+
+// Start запускает публикацию событий
+func (r *Relay) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.wg.Add(1)
+	go r.run(ctx)
+
+	r.log.Info("outbox relay started",
+		slog.Duration("interval", r.cfg.Interval),
+		slog.Int("batch_size", r.cfg.BatchSize),
+	)
+}
+
+// Stop останавливает публикацию и закрывает sink. События, взятые в работу, но не
+// подтверждённые sink, будут опубликованы повторно после Lease
+func (r *Relay) Stop() {
+	if r.cancel == nil {
+		return
+	}
+	r.cancel()
+	r.wg.Wait()
+
+	if err := r.sink.Close(); err != nil {
+		r.log.Warn("failed to close outbox sink", slog.String("error", err.Error()))
+	}
+
+	r.log.Info("outbox relay stopped")
+}
+
+func (r *Relay) run(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.drain(ctx)
+
+			if time.Since(lastCleanup) >= cleanupInterval {
+				r.cleanup(ctx)
+				lastCleanup = time.Now()
+			}
+		}
+	}
+}
+
+// drain публикует события, пока они есть
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		events, err := r.repo.Claim(ctx, r.cfg.BatchSize, r.cfg.Lease)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.log.Error("failed to claim outbox events", slog.String("error", err.Error()))
+			}
+			return
+		}
+		if len(events) == 0 {
+			return
+		}
+
+		published := make([]int64, 0, len(events))
+		for _, event := range events {
+			err := r.sink.Publish(ctx, NewMessage(event))
+			if err == nil {
+				published = append(published, event.ID)
+				continue
+			}
+			if ctx.Err() != nil {
+				// Остановка: событие вернётся в очередь после Lease, попытку не считаем
+				break
+			}
+
+			next := time.Now().Add(r.backoff(event.Attempts))
+			r.log.Warn("failed to publish outbox event",
+				slog.Int64("event_id", event.ID),
+				slog.String("type", event.Type),
+				slog.String("ordering_key", event.OrderingKey),
+				slog.Int("attempt", int(event.Attempts)+1),
+				slog.Time("next_attempt", next),
+				slog.String("error", err.Error()),
+			)
+			if err := r.repo.MarkFailed(ctx, event.ID, err.Error(), next); err != nil {
+				r.log.Error("failed to record outbox failure", slog.String("error", err.Error()))
+			}
+		}
+
+		// Подтверждаем и при остановке: эти события sink уже принял
+		mctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		err = r.repo.MarkPublished(mctx, published)
+		cancel()
+		if err != nil {
+			// События будут опубликованы повторно после Lease
+			r.log.Error("failed to mark outbox events published", slog.String("error", err.Error()))
+			return
+		}
+	}
+}
+
+// backoff пауза перед следующей попыткой: 1с, 2с, 4с... не больше MaxBackoff
+func (r *Relay) backoff(attempts int32) time.Duration {
+	if attempts >= 30 {
+		return r.cfg.MaxBackoff
+	}
+	return min(time.Second<<attempts, r.cfg.MaxBackoff)
+}
+
+func (r *Relay) cleanup(ctx context.Context) {
+	deleted, err := r.repo.DeletePublished(ctx, time.Now().Add(-r.cfg.Retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			r.log.Error("failed to delete published outbox events", slog.String("error", err.Error()))
+		}
+		return
+	}
+	if deleted > 0 {
+		r.log.Debug("published outbox events deleted", slog.Int64("count", deleted))
+	}
+}
@@ -0,0 +1,238 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/makhtech/management/internal/domain/models"
+)
+
+// Типы sink в конфигурации
+const (
+	SinkStdout  = "stdout"
+	SinkFile    = "file"
+	SinkWebhook = "webhook"
+)
+
+// Message событие в том виде, в котором оно уходит во внешние системы.
+// Доставка не меньше одного раза: получатель отбрасывает повторы по ID
+type Message struct {
+	ID            int64           `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   int64           `json:"aggregate_id"`
+	OrderingKey   string          `json:"ordering_key"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// NewMessage собирает сообщение из события outbox
+func NewMessage(e *models.OutboxEvent) Message {
+	return Message{
+		ID:            e.ID,
+		Type:          e.Type,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		OrderingKey:   e.OrderingKey,
+		OccurredAt:    e.CreatedAt,
+		Payload:       e.Payload,
+	}
+}
+
+// Sink получатель событий. Publish возвращает nil только после того, как получатель
+// принял событие: иначе событие будет отправлено повторно
+type Sink interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// SinkConfig конфигурация sink для NewSink
+type SinkConfig struct {
+	// Type stdout, file или webhook
+	Type string
+	// Path файл для sink file
+	Path string
+	// URL адрес для sink webhook
+	URL string
+	// Timeout время на один запрос webhook
+	Timeout time.Duration
+}
+
+// NewSink создаёт sink по конфигурации. Брокер сообщений подключается в коде через NewBrokerSink
+func NewSink(cfg SinkConfig) (Sink, error) {
+	switch cfg.Type {
+	case SinkStdout:
+		return NewWriterSink(os.Stdout, nil), nil
+	case SinkFile:
+		if cfg.Path == "" {
+			return nil, errors.New("outbox: file sink requires a path")
+		}
+		f, err := os.OpenFile(cfg.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("outbox: failed to open sink file: %w", err)
+		}
+		return NewWriterSink(f, f), nil
+	case SinkWebhook:
+		if cfg.URL == "" {
+			return nil, errors.New("outbox: webhook sink requires a url")
+		}
+		return NewWebhookSink(cfg.URL, cfg.Timeout), nil
+	default:
+		return nil, fmt.Errorf("outbox: unknown sink %q (available: %s, %s, %s)", cfg.Type, SinkStdout, SinkFile, SinkWebhook)
+	}
+}
+
+// WriterSink пишет события строками JSON. Для разработки: stdout или файл
+type WriterSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewWriterSink создаёт sink, пишущий в w. closer закрывается в Close, nil - закрывать нечего
+func NewWriterSink(w io.Writer, closer io.Closer) *WriterSink {
+	return &WriterSink{w: w, closer: closer}
+}
+
+func (s *WriterSink) Publish(_ context.Context, msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+func (s *WriterSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// WebhookSink отправляет каждое событие POST запросом с JSON телом.
+// Событие принято, если получатель ответил 2xx
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink создаёт webhook sink. timeout 0 - 10 секунд
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", strconv.FormatInt(msg.ID, 10))
+	req.Header.Set("X-Event-Type", msg.Type)
+	req.Header.Set("X-Ordering-Key", msg.OrderingKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *WebhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// Broker клиент брокера сообщений (Kafka, NATS, RabbitMQ и т.п.)
+type Broker interface {
+	// Publish отправляет body в topic и возвращает nil после подтверждения брокером.
+	// Сообщения с одним key брокер должен хранить по порядку (партиция, message group)
+	Publish(ctx context.Context, topic, key string, body []byte, headers map[string]string) error
+	Close() error
+}
+
+// BrokerSink публикует события в брокер: ключ сообщения - ordering_key события
+type BrokerSink struct {
+	broker Broker
+	topic  string
+}
+
+// NewBrokerSink создаёт sink поверх broker с публикацией в topic
+func NewBrokerSink(broker Broker, topic string) *BrokerSink {
+	return &BrokerSink{broker: broker, topic: topic}
+}
+
+func (s *BrokerSink) Publish(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return s.broker.Publish(ctx, s.topic, msg.OrderingKey, body, map[string]string{
+		"event_id":   strconv.FormatInt(msg.ID, 10),
+		"event_type": msg.Type,
+	})
+}
+
+func (s *BrokerSink) Close() error {
+	return s.broker.Close()
+}
+
+// MultiSink публикует событие во все sink по порядку. Если какой-то sink не принял событие,
+// при повторе оно уйдёт во все sink заново, поэтому каждый из них должен отбрасывать повторы
+type MultiSink struct {
+	sinks []Sink
+}
+
+// NewMultiSink создаёт sink, публикующий в sinks
+func NewMultiSink(sinks ...Sink) *MultiSink {
+	return &MultiSink{sinks: sinks}
+}
+
+func (s *MultiSink) Publish(ctx context.Context, msg Message) error {
+	for _, sink := range s.sinks {
+		if err := sink.Publish(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MultiSink) Close() error {
+	var errs []error
+	for _, sink := range s.sinks {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
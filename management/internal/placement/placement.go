@@ -0,0 +1,105 @@
+package placement
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// Requirements ресурсы, необходимые VDS на ноде
+type Requirements struct {
+	CPU    int32
+	RAMMB  int32
+	DiskGB int32
+}
+
+// RequirementsFromPlan ресурсы VDS указанного тарифа
+func RequirementsFromPlan(plan *models.Plan) Requirements {
+	return Requirements{
+		CPU:    plan.CPU,
+		RAMMB:  plan.RAMMB,
+		DiskGB: plan.DiskGB,
+	}
+}
+
+// Constraints ограничения на выбор ноды. Пустые поля не ограничивают выбор
+type Constraints struct {
+	Region string
+	// Labels должны присутствовать у ноды с теми же значениями
+	Labels map[string]string
+}
+
+// Fits проверяет, что на ноде хватает свободных ресурсов для req
+func Fits(node *models.NodeUtilization, req Requirements) bool {
+	return node.FreeCPU() >= req.CPU &&
+		node.FreeRAM() >= req.RAMMB &&
+		node.FreeDisk() >= req.DiskGB
+}
+
+// Scheduler выбирает ноды для новых VDS по данным view node_utilization
+type Scheduler struct {
+	nodeRepo repository.NodeRepository
+	strategy Strategy
+	log      *slog.Logger
+}
+
+// New создает планировщик размещения с заданной стратегией
+func New(nodeRepo repository.NodeRepository, strategy Strategy, log *slog.Logger) *Scheduler {
+	return &Scheduler{
+		nodeRepo: nodeRepo,
+		strategy: strategy,
+		log:      log,
+	}
+}
+
+// Candidates возвращает активные ноды, подходящие под ограничения и вмещающие req,
+// от наиболее предпочтительной. Если подходящих нод нет - repository.ErrInsufficientResources
+func (s *Scheduler) Candidates(ctx context.Context, req Requirements, c Constraints) ([]*models.NodeUtilization, error) {
+	const op = "placement.Scheduler.Candidates"
+
+	nodes, err := s.nodeRepo.ListUtilization(ctx, c.Region, c.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	type scored struct {
+		node  *models.NodeUtilization
+		score float64
+	}
+	candidates := make([]scored, 0, len(nodes))
+	for _, node := range nodes {
+		if !Fits(node, req) {
+			continue
+		}
+		candidates = append(candidates, scored{node: node, score: s.strategy.Score(node, req)})
+	}
+
+	s.log.Debug("placement candidates evaluated",
+		slog.String("op", op),
+		slog.String("strategy", s.strategy.Name()),
+		slog.Int("nodes", len(nodes)),
+		slog.Int("candidates", len(candidates)),
+	)
+
+	if len(candidates) == 0 {
+		return nil, repository.ErrInsufficientResources
+	}
+
+	// При равной оценке порядок детерминирован по ID ноды
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].node.NodeID < candidates[j].node.NodeID
+	})
+
+	result := make([]*models.NodeUtilization, 0, len(candidates))
+	for _, c := range candidates {
+		result = append(result, c.node)
+	}
+	return result, nil
+}
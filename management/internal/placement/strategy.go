@@ -0,0 +1,117 @@
+package placement
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/makhtech/management/internal/domain/models"
+)
+
+// Имена встроенных стратегий
+const (
+	StrategyBinPack     = "bin-packing"
+	StrategySpread      = "spread"
+	StrategyLeastLoaded = "least-loaded"
+)
+
+// Strategy оценивает ноду, на которую подходит VDS. Чем больше Score, тем предпочтительнее нода.
+// Score вызывается только для нод, на которых хватает ресурсов
+type Strategy interface {
+	Name() string
+	Score(node *models.NodeUtilization, req Requirements) float64
+}
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]Strategy{}
+)
+
+func init() {
+	Register(BinPack{})
+	Register(Spread{})
+	Register(LeastLoaded{})
+}
+
+// Register добавляет стратегию в реестр. Стратегия с тем же именем заменяется
+func Register(s Strategy) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+
+	strategies[s.Name()] = s
+}
+
+// ByName возвращает зарегистрированную стратегию по имени
+func ByName(name string) (Strategy, error) {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+
+	s, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown placement strategy %q", name)
+	}
+	return s, nil
+}
+
+// Names возвращает имена зарегистрированных стратегий
+func Names() []string {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BinPack плотно заполняет ноды: выбирается нода с наибольшей загрузкой после размещения.
+// Оставляет целые ноды свободными под крупные тарифы
+type BinPack struct{}
+
+func (BinPack) Name() string { return StrategyBinPack }
+
+func (BinPack) Score(node *models.NodeUtilization, req Requirements) float64 {
+	return meanLoad(node, req)
+}
+
+// Spread распределяет VDS равномерно: выбирается нода с наименьшим числом VDS,
+// при равенстве - менее загруженная
+type Spread struct{}
+
+func (Spread) Name() string { return StrategySpread }
+
+func (Spread) Score(node *models.NodeUtilization, req Requirements) float64 {
+	// meanLoad лежит в [0, 1] и влияет только на ноды с одинаковым числом VDS
+	return -float64(node.VDSCount) - meanLoad(node, req)
+}
+
+// LeastLoaded выбирает ноду, у которой самый загруженный ресурс после размещения загружен меньше всего
+type LeastLoaded struct{}
+
+func (LeastLoaded) Name() string { return StrategyLeastLoaded }
+
+func (LeastLoaded) Score(node *models.NodeUtilization, req Requirements) float64 {
+	cpu, ram, disk := loadAfter(node, req)
+	return -max(cpu, ram, disk)
+}
+
+// loadAfter доли занятых CPU, RAM и диска ноды после размещения req
+func loadAfter(node *models.NodeUtilization, req Requirements) (cpu, ram, disk float64) {
+	return ratio(node.UsedCPU+req.CPU, node.MaxCPU),
+		ratio(node.UsedRAM+req.RAMMB, node.MaxRAM),
+		ratio(node.UsedDisk+req.DiskGB, node.MaxDisk)
+}
+
+func meanLoad(node *models.NodeUtilization, req Requirements) float64 {
+	cpu, ram, disk := loadAfter(node, req)
+	return (cpu + ram + disk) / 3
+}
+
+func ratio(used, total int32) float64 {
+	if total <= 0 {
+		return 1
+	}
+	return float64(used) / float64(total)
+}
@@ -8,11 +8,41 @@ var (
 	ErrAppNotFound    = errors.New("app not found")
 	ErrUserRoleExists = errors.New("user role already exists or (user, app) not found")
 	ErrPlanNotFound   = errors.New("plan not found")
+	ErrPlanInUse      = errors.New("plan is used by vds")
+	ErrPlanArchived   = errors.New("plan is archived")
+	ErrNodeNotFound   = errors.New("node not found")
+	ErrNodeExists     = errors.New("node already exists")
+	ErrNodeInUse      = errors.New("node is in use by vds")
+	ErrVDSNotFound    = errors.New("vds not found")
+	ErrTaskInProgress = errors.New("another task is already in progress for vds")
+	ErrTaskNotFound   = errors.New("task not found")
+	ErrNoPendingTasks = errors.New("no pending tasks")
+	ErrIPPoolNotFound = errors.New("ip pool not found")
+	ErrIPPoolOverlaps = errors.New("ip pool overlaps an existing pool")
+	ErrIPPoolInUse    = errors.New("ip pool has allocated addresses")
+	ErrNoFreeIP       = errors.New("no free ip addresses in pool")
+	ErrIPUnavailable  = errors.New("ip address is not available for allocation")
+
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	ErrInsufficientResources = errors.New("no node has enough free resources")
+	ErrReservationUsed       = errors.New("reservation already pays for another vds")
+	ErrSubscriptionChanged   = errors.New("vds subscription changed concurrently")
+	ErrVDSSuspended          = errors.New("vds is suspended for non-payment")
+	ErrInvalidVDSState       = errors.New("action is not allowed in the current vds status")
+
+	ErrInvalidTaskTransition = errors.New("invalid task status transition")
+	ErrTaskTypeNotAllowed    = errors.New("task type cannot be queued directly")
+
+	ErrInvalidPageToken = errors.New("invalid page token")
+	ErrInvalidOrderBy   = errors.New("invalid order_by")
 
 	ErrUsernameUnique = errors.New("username must be unique")
 	ErrEmailUnique    = errors.New("email must be unique")
 
-	IdempotentKeyNotFound = errors.New("idempotent key not found")
+	IdempotentKeyNotFound      = errors.New("idempotent key not found")
+	ErrIdempotentKeyMismatch   = errors.New("idempotency key was used with a different request")
+	ErrIdempotentKeyInProgress = errors.New("request with this idempotency key is still in progress")
 
 	// Transaction errors
 	ErrInsufficientFunds      = errors.New("insufficient funds")
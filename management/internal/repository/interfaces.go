@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/makhtech/management/internal/domain/models"
 )
@@ -12,7 +13,136 @@ type PlanRepository interface {
 	GetByID(ctx context.Context, id int32) (*models.Plan, error)
 	Update(ctx context.Context, req *models.UpdatePlanRequest) (*models.Plan, error)
 	Delete(ctx context.Context, id int32) error
-	List(ctx context.Context, activeOnly bool) ([]*models.Plan, error)
+	Archive(ctx context.Context, id int32) (*models.Plan, error)
+	// List возвращает страницу планов и токен следующей страницы (пусто - страница последняя)
+	List(ctx context.Context, filter models.PlanFilter, page models.PageRequest) ([]*models.Plan, string, error)
+	ListArchived(ctx context.Context, page models.PageRequest) ([]*models.Plan, string, error)
+}
+
+// NodeRepository интерфейс для работы с Proxmox нодами
+type NodeRepository interface {
+	Create(ctx context.Context, req *models.CreateNodeRequest) (*models.Node, error)
+	GetByID(ctx context.Context, id int32) (*models.Node, error)
+	Update(ctx context.Context, req *models.UpdateNodeRequest) (*models.Node, error)
+	Delete(ctx context.Context, id int32) error
+	List(ctx context.Context, filter models.NodeFilter, page models.PageRequest) ([]*models.Node, string, error)
+	GetUtilization(ctx context.Context, id int32) (*models.NodeUtilization, error)
+	ListUtilization(ctx context.Context, region string, labels map[string]string) ([]*models.NodeUtilization, error)
+}
+
+// VDSRepository интерфейс для работы с VDS
+type VDSRepository interface {
+	// Create создаёт VDS в статусе creating и задачу create в одной транзакции
+	Create(ctx context.Context, req *models.CreateVDSRequest) (*models.VDS, *models.Task, error)
+	GetByID(ctx context.Context, id int32) (*models.VDS, error)
+	GetByReservationID(ctx context.Context, reservationID string) (*models.VDS, error)
+	ListByUser(ctx context.Context, filter models.VDSFilter, page models.PageRequest) ([]*models.VDS, string, error)
+	UpdateStatus(ctx context.Context, id int32, status models.VDSStatus) (*models.VDS, error)
+	// AllocateIP назначает VDS адреса из пулов её ноды: переданные явно или следующие свободные
+	AllocateIP(ctx context.Context, req *models.AllocateIPRequest) (*models.VDS, error)
+	// MarkDeleting переводит VDS в статус deleting и создаёт задачу delete в одной транзакции
+	MarkDeleting(ctx context.Context, id int32) (*models.Task, error)
+	// QueuePower ставит задачу питания, если статус VDS её допускает и других задач по VDS нет
+	QueuePower(ctx context.Context, id int32, action models.TaskType) (*models.Task, error)
+	// MarkDeleted переводит VDS в статус deleted и освобождает её адреса в одной транзакции
+	MarkDeleted(ctx context.Context, id int32) (*models.VDS, error)
+	// SettleBilling записывает итог резервирования оплаты, если он ещё не записан
+	SettleBilling(ctx context.Context, id int32, status models.BillingStatus) (bool, error)
+	// SettleRenewal записывает списание оплаты продления, если оно ещё не записано
+	SettleRenewal(ctx context.Context, reservationID string) (bool, error)
+	// ListUnsettled возвращает резервирования VDS с завершённой задачей create и продлений без записанного итога
+	ListUnsettled(ctx context.Context, olderThan time.Duration, limit int) ([]*models.BillingSettlement, error)
+
+	// Renew продлевает подписку на months месяцев, снимает приостановку и записывает событие.
+	// Непустой reservationID сохраняется как неподтверждённая оплата продления
+	Renew(ctx context.Context, id int32, expectedExpiresAt time.Time, months int, reservationID string, details map[string]string) (*models.VDS, *models.Task, error)
+	// SetAutoRenewToken сохраняет зашифрованную авторизацию автопродления, nil - отключает автопродление
+	SetAutoRenewToken(ctx context.Context, id int32, token []byte) (*models.VDS, error)
+	GetAutoRenewToken(ctx context.Context, id int32) ([]byte, error)
+	// RotateAutoRenewToken заменяет авторизацию автопродления, если она не менялась с момента чтения
+	RotateAutoRenewToken(ctx context.Context, id int32, old, token []byte) (bool, error)
+	// ClaimRenewable отмечает попытку автопродления и возвращает VDS, подписка которых истекает раньше renewBefore
+	ClaimRenewable(ctx context.Context, renewBefore time.Time, retryAfter time.Duration, limit int) ([]*models.VDS, error)
+	ListSuspendable(ctx context.Context, expiredBefore time.Time, limit int) ([]*models.VDS, error)
+	// Suspend приостанавливает VDS с истёкшей подпиской и записывает событие в одной транзакции
+	Suspend(ctx context.Context, id int32, expiredBefore time.Time, event *models.VDSEvent) (*models.Task, error)
+	ListDeletable(ctx context.Context, expiredBefore time.Time, limit int) ([]*models.VDS, error)
+	// QueueExpiredDeletion ставит VDS с истёкшей подпиской на удаление и записывает событие в одной транзакции
+	QueueExpiredDeletion(ctx context.Context, id int32, expiredBefore time.Time, event *models.VDSEvent) (*models.Task, error)
+	AddEvent(ctx context.Context, event *models.VDSEvent) error
+	ListEvents(ctx context.Context, filter models.VDSEventFilter, page models.PageRequest) ([]*models.VDSEvent, string, error)
+}
+
+// IPPoolRepository интерфейс для работы с пулами IP адресов
+type IPPoolRepository interface {
+	// Create сохраняет пул вместе с зарезервированными диапазонами. ID и CreatedAt заполняются из БД
+	Create(ctx context.Context, pool *models.IPPool) (*models.IPPool, error)
+	Delete(ctx context.Context, id int32) error
+	GetUsage(ctx context.Context, id int32) (*models.IPPoolUsage, error)
+	ListUsage(ctx context.Context, filter models.IPPoolFilter, page models.PageRequest) ([]*models.IPPoolUsage, string, error)
+}
+
+// TaskRepository интерфейс для работы с фоновыми задачами
+type TaskRepository interface {
+	Create(ctx context.Context, vdsID int32, taskType models.TaskType) (*models.Task, error)
+	GetByID(ctx context.Context, id int32) (*models.Task, error)
+	ListByVDS(ctx context.Context, filter models.TaskFilter, page models.PageRequest) ([]*models.Task, string, error)
+	// UpdateStatus меняет статус задачи, проставляя started_at/completed_at.
+	// Недопустимый переход возвращает ErrInvalidTaskTransition
+	UpdateStatus(ctx context.Context, req *models.UpdateTaskStatusRequest) (*models.Task, error)
+	GetPendingCount(ctx context.Context, vdsID int32) (int32, error)
+	// CountQueued возвращает количество pending и running задач по статусам (глубина очереди)
+	CountQueued(ctx context.Context) (map[models.TaskStatus]int64, error)
+	// ClaimNext атомарно забирает следующую pending задачу и переводит её в running.
+	// Безопасно при нескольких репликах сервиса. Если задач нет - ErrNoPendingTasks
+	ClaimNext(ctx context.Context) (*models.Task, error)
+	// FailStale завершает с ошибкой задачи, которые висят в running дольше olderThan
+	// (например, реплика упала во время обработки)
+	FailStale(ctx context.Context, olderThan time.Duration) (int64, error)
+}
+
+// AuditRepository интерфейс журнала аудита. Записи только добавляются
+type AuditRepository interface {
+	Append(ctx context.Context, entry *models.AuditEntry) error
+	List(ctx context.Context, filter models.AuditFilter, page models.PageRequest) ([]*models.AuditEntry, string, error)
+}
+
+// IdempotencyRepository интерфейс для работы с ключами идемпотентности
+type IdempotencyRepository interface {
+	// Acquire занимает ключ под выполнение вызова. Если ключ уже занят - ErrIdempotentKeyExists
+	Acquire(ctx context.Context, key *models.IdempotencyKey, lockTimeout time.Duration) error
+	Get(ctx context.Context, userID int64, key string) (*models.IdempotencyKey, error)
+	Complete(ctx context.Context, userID int64, key string, response []byte) error
+	Release(ctx context.Context, userID int64, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// OutboxRepository интерфейс для публикации доменных событий outbox
+type OutboxRepository interface {
+	// Claim берёт в работу готовые к публикации события, не больше одного на ordering_key
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, ids []int64) error
+	MarkFailed(ctx context.Context, id int64, reason string, nextAttempt time.Time) error
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+	CountPending(ctx context.Context) (int64, error)
+}
+
+// WebhookRepository интерфейс подписок на доменные события и журнала их доставки
+type WebhookRepository interface {
+	Create(ctx context.Context, req *models.CreateWebhookRequest) (*models.Webhook, error)
+	GetByID(ctx context.Context, id int32) (*models.Webhook, error)
+	Update(ctx context.Context, req *models.UpdateWebhookRequest) (*models.Webhook, error)
+	Delete(ctx context.Context, id int32) error
+	List(ctx context.Context, filter models.WebhookFilter, page models.PageRequest) ([]*models.Webhook, string, error)
+	// Fanout создаёт доставки события подпискам, vdsID ограничивает их владельцем VDS
+	Fanout(ctx context.Context, eventID int64, eventType string, payload []byte, vdsID *int32) (int64, error)
+	// ClaimDeliveries берёт в работу готовые к отправке доставки
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDispatch, error)
+	MarkDelivered(ctx context.Context, id int64, statusCode int32) error
+	// MarkFailed записывает неудачную попытку, nextAttempt nil - доставка dead
+	MarkFailed(ctx context.Context, id int64, statusCode int32, reason string, nextAttempt *time.Time) error
+	ListDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter, page models.PageRequest) ([]*models.WebhookDelivery, string, error)
+	DeleteDeliveries(ctx context.Context, before time.Time) (int64, error)
 }
 
 // PostgresRepository объединяет все PostgreSQL репозитории
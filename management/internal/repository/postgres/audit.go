@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/makhtech/management/internal/domain/models"
+)
+
+// auditColumns список колонок журнала аудита в порядке, ожидаемом scanAuditEntry
+const auditColumns = `id, actor_id, actor_name, actor_role, method, entity_type, entity_id, diff,
+	request_id, COALESCE(host(client_ip), ''), code, created_at`
+
+// AuditRepository - репозиторий журнала аудита. Записи только добавляются:
+// изменение и удаление запрещены триггером в БД
+type AuditRepository struct {
+	db *Database
+}
+
+// NewAuditRepository создает новый репозиторий журнала аудита
+func NewAuditRepository(db *Database) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// scanAuditEntry сканирует строку с колонками auditColumns
+func scanAuditEntry(row interface{ Scan(dest ...any) error }) (*models.AuditEntry, error) {
+	var entry models.AuditEntry
+	err := row.Scan(
+		&entry.ID,
+		&entry.ActorID,
+		&entry.ActorName,
+		&entry.ActorRole,
+		&entry.Method,
+		&entry.EntityType,
+		&entry.EntityID,
+		&entry.Diff,
+		&entry.RequestID,
+		&entry.ClientIP,
+		&entry.Code,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Append добавляет запись в журнал. ID и CreatedAt заполняются из БД
+func (r *AuditRepository) Append(ctx context.Context, entry *models.AuditEntry) error {
+	const op = "repository.postgres.AuditRepository.Append"
+
+	query := `
+		INSERT INTO audit_log (actor_id, actor_name, actor_role, method, entity_type, entity_id,
+		                       diff, request_id, client_ip, code)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')::inet, $10)
+		RETURNING id, created_at
+	`
+
+	diff := entry.Diff
+	if len(diff) == 0 {
+		diff = []byte("{}")
+	}
+
+	err := r.db.Pool.QueryRow(ctx, query,
+		entry.ActorID,
+		entry.ActorName,
+		entry.ActorRole,
+		entry.Method,
+		entry.EntityType,
+		entry.EntityID,
+		string(diff),
+		entry.RequestID,
+		entry.ClientIP,
+		entry.Code,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+var auditPage = pageSpec[*models.AuditEntry]{
+	query:    `SELECT ` + auditColumns + ` FROM audit_log`,
+	idColumn: "id",
+	id:       func(e *models.AuditEntry) int64 { return e.ID },
+	scan:     scanAuditEntry,
+	keys: map[string]sortKey[*models.AuditEntry]{
+		"id":         {column: "id"},
+		"created_at": {column: "created_at", cast: "timestamptz", value: func(e *models.AuditEntry) string { return cursorTime(e.CreatedAt) }},
+	},
+	defaultOrder: "created_at desc",
+}
+
+// List возвращает страницу журнала аудита, по умолчанию начиная с последних записей
+func (r *AuditRepository) List(ctx context.Context, filter models.AuditFilter, page models.PageRequest) ([]*models.AuditEntry, string, error) {
+	const op = "repository.postgres.AuditRepository.List"
+
+	q := &listQuery{}
+	if filter.ActorID != nil {
+		q.where("actor_id = " + q.arg(*filter.ActorID))
+	}
+	if filter.EntityType != "" {
+		q.where("entity_type = " + q.arg(filter.EntityType))
+	}
+	if filter.EntityID != nil {
+		q.where("entity_id = " + q.arg(*filter.EntityID))
+	}
+	if filter.Method != "" {
+		q.where("method = " + q.arg(filter.Method))
+	}
+	q.whereTime("created_at", filter.Created)
+
+	entries, next, err := listPage(ctx, r.db.Pool, auditPage, q, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, next, nil
+}
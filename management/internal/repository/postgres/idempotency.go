@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// IdempotencyRepository - репозиторий ключей идемпотентности
+type IdempotencyRepository struct {
+	db *Database
+}
+
+// NewIdempotencyRepository создает новый репозиторий ключей идемпотентности
+func NewIdempotencyRepository(db *Database) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Acquire занимает ключ под выполнение вызова. Просроченный ключ и ключ, незавершённый
+// дольше lockTimeout, занимаются заново. Если ключ занят - ErrIdempotentKeyExists
+func (r *IdempotencyRepository) Acquire(ctx context.Context, key *models.IdempotencyKey, lockTimeout time.Duration) error {
+	const op = "repository.postgres.IdempotencyRepository.Acquire"
+
+	query := `
+		INSERT INTO idempotency_keys (user_id, key, method, request_hash, status, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, 'in_progress', now(), $5)
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET method = EXCLUDED.method,
+		    request_hash = EXCLUDED.request_hash,
+		    status = 'in_progress',
+		    response = NULL,
+		    created_at = now(),
+		    expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < now()
+		   OR (idempotency_keys.status = 'in_progress' AND idempotency_keys.created_at < now() - $6::interval)
+		RETURNING created_at
+	`
+
+	err := r.db.Pool.QueryRow(ctx, query,
+		key.UserID,
+		key.Key,
+		key.Method,
+		key.RequestHash,
+		key.ExpiresAt,
+		lockTimeout,
+	).Scan(&key.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrIdempotentKeyExists
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	key.Status = models.IdempotencyInProgress
+	key.Response = nil
+	return nil
+}
+
+// Get возвращает действующий ключ. Нет ключа или он просрочен - IdempotentKeyNotFound
+func (r *IdempotencyRepository) Get(ctx context.Context, userID int64, key string) (*models.IdempotencyKey, error) {
+	const op = "repository.postgres.IdempotencyRepository.Get"
+
+	query := `
+		SELECT user_id, key, method, request_hash, status, response, created_at, expires_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2 AND expires_at >= now()
+	`
+
+	var k models.IdempotencyKey
+	err := r.db.Pool.QueryRow(ctx, query, userID, key).Scan(
+		&k.UserID,
+		&k.Key,
+		&k.Method,
+		&k.RequestHash,
+		&k.Status,
+		&k.Response,
+		&k.CreatedAt,
+		&k.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.IdempotentKeyNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &k, nil
+}
+
+// Complete сохраняет ответ успешного вызова
+func (r *IdempotencyRepository) Complete(ctx context.Context, userID int64, key string, response []byte) error {
+	const op = "repository.postgres.IdempotencyRepository.Complete"
+
+	query := `
+		UPDATE idempotency_keys
+		SET status = 'completed', response = $3
+		WHERE user_id = $1 AND key = $2 AND status = 'in_progress'
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, userID, key, response)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return repository.IdempotentKeyNotFound
+	}
+
+	return nil
+}
+
+// Release освобождает незавершённый ключ после неудачного вызова, чтобы его можно было повторить
+func (r *IdempotencyRepository) Release(ctx context.Context, userID int64, key string) error {
+	const op = "repository.postgres.IdempotencyRepository.Release"
+
+	query := `DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2 AND status = 'in_progress'`
+
+	if _, err := r.db.Pool.Exec(ctx, query, userID, key); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteExpired удаляет просроченные ключи и возвращает их количество
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	const op = "repository.postgres.IdempotencyRepository.DeleteExpired"
+
+	result, err := r.db.Pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at < now()`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,386 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// ipPoolColumns список колонок пула в порядке, ожидаемом scanIPPool
+const ipPoolColumns = `id, node_id, family, subnet, gateway, is_active, created_at`
+
+// errNoIPPool у ноды нет активных пулов нужного семейства
+var errNoIPPool = errors.New("no active ip pool for node")
+
+// IPPoolRepository - репозиторий для работы с пулами IP адресов
+type IPPoolRepository struct {
+	db *Database
+}
+
+// NewIPPoolRepository создает новый репозиторий пулов IP адресов
+func NewIPPoolRepository(db *Database) *IPPoolRepository {
+	return &IPPoolRepository{db: db}
+}
+
+// scanIPPool сканирует строку с колонками ipPoolColumns
+func scanIPPool(row interface{ Scan(dest ...any) error }, extra ...any) (*models.IPPool, error) {
+	var pool models.IPPool
+	dest := []any{
+		&pool.ID,
+		&pool.NodeID,
+		&pool.Family,
+		&pool.Subnet,
+		&pool.Gateway,
+		&pool.IsActive,
+		&pool.CreatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
+
+// Create сохраняет пул и его зарезервированные диапазоны в одной транзакции
+func (r *IPPoolRepository) Create(ctx context.Context, pool *models.IPPool) (*models.IPPool, error) {
+	const op = "repository.postgres.IPPoolRepository.Create"
+
+	var created *models.IPPool
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		query := `
+			INSERT INTO ip_pools (node_id, family, subnet, gateway)
+			VALUES ($1, $2, $3, $4)
+			RETURNING ` + ipPoolColumns
+
+		var err error
+		created, err = scanIPPool(tx.QueryRow(ctx, query, pool.NodeID, pool.Family, pool.Subnet, pool.Gateway))
+		if err != nil {
+			return err
+		}
+
+		for _, rng := range pool.ReservedRanges {
+			_, err := tx.Exec(ctx,
+				`INSERT INTO ip_pool_reserved_ranges (pool_id, start_ip, end_ip, description) VALUES ($1, $2, $3, $4)`,
+				created.ID, rng.Start, rng.End, rng.Description,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		created.ReservedRanges = pool.ReservedRanges
+		return nil
+	})
+
+	if err != nil {
+		if isPgError(err, pgErrForeignKeyViolation) {
+			return nil, repository.ErrNodeNotFound
+		}
+		if isPgError(err, pgErrExclusionViolation) {
+			return nil, repository.ErrIPPoolOverlaps
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// Delete удаляет пул. Пул с выданными адресами удалить нельзя - ErrIPPoolInUse
+func (r *IPPoolRepository) Delete(ctx context.Context, id int32) error {
+	const op = "repository.postgres.IPPoolRepository.Delete"
+
+	result, err := r.db.Pool.Exec(ctx, `DELETE FROM ip_pools WHERE id = $1`, id)
+	if err != nil {
+		if isPgError(err, pgErrForeignKeyViolation) {
+			return repository.ErrIPPoolInUse
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrIPPoolNotFound
+	}
+
+	return nil
+}
+
+// ipPoolUsageQuery пулы с количеством выданных адресов, колонки в порядке scanIPPoolRow
+const ipPoolUsageQuery = `
+	SELECT p.id, p.node_id, p.family, p.subnet, p.gateway, p.is_active, p.created_at,
+	       (SELECT COUNT(*) FROM ip_allocations a WHERE a.pool_id = p.id)
+	FROM ip_pools p`
+
+// ipPoolRow пул и количество выданных из него адресов
+type ipPoolRow struct {
+	pool      *models.IPPool
+	allocated int64
+}
+
+func scanIPPoolRow(row interface{ Scan(dest ...any) error }) (*ipPoolRow, error) {
+	var r ipPoolRow
+	pool, err := scanIPPool(row, &r.allocated)
+	if err != nil {
+		return nil, err
+	}
+	r.pool = pool
+	return &r, nil
+}
+
+var ipPoolPage = pageSpec[*ipPoolRow]{
+	query:    ipPoolUsageQuery,
+	idColumn: "p.id",
+	id:       func(r *ipPoolRow) int64 { return int64(r.pool.ID) },
+	scan:     scanIPPoolRow,
+	keys: map[string]sortKey[*ipPoolRow]{
+		"id":         {column: "p.id"},
+		"node_id":    {column: "p.node_id", cast: "integer", value: func(r *ipPoolRow) string { return cursorInt(r.pool.NodeID) }},
+		"created_at": {column: "p.created_at", cast: "timestamptz", value: func(r *ipPoolRow) string { return cursorTime(r.pool.CreatedAt) }},
+	},
+	defaultOrder: "node_id",
+}
+
+// GetUsage возвращает статистику использования пула
+func (r *IPPoolRepository) GetUsage(ctx context.Context, id int32) (*models.IPPoolUsage, error) {
+	const op = "repository.postgres.IPPoolRepository.GetUsage"
+
+	row, err := scanIPPoolRow(r.db.Pool.QueryRow(ctx, ipPoolUsageQuery+` WHERE p.id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrIPPoolNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	usage, err := r.usage(ctx, []*ipPoolRow{row})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return usage[0], nil
+}
+
+// ListUsage возвращает страницу статистики использования пулов ноды или всех нод
+func (r *IPPoolRepository) ListUsage(ctx context.Context, filter models.IPPoolFilter, page models.PageRequest) ([]*models.IPPoolUsage, string, error) {
+	const op = "repository.postgres.IPPoolRepository.ListUsage"
+
+	q := &listQuery{}
+	if filter.NodeID != nil {
+		q.where("p.node_id = " + q.arg(*filter.NodeID))
+	}
+	if filter.Family != 0 {
+		q.where("p.family = " + q.arg(filter.Family))
+	}
+
+	rows, next, err := listPage(ctx, r.db.Pool, ipPoolPage, q, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	usage, err := r.usage(ctx, rows)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return usage, next, nil
+}
+
+// usage дозагружает зарезервированные диапазоны и считает использование пулов
+func (r *IPPoolRepository) usage(ctx context.Context, rows []*ipPoolRow) ([]*models.IPPoolUsage, error) {
+	pools := make([]*models.IPPool, 0, len(rows))
+	for _, row := range rows {
+		pools = append(pools, row.pool)
+	}
+
+	if err := loadReservedRanges(ctx, r.db.Pool, pools); err != nil {
+		return nil, err
+	}
+
+	usage := make([]*models.IPPoolUsage, 0, len(rows))
+	for _, row := range rows {
+		usage = append(usage, row.pool.Usage(uint64(row.allocated)))
+	}
+	return usage, nil
+}
+
+// loadReservedRanges заполняет ReservedRanges у переданных пулов
+func loadReservedRanges(ctx context.Context, q querier, pools []*models.IPPool) error {
+	if len(pools) == 0 {
+		return nil
+	}
+
+	byID := make(map[int32]*models.IPPool, len(pools))
+	ids := make([]int32, 0, len(pools))
+	for _, pool := range pools {
+		byID[pool.ID] = pool
+		ids = append(ids, pool.ID)
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT pool_id, start_ip, end_ip, description
+		FROM ip_pool_reserved_ranges
+		WHERE pool_id = ANY($1)
+		ORDER BY pool_id, start_ip`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var poolID int32
+		var rng models.IPRange
+		if err := rows.Scan(&poolID, &rng.Start, &rng.End, &rng.Description); err != nil {
+			return err
+		}
+		byID[poolID].ReservedRanges = append(byID[poolID].ReservedRanges, rng)
+	}
+
+	return rows.Err()
+}
+
+// lockNodePools блокирует активные пулы ноды заданного семейства до конца транзакции.
+// Блокировка пула сериализует выдачу адресов из него между репликами
+func lockNodePools(ctx context.Context, q querier, nodeID, family int32) ([]*models.IPPool, error) {
+	rows, err := q.Query(ctx, `
+		SELECT `+ipPoolColumns+`
+		FROM ip_pools
+		WHERE node_id = $1 AND family = $2 AND is_active = true
+		ORDER BY id
+		FOR UPDATE`, nodeID, family)
+	if err != nil {
+		return nil, err
+	}
+
+	var pools []*models.IPPool
+	for rows.Next() {
+		pool, err := scanIPPool(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		pools = append(pools, pool)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadReservedRanges(ctx, q, pools); err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+// allocatedAddrs возвращает адреса пула, уже выданные VDS
+func allocatedAddrs(ctx context.Context, q querier, poolID int32) (map[netip.Addr]bool, error) {
+	rows, err := q.Query(ctx, `SELECT address FROM ip_allocations WHERE pool_id = $1`, poolID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allocated := make(map[netip.Addr]bool)
+	for rows.Next() {
+		var addr netip.Addr
+		if err := rows.Scan(&addr); err != nil {
+			return nil, err
+		}
+		allocated[addr] = true
+	}
+
+	return allocated, rows.Err()
+}
+
+// allocateNextIP выдаёт VDS следующий свободный адрес из пулов её ноды.
+// Если активных пулов семейства нет - errNoIPPool, если все заняты - ErrNoFreeIP
+func allocateNextIP(ctx context.Context, q querier, vdsID, nodeID, family int32) (netip.Addr, error) {
+	pools, err := lockNodePools(ctx, q, nodeID, family)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if len(pools) == 0 {
+		return netip.Addr{}, errNoIPPool
+	}
+
+	for _, pool := range pools {
+		allocated, err := allocatedAddrs(ctx, q, pool.ID)
+		if err != nil {
+			return netip.Addr{}, err
+		}
+
+		addr, ok := pool.NextFree(allocated)
+		if !ok {
+			continue
+		}
+
+		_, err = q.Exec(ctx,
+			`INSERT INTO ip_allocations (pool_id, vds_id, address) VALUES ($1, $2, $3)`,
+			pool.ID, vdsID, addr,
+		)
+		if err != nil {
+			return netip.Addr{}, err
+		}
+		return addr, nil
+	}
+
+	return netip.Addr{}, repository.ErrNoFreeIP
+}
+
+// assignIP закрепляет за VDS конкретный адрес из пулов её ноды, освобождая прежний адрес
+// того же семейства. Адрес вне пулов, зарезервированный или занятый другой VDS - ErrIPUnavailable
+func assignIP(ctx context.Context, q querier, vdsID, nodeID int32, addr netip.Addr) error {
+	family := models.IPFamilyV6
+	if addr.Is4() {
+		family = models.IPFamilyV4
+	}
+
+	pools, err := lockNodePools(ctx, q, nodeID, family)
+	if err != nil {
+		return err
+	}
+
+	var pool *models.IPPool
+	for _, p := range pools {
+		if p.IsAssignable(addr) {
+			pool = p
+			break
+		}
+	}
+	if pool == nil {
+		return repository.ErrIPUnavailable
+	}
+
+	var owner int32
+	err = q.QueryRow(ctx, `SELECT vds_id FROM ip_allocations WHERE address = $1`, addr).Scan(&owner)
+	switch {
+	case err == nil && owner == vdsID:
+		return nil
+	case err == nil:
+		return repository.ErrIPUnavailable
+	case !errors.Is(err, pgx.ErrNoRows):
+		return err
+	}
+
+	_, err = q.Exec(ctx, `DELETE FROM ip_allocations WHERE vds_id = $1 AND family(address) = $2`, vdsID, family)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx,
+		`INSERT INTO ip_allocations (pool_id, vds_id, address) VALUES ($1, $2, $3)`,
+		pool.ID, vdsID, addr,
+	)
+	if isPgError(err, pgErrUniqueViolation) {
+		return repository.ErrIPUnavailable
+	}
+	return err
+}
+
+// releaseIPs возвращает в пулы все адреса VDS
+func releaseIPs(ctx context.Context, q querier, vdsID int32) error {
+	_, err := q.Exec(ctx, `DELETE FROM ip_allocations WHERE vds_id = $1`, vdsID)
+	return err
+}
@@ -0,0 +1,230 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// listQuery собирает условия WHERE для List методов. Значения фильтров передаются
+// только аргументами запроса, в текст запроса попадают лишь колонки из кода репозитория
+type listQuery struct {
+	conds []string
+	args  []any
+}
+
+// arg добавляет аргумент запроса и возвращает его плейсхолдер ($N)
+func (q *listQuery) arg(v any) string {
+	q.args = append(q.args, v)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+// where добавляет условие
+func (q *listQuery) where(cond string) {
+	q.conds = append(q.conds, cond)
+}
+
+// whereTime добавляет условия диапазона r по колонке column
+func (q *listQuery) whereTime(column string, r models.TimeRange) {
+	if r.From != nil {
+		q.where(column + " >= " + q.arg(*r.From))
+	}
+	if r.To != nil {
+		q.where(column + " < " + q.arg(*r.To))
+	}
+}
+
+func (q *listQuery) whereClause() string {
+	if len(q.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.conds, " AND ")
+}
+
+// fingerprint отпечаток условий и их значений: курсор действителен только с теми же фильтрами
+func (q *listQuery) fingerprint() uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strings.Join(q.conds, " AND ")))
+	for _, a := range q.args {
+		_, _ = fmt.Fprintf(h, "\x00%v", a)
+	}
+	return h.Sum64()
+}
+
+// sortKey поле, по которому разрешена сортировка списка
+type sortKey[T any] struct {
+	// column колонка в запросе
+	column string
+	// cast тип PostgreSQL, к которому приводится значение курсора
+	cast string
+	// value значение поля элемента в текстовом виде для курсора
+	value func(T) string
+}
+
+// pageSpec описание постраничного списка элементов T
+type pageSpec[T any] struct {
+	// query SELECT ... FROM ... без WHERE и ORDER BY
+	query string
+	// idColumn уникальная колонка, которая добивает сортировку до строгого порядка
+	idColumn string
+	id       func(T) int64
+	scan     func(row interface{ Scan(dest ...any) error }) (T, error)
+	// keys допустимые поля order_by
+	keys map[string]sortKey[T]
+	// defaultOrder порядок, если order_by не задан
+	defaultOrder string
+}
+
+// pageCursor содержимое page_token
+type pageCursor struct {
+	Order  string `json:"o"`
+	Filter uint64 `json:"f"`
+	Value  string `json:"v,omitempty"`
+	ID     int64  `json:"i"`
+}
+
+func encodeCursor(c pageCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(token string) (pageCursor, error) {
+	var c pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
+// sortOrder разобранный order_by
+type sortOrder struct {
+	field string
+	desc  bool
+}
+
+func (o sortOrder) String() string {
+	if o.desc {
+		return o.field + " desc"
+	}
+	return o.field + " asc"
+}
+
+// parseOrder разбирает order_by вида "field", "field asc" или "field desc"
+func parseOrder(orderBy string) (sortOrder, error) {
+	parts := strings.Fields(strings.ToLower(orderBy))
+	switch {
+	case len(parts) == 1:
+		return sortOrder{field: parts[0]}, nil
+	case len(parts) == 2 && (parts[1] == "asc" || parts[1] == "desc"):
+		return sortOrder{field: parts[0], desc: parts[1] == "desc"}, nil
+	}
+	return sortOrder{}, fmt.Errorf("%w: expected \"field [asc|desc]\"", repository.ErrInvalidOrderBy)
+}
+
+// listPage выполняет постраничный запрос spec с условиями q и возвращает страницу
+// и токен следующей страницы (пусто - страница последняя).
+// Пагинация keyset: страница начинается строго после (поле сортировки, id) последнего
+// элемента предыдущей, поэтому вставки и удаления между запросами не сдвигают страницы
+func listPage[T any](ctx context.Context, db querier, spec pageSpec[T], q *listQuery, page models.PageRequest) ([]T, string, error) {
+	orderBy := page.OrderBy
+	if strings.TrimSpace(orderBy) == "" {
+		orderBy = spec.defaultOrder
+	}
+	order, err := parseOrder(orderBy)
+	if err != nil {
+		return nil, "", err
+	}
+	key, ok := spec.keys[order.field]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: unknown field %q, allowed: %s",
+			repository.ErrInvalidOrderBy, order.field, strings.Join(slices.Sorted(maps.Keys(spec.keys)), ", "))
+	}
+	byID := key.column == spec.idColumn
+
+	size := int(page.Size)
+	if size <= 0 {
+		size = models.DefaultPageSize
+	}
+
+	cmp, dir := ">", "ASC"
+	if order.desc {
+		cmp, dir = "<", "DESC"
+	}
+
+	fingerprint := q.fingerprint()
+	if page.Token != "" {
+		cursor, err := decodeCursor(page.Token)
+		if err != nil || cursor.Order != order.String() || cursor.Filter != fingerprint {
+			return nil, "", repository.ErrInvalidPageToken
+		}
+		if byID {
+			q.where(fmt.Sprintf("%s %s %s", spec.idColumn, cmp, q.arg(cursor.ID)))
+		} else {
+			q.where(fmt.Sprintf("(%s, %s) %s (%s::text::%s, %s)",
+				key.column, spec.idColumn, cmp, q.arg(cursor.Value), key.cast, q.arg(cursor.ID)))
+		}
+	}
+
+	orderClause := spec.idColumn + " " + dir
+	if !byID {
+		orderClause = key.column + " " + dir + ", " + orderClause
+	}
+
+	query := spec.query + q.whereClause() + " ORDER BY " + orderClause + " LIMIT " + q.arg(size+1)
+
+	rows, err := db.Query(ctx, query, q.args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	items := make([]T, 0, size)
+	for rows.Next() {
+		item, err := spec.scan(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	if len(items) <= size {
+		return items, "", nil
+	}
+
+	items = items[:size]
+	last := items[size-1]
+	cursor := pageCursor{Order: order.String(), Filter: fingerprint, ID: spec.id(last)}
+	if !byID {
+		cursor.Value = key.value(last)
+	}
+
+	return items, encodeCursor(cursor), nil
+}
+
+// cursorTime значение времени для курсора без потери точности
+func cursorTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func cursorFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func cursorInt(v int32) string {
+	return strconv.FormatInt(int64(v), 10)
+}
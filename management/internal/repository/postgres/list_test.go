@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+var errQueried = errors.New("query reached the database")
+
+// fakeQuerier не ходит в базу: токен принят, если дело дошло до Query
+type fakeQuerier struct {
+	querier
+	queried bool
+}
+
+func (q *fakeQuerier) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	q.queried = true
+	return nil, errQueried
+}
+
+type testItem struct {
+	id   int64
+	name string
+}
+
+var testSpec = pageSpec[testItem]{
+	query:    "SELECT id, name FROM items",
+	idColumn: "id",
+	id:       func(i testItem) int64 { return i.id },
+	keys: map[string]sortKey[testItem]{
+		"id":   {column: "id"},
+		"name": {column: "name", cast: "text", value: func(i testItem) string { return i.name }},
+	},
+	defaultOrder: "id asc",
+}
+
+func testQuery(nodeID int32) *listQuery {
+	q := &listQuery{}
+	q.where("node_id = " + q.arg(nodeID))
+	return q
+}
+
+func TestListPageToken(t *testing.T) {
+	valid := pageCursor{Order: "name asc", Filter: testQuery(1).fingerprint(), Value: "b", ID: 10}
+
+	with := func(change func(c *pageCursor)) string {
+		c := valid
+		change(&c)
+		return encodeCursor(c)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		orderBy string
+		nodeID  int32
+		wantErr error
+	}{
+		{name: "valid", token: encodeCursor(valid), orderBy: "name", nodeID: 1, wantErr: errQueried},
+		{name: "no token", orderBy: "name", nodeID: 2, wantErr: errQueried},
+		{name: "other filter values", token: encodeCursor(valid), orderBy: "name", nodeID: 2, wantErr: repository.ErrInvalidPageToken},
+		{name: "other order", token: encodeCursor(valid), orderBy: "name desc", nodeID: 1, wantErr: repository.ErrInvalidPageToken},
+		{name: "forged filter", token: with(func(c *pageCursor) { c.Filter++ }), orderBy: "name", nodeID: 1, wantErr: repository.ErrInvalidPageToken},
+		{name: "forged order", token: with(func(c *pageCursor) { c.Order = "id asc" }), orderBy: "name", nodeID: 1, wantErr: repository.ErrInvalidPageToken},
+		{name: "not base64", token: "!!!", orderBy: "name", nodeID: 1, wantErr: repository.ErrInvalidPageToken},
+		{name: "not json", token: base64.RawURLEncoding.EncodeToString([]byte("garbage")), orderBy: "name", nodeID: 1, wantErr: repository.ErrInvalidPageToken},
+		{name: "truncated", token: encodeCursor(valid)[:10], orderBy: "name", nodeID: 1, wantErr: repository.ErrInvalidPageToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeQuerier{}
+			page := models.PageRequest{Token: tt.token, OrderBy: tt.orderBy}
+
+			_, _, err := listPage(context.Background(), db, testSpec, testQuery(tt.nodeID), page)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("listPage() error = %v, want %v", err, tt.wantErr)
+			}
+			if db.queried != (tt.wantErr == errQueried) {
+				t.Fatalf("queried = %v", db.queried)
+			}
+		})
+	}
+}
@@ -0,0 +1,377 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// NodeRepository - репозиторий для работы с Proxmox нодами
+type NodeRepository struct {
+	db *Database
+}
+
+// NewNodeRepository создает новый репозиторий нод
+func NewNodeRepository(db *Database) *NodeRepository {
+	return &NodeRepository{db: db}
+}
+
+// Create создает новую ноду
+func (r *NodeRepository) Create(ctx context.Context, req *models.CreateNodeRequest) (*models.Node, error) {
+	const op = "repository.postgres.NodeRepository.Create"
+
+	query := `
+		INSERT INTO nodes (name, api_url, max_cpu, max_ram, max_disk, region, labels, is_active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, true, $8)
+		RETURNING id, name, api_url, max_cpu, max_ram, max_disk, region, labels, is_active, created_at
+	`
+
+	var node models.Node
+	now := time.Now()
+
+	err := r.db.Pool.QueryRow(ctx, query,
+		req.Name,
+		req.APIURL,
+		req.MaxCPU,
+		req.MaxRAM,
+		req.MaxDisk,
+		req.Region,
+		labelsOrEmpty(req.Labels),
+		now,
+	).Scan(
+		&node.ID,
+		&node.Name,
+		&node.APIURL,
+		&node.MaxCPU,
+		&node.MaxRAM,
+		&node.MaxDisk,
+		&node.Region,
+		&node.Labels,
+		&node.IsActive,
+		&node.CreatedAt,
+	)
+
+	if err != nil {
+		if isPgError(err, pgErrUniqueViolation) {
+			return nil, repository.ErrNodeExists
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &node, nil
+}
+
+// GetByID получает ноду по ID
+func (r *NodeRepository) GetByID(ctx context.Context, id int32) (*models.Node, error) {
+	const op = "repository.postgres.NodeRepository.GetByID"
+
+	query := `
+		SELECT id, name, api_url, max_cpu, max_ram, max_disk, region, labels, is_active, created_at
+		FROM nodes
+		WHERE id = $1
+	`
+
+	var node models.Node
+	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
+		&node.ID,
+		&node.Name,
+		&node.APIURL,
+		&node.MaxCPU,
+		&node.MaxRAM,
+		&node.MaxDisk,
+		&node.Region,
+		&node.Labels,
+		&node.IsActive,
+		&node.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNodeNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &node, nil
+}
+
+// Update обновляет существующую ноду
+func (r *NodeRepository) Update(ctx context.Context, req *models.UpdateNodeRequest) (*models.Node, error) {
+	const op = "repository.postgres.NodeRepository.Update"
+
+	// Строим динамический запрос
+	var setClauses []string
+	var args []interface{}
+	argIndex := 1
+
+	if req.APIURL != nil {
+		setClauses = append(setClauses, fmt.Sprintf("api_url = $%d", argIndex))
+		args = append(args, *req.APIURL)
+		argIndex++
+	}
+	if req.MaxCPU != nil {
+		setClauses = append(setClauses, fmt.Sprintf("max_cpu = $%d", argIndex))
+		args = append(args, *req.MaxCPU)
+		argIndex++
+	}
+	if req.MaxRAM != nil {
+		setClauses = append(setClauses, fmt.Sprintf("max_ram = $%d", argIndex))
+		args = append(args, *req.MaxRAM)
+		argIndex++
+	}
+	if req.MaxDisk != nil {
+		setClauses = append(setClauses, fmt.Sprintf("max_disk = $%d", argIndex))
+		args = append(args, *req.MaxDisk)
+		argIndex++
+	}
+	if req.Region != nil {
+		setClauses = append(setClauses, fmt.Sprintf("region = $%d", argIndex))
+		args = append(args, *req.Region)
+		argIndex++
+	}
+	if req.Labels != nil {
+		setClauses = append(setClauses, fmt.Sprintf("labels = $%d", argIndex))
+		args = append(args, labelsOrEmpty(req.Labels))
+		argIndex++
+	}
+	if req.IsActive != nil {
+		setClauses = append(setClauses, fmt.Sprintf("is_active = $%d", argIndex))
+		args = append(args, *req.IsActive)
+		argIndex++
+	}
+
+	if len(setClauses) == 0 {
+		return r.GetByID(ctx, req.ID)
+	}
+
+	args = append(args, req.ID)
+
+	query := fmt.Sprintf(`
+		UPDATE nodes
+		SET %s
+		WHERE id = $%d
+		RETURNING id, name, api_url, max_cpu, max_ram, max_disk, region, labels, is_active, created_at
+	`, strings.Join(setClauses, ", "), argIndex)
+
+	var node models.Node
+	err := r.db.Pool.QueryRow(ctx, query, args...).Scan(
+		&node.ID,
+		&node.Name,
+		&node.APIURL,
+		&node.MaxCPU,
+		&node.MaxRAM,
+		&node.MaxDisk,
+		&node.Region,
+		&node.Labels,
+		&node.IsActive,
+		&node.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNodeNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &node, nil
+}
+
+// Delete удаляет ноду по ID.
+// Возвращает repository.ErrNodeInUse, если на ноде ещё есть VDS
+func (r *NodeRepository) Delete(ctx context.Context, id int32) error {
+	const op = "repository.postgres.NodeRepository.Delete"
+
+	// Проверка и удаление в одном запросе, чтобы не было гонки с созданием VDS
+	query := `
+		DELETE FROM nodes
+		WHERE id = $1
+		  AND NOT EXISTS (SELECT 1 FROM vds WHERE node_id = $1)
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, id)
+	if err != nil {
+		if isPgError(err, pgErrForeignKeyViolation) {
+			return repository.ErrNodeInUse
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		// Различаем "не найдена" и "используется"
+		var exists bool
+		err = r.db.Pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM nodes WHERE id = $1)`, id).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if exists {
+			return repository.ErrNodeInUse
+		}
+		return repository.ErrNodeNotFound
+	}
+
+	return nil
+}
+
+// nodeColumns список колонок ноды в порядке, ожидаемом scanNode
+const nodeColumns = `id, name, api_url, max_cpu, max_ram, max_disk, region, labels, is_active, created_at`
+
+// scanNode сканирует строку с колонками nodeColumns
+func scanNode(row interface{ Scan(dest ...any) error }) (*models.Node, error) {
+	var node models.Node
+	err := row.Scan(
+		&node.ID,
+		&node.Name,
+		&node.APIURL,
+		&node.MaxCPU,
+		&node.MaxRAM,
+		&node.MaxDisk,
+		&node.Region,
+		&node.Labels,
+		&node.IsActive,
+		&node.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+var nodePage = pageSpec[*models.Node]{
+	query:    `SELECT ` + nodeColumns + ` FROM nodes`,
+	idColumn: "id",
+	id:       func(n *models.Node) int64 { return int64(n.ID) },
+	scan:     scanNode,
+	keys: map[string]sortKey[*models.Node]{
+		"id":         {column: "id"},
+		"name":       {column: "name", cast: "text", value: func(n *models.Node) string { return n.Name }},
+		"region":     {column: "region", cast: "text", value: func(n *models.Node) string { return n.Region }},
+		"created_at": {column: "created_at", cast: "timestamptz", value: func(n *models.Node) string { return cursorTime(n.CreatedAt) }},
+	},
+	defaultOrder: "id",
+}
+
+// List возвращает страницу списка нод
+func (r *NodeRepository) List(ctx context.Context, filter models.NodeFilter, page models.PageRequest) ([]*models.Node, string, error) {
+	const op = "repository.postgres.NodeRepository.List"
+
+	q := &listQuery{}
+	if filter.ActiveOnly {
+		q.where("is_active = true")
+	}
+	if filter.Region != "" {
+		q.where("region = " + q.arg(filter.Region))
+	}
+	q.whereTime("created_at", filter.Created)
+
+	nodes, next, err := listPage(ctx, r.db.Pool, nodePage, q, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nodes, next, nil
+}
+
+// GetUtilization возвращает статистику использования ресурсов ноды из view node_utilization.
+// View содержит только активные ноды, поэтому для неактивной ноды вернётся repository.ErrNodeNotFound
+func (r *NodeRepository) GetUtilization(ctx context.Context, id int32) (*models.NodeUtilization, error) {
+	const op = "repository.postgres.NodeRepository.GetUtilization"
+
+	query := `
+		SELECT id, name, max_cpu, max_ram, max_disk, vds_count,
+		       used_cpu, used_ram, used_disk,
+		       cpu_usage_pct, ram_usage_pct, disk_usage_pct
+		FROM node_utilization
+		WHERE id = $1
+	`
+
+	var u models.NodeUtilization
+	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
+		&u.NodeID,
+		&u.NodeName,
+		&u.MaxCPU,
+		&u.MaxRAM,
+		&u.MaxDisk,
+		&u.VDSCount,
+		&u.UsedCPU,
+		&u.UsedRAM,
+		&u.UsedDisk,
+		&u.CPUUsagePct,
+		&u.RAMUsagePct,
+		&u.DiskUsagePct,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNodeNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &u, nil
+}
+
+// ListUtilization возвращает статистику активных нод из view node_utilization.
+// Пустой region и labels не ограничивают выборку. labels должны содержаться в метках ноды целиком
+func (r *NodeRepository) ListUtilization(ctx context.Context, region string, labels map[string]string) ([]*models.NodeUtilization, error) {
+	const op = "repository.postgres.NodeRepository.ListUtilization"
+
+	query := `
+		SELECT u.id, u.name, u.max_cpu, u.max_ram, u.max_disk, u.vds_count,
+		       u.used_cpu, u.used_ram, u.used_disk,
+		       u.cpu_usage_pct, u.ram_usage_pct, u.disk_usage_pct
+		FROM node_utilization u
+		JOIN nodes n ON n.id = u.id
+		WHERE ($1 = '' OR n.region = $1)
+		  AND n.labels @> $2
+		ORDER BY u.id
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, region, labelsOrEmpty(labels))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var list []*models.NodeUtilization
+	for rows.Next() {
+		var u models.NodeUtilization
+		if err := rows.Scan(
+			&u.NodeID,
+			&u.NodeName,
+			&u.MaxCPU,
+			&u.MaxRAM,
+			&u.MaxDisk,
+			&u.VDSCount,
+			&u.UsedCPU,
+			&u.UsedRAM,
+			&u.UsedDisk,
+			&u.CPUUsagePct,
+			&u.RAMUsagePct,
+			&u.DiskUsagePct,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		list = append(list, &u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return list, nil
+}
+
+// labelsOrEmpty заменяет nil на пустую карту, чтобы в jsonb попадал {} вместо null
+func labelsOrEmpty(labels map[string]string) map[string]string {
+	if labels == nil {
+		return map[string]string{}
+	}
+	return labels
+}
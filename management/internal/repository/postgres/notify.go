@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Каналы LISTEN/NOTIFY, в которые триггеры пишут ID изменённой строки
+const (
+	TaskStatusChannel = "task_status"
+	VDSStatusChannel  = "vds_status"
+)
+
+// Listener выделенное соединение, подписанное на каналы LISTEN/NOTIFY.
+// Соединение забирается из пула и в него не возвращается
+type Listener struct {
+	conn *pgx.Conn
+}
+
+// Listen подписывается на каналы channels на отдельном соединении
+func (d *Database) Listen(ctx context.Context, channels ...string) (*Listener, error) {
+	const op = "repository.postgres.Database.Listen"
+
+	pc, err := d.Pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	conn := pc.Hijack()
+
+	for _, channel := range channels {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			_ = conn.Close(context.Background())
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return &Listener{conn: conn}, nil
+}
+
+// Wait ждёт следующее уведомление. Ошибка означает, что соединение потеряно
+// или ctx отменён: уведомления, пришедшие до нового Listen, теряются
+func (l *Listener) Wait(ctx context.Context) (channel, payload string, err error) {
+	n, err := l.conn.WaitForNotification(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("repository.postgres.Listener.Wait: %w", err)
+	}
+	return n.Channel, n.Payload, nil
+}
+
+// Close закрывает соединение
+func (l *Listener) Close() {
+	_ = l.conn.Close(context.Background())
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/makhtech/management/internal/domain/models"
+)
+
+// OutboxRepository - репозиторий доменных событий outbox
+type OutboxRepository struct {
+	db *Database
+}
+
+// NewOutboxRepository создает новый репозиторий outbox
+func NewOutboxRepository(db *Database) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Claim берёт в работу на время lease до limit событий, готовых к публикации.
+// От каждого ordering_key берётся только самое раннее неопубликованное событие, поэтому
+// следующее событие ключа не уйдёт раньше предыдущего ни с этой, ни с другой реплики
+func (r *OutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*models.OutboxEvent, error) {
+	const op = "repository.postgres.OutboxRepository.Claim"
+
+	query := `
+		UPDATE outbox
+		SET locked_until = now() + $2::interval
+		WHERE id IN (
+			SELECT o.id
+			FROM outbox o
+			WHERE o.published_at IS NULL
+			  AND o.next_attempt_at <= now()
+			  AND (o.locked_until IS NULL OR o.locked_until < now())
+			  AND NOT EXISTS (
+			      SELECT 1 FROM outbox p
+			      WHERE p.ordering_key = o.ordering_key
+			        AND p.published_at IS NULL
+			        AND p.id < o.id
+			  )
+			ORDER BY o.id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_type, aggregate_type, aggregate_id, ordering_key, payload, created_at, attempts
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var events []*models.OutboxEvent
+	for rows.Next() {
+		var e models.OutboxEvent
+		err := rows.Scan(
+			&e.ID,
+			&e.Type,
+			&e.AggregateType,
+			&e.AggregateID,
+			&e.OrderingKey,
+			&e.Payload,
+			&e.CreatedAt,
+			&e.Attempts,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		events = append(events, &e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// MarkPublished отмечает события опубликованными
+func (r *OutboxRepository) MarkPublished(ctx context.Context, ids []int64) error {
+	const op = "repository.postgres.OutboxRepository.MarkPublished"
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := r.db.Pool.Exec(ctx,
+		`UPDATE outbox SET published_at = now(), locked_until = NULL, last_error = NULL WHERE id = ANY($1)`,
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkFailed записывает неудачную попытку публикации и откладывает следующую до nextAttempt
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, reason string, nextAttempt time.Time) error {
+	const op = "repository.postgres.OutboxRepository.MarkFailed"
+
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1,
+		    last_error = $2,
+		    next_attempt_at = $3,
+		    locked_until = NULL
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, id, reason, nextAttempt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeletePublished удаляет события, опубликованные раньше before, и возвращает их количество
+func (r *OutboxRepository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	const op = "repository.postgres.OutboxRepository.DeletePublished"
+
+	result, err := r.db.Pool.Exec(ctx, `DELETE FROM outbox WHERE published_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// CountPending возвращает количество неопубликованных событий
+func (r *OutboxRepository) CountPending(ctx context.Context) (int64, error) {
+	const op = "repository.postgres.OutboxRepository.CountPending"
+
+	var count int64
+	if err := r.db.Pool.QueryRow(ctx, `SELECT count(*) FROM outbox WHERE published_at IS NULL`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
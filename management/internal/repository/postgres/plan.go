@@ -12,6 +12,9 @@ import (
 	"github.com/makhtech/management/internal/repository"
 )
 
+// planColumns список колонок плана в порядке, ожидаемом scanPlan
+const planColumns = `id, name, cpu, ram_mb, disk_gb, price_month, is_active, created_at, deleted_at`
+
 // PlanRepository - репозиторий для работы с планами
 type PlanRepository struct {
 	db *Database
@@ -22,6 +25,26 @@ func NewPlanRepository(db *Database) *PlanRepository {
 	return &PlanRepository{db: db}
 }
 
+// scanPlan сканирует строку с колонками planColumns
+func scanPlan(row interface{ Scan(dest ...any) error }) (*models.Plan, error) {
+	var plan models.Plan
+	err := row.Scan(
+		&plan.ID,
+		&plan.Name,
+		&plan.CPU,
+		&plan.RAMMB,
+		&plan.DiskGB,
+		&plan.PriceMonth,
+		&plan.IsActive,
+		&plan.CreatedAt,
+		&plan.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
 // Create создает новый план
 func (r *PlanRepository) Create(ctx context.Context, req *models.CreatePlanRequest) (*models.Plan, error) {
 	const op = "repository.postgres.PlanRepository.Create"
@@ -29,59 +52,34 @@ func (r *PlanRepository) Create(ctx context.Context, req *models.CreatePlanReque
 	query := `
 		INSERT INTO plans (name, cpu, ram_mb, disk_gb, price_month, is_active, created_at)
 		VALUES ($1, $2, $3, $4, $5, true, $6)
-		RETURNING id, name, cpu, ram_mb, disk_gb, price_month, is_active, created_at
-	`
+		RETURNING ` + planColumns
 
-	var plan models.Plan
 	now := time.Now()
 
-	err := r.db.Pool.QueryRow(ctx, query,
+	plan, err := scanPlan(r.db.Pool.QueryRow(ctx, query,
 		req.Name,
 		req.CPU,
 		req.RAMMB,
 		req.DiskGB,
 		req.PriceMonth,
 		now,
-	).Scan(
-		&plan.ID,
-		&plan.Name,
-		&plan.CPU,
-		&plan.RAMMB,
-		&plan.DiskGB,
-		&plan.PriceMonth,
-		&plan.IsActive,
-		&plan.CreatedAt,
-	)
+	))
 
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &plan, nil
+	return plan, nil
 }
 
-// GetByID получает план по ID
+// GetByID получает план по ID. Архивные планы тоже возвращаются: по ним продлеваются
+// уже купленные VDS
 func (r *PlanRepository) GetByID(ctx context.Context, id int32) (*models.Plan, error) {
 	const op = "repository.postgres.PlanRepository.GetByID"
 
-	query := `
-		SELECT id, name, cpu, ram_mb, disk_gb, price_month, is_active, created_at
-		FROM plans
-		WHERE id = $1
-	`
-
-	var plan models.Plan
-	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
-		&plan.ID,
-		&plan.Name,
-		&plan.CPU,
-		&plan.RAMMB,
-		&plan.DiskGB,
-		&plan.PriceMonth,
-		&plan.IsActive,
-		&plan.CreatedAt,
-	)
+	query := `SELECT ` + planColumns + ` FROM plans WHERE id = $1`
 
+	plan, err := scanPlan(r.db.Pool.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, repository.ErrPlanNotFound
@@ -89,10 +87,10 @@ func (r *PlanRepository) GetByID(ctx context.Context, id int32) (*models.Plan, e
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &plan, nil
+	return plan, nil
 }
 
-// Update обновляет существующий план
+// Update обновляет существующий план. Архивный план не изменяется - ErrPlanArchived
 func (r *PlanRepository) Update(ctx context.Context, req *models.UpdatePlanRequest) (*models.Plan, error) {
 	const op = "repository.postgres.PlanRepository.Update"
 
@@ -141,99 +139,173 @@ func (r *PlanRepository) Update(ctx context.Context, req *models.UpdatePlanReque
 	query := fmt.Sprintf(`
 		UPDATE plans
 		SET %s
-		WHERE id = $%d
-		RETURNING id, name, cpu, ram_mb, disk_gb, price_month, is_active, created_at
-	`, strings.Join(setClauses, ", "), argIndex)
-
-	var plan models.Plan
-	err := r.db.Pool.QueryRow(ctx, query, args...).Scan(
-		&plan.ID,
-		&plan.Name,
-		&plan.CPU,
-		&plan.RAMMB,
-		&plan.DiskGB,
-		&plan.PriceMonth,
-		&plan.IsActive,
-		&plan.CreatedAt,
-	)
+		WHERE id = $%d AND deleted_at IS NULL
+		RETURNING `+planColumns, strings.Join(setClauses, ", "), argIndex)
 
+	plan, err := scanPlan(r.db.Pool.QueryRow(ctx, query, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, repository.ErrPlanNotFound
+			return nil, r.notUpdatable(ctx, req.ID)
 		}
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &plan, nil
+	return plan, nil
 }
 
-// Delete удаляет план по ID
+// notUpdatable выясняет, почему план не нашёлся для изменения: его нет или он в архиве
+func (r *PlanRepository) notUpdatable(ctx context.Context, id int32) error {
+	const op = "repository.postgres.PlanRepository.notUpdatable"
+
+	var archived bool
+	err := r.db.Pool.QueryRow(ctx, `SELECT deleted_at IS NOT NULL FROM plans WHERE id = $1`, id).Scan(&archived)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrPlanNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if archived {
+		return repository.ErrPlanArchived
+	}
+	return repository.ErrPlanNotFound
+}
+
+// Delete удаляет план по ID. План, на который ссылается хотя бы одна VDS (включая удалённые),
+// не удаляется - ErrPlanInUse, его можно только архивировать
 func (r *PlanRepository) Delete(ctx context.Context, id int32) error {
 	const op = "repository.postgres.PlanRepository.Delete"
 
-	query := `DELETE FROM plans WHERE id = $1`
+	query := `
+		DELETE FROM plans
+		WHERE id = $1
+		  AND NOT EXISTS (SELECT 1 FROM vds WHERE plan_id = $1)
+		RETURNING id
+	`
 
-	result, err := r.db.Pool.Exec(ctx, query, id)
+	var deleted int32
+	err := r.db.Pool.QueryRow(ctx, query, id).Scan(&deleted)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return r.notDeletable(ctx, id)
+		}
+		// VDS на этот план создана параллельно с удалением
+		if isPgError(err, pgErrForeignKeyViolation) {
+			return repository.ErrPlanInUse
+		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if result.RowsAffected() == 0 {
+	return nil
+}
+
+// notDeletable выясняет, почему план не удалился: его нет или на него ссылаются VDS
+func (r *PlanRepository) notDeletable(ctx context.Context, id int32) error {
+	const op = "repository.postgres.PlanRepository.notDeletable"
+
+	var exists bool
+	err := r.db.Pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM plans WHERE id = $1)`, id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
 		return repository.ErrPlanNotFound
 	}
-
-	return nil
+	return repository.ErrPlanInUse
 }
 
-// List возвращает список планов
-func (r *PlanRepository) List(ctx context.Context, activeOnly bool) ([]*models.Plan, error) {
-	const op = "repository.postgres.PlanRepository.List"
+// Archive снимает план с продажи и скрывает его из списка планов. План остаётся
+// для истории биллинга и продления уже купленных VDS. Повторная архивация не меняет deleted_at
+func (r *PlanRepository) Archive(ctx context.Context, id int32) (*models.Plan, error) {
+	const op = "repository.postgres.PlanRepository.Archive"
 
-	var query string
-	var args []interface{}
+	query := `
+		UPDATE plans
+		SET is_active = false,
+		    deleted_at = COALESCE(deleted_at, now())
+		WHERE id = $1
+		RETURNING ` + planColumns
 
-	if activeOnly {
-		query = `
-			SELECT id, name, cpu, ram_mb, disk_gb, price_month, is_active, created_at
-			FROM plans
-			WHERE is_active = true
-			ORDER BY id
-		`
-	} else {
-		query = `
-			SELECT id, name, cpu, ram_mb, disk_gb, price_month, is_active, created_at
-			FROM plans
-			ORDER BY id
-		`
-	}
-
-	rows, err := r.db.Pool.Query(ctx, query, args...)
+	plan, err := scanPlan(r.db.Pool.QueryRow(ctx, query, id))
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrPlanNotFound
+		}
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	defer rows.Close()
-
-	var plans []*models.Plan
-	for rows.Next() {
-		var plan models.Plan
-		if err := rows.Scan(
-			&plan.ID,
-			&plan.Name,
-			&plan.CPU,
-			&plan.RAMMB,
-			&plan.DiskGB,
-			&plan.PriceMonth,
-			&plan.IsActive,
-			&plan.CreatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
-		plans = append(plans, &plan)
+
+	return plan, nil
+}
+
+// planSortKeys поля order_by списка планов
+func planSortKeys() map[string]sortKey[*models.Plan] {
+	return map[string]sortKey[*models.Plan]{
+		"id":          {column: "id"},
+		"name":        {column: "name", cast: "text", value: func(p *models.Plan) string { return p.Name }},
+		"cpu":         {column: "cpu", cast: "integer", value: func(p *models.Plan) string { return cursorInt(p.CPU) }},
+		"ram_mb":      {column: "ram_mb", cast: "integer", value: func(p *models.Plan) string { return cursorInt(p.RAMMB) }},
+		"price_month": {column: "price_month", cast: "numeric", value: func(p *models.Plan) string { return cursorFloat(p.PriceMonth) }},
+		"created_at":  {column: "created_at", cast: "timestamptz", value: func(p *models.Plan) string { return cursorTime(p.CreatedAt) }},
 	}
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+var planPage = pageSpec[*models.Plan]{
+	query:        `SELECT ` + planColumns + ` FROM plans`,
+	idColumn:     "id",
+	id:           func(p *models.Plan) int64 { return int64(p.ID) },
+	scan:         scanPlan,
+	keys:         planSortKeys(),
+	defaultOrder: "id",
+}
+
+var archivedPlanPage = func() pageSpec[*models.Plan] {
+	spec := planPage
+	spec.keys = planSortKeys()
+	spec.keys["deleted_at"] = sortKey[*models.Plan]{
+		column: "deleted_at",
+		cast:   "timestamptz",
+		value:  func(p *models.Plan) string { return cursorTime(*p.DeletedAt) },
+	}
+	spec.defaultOrder = "deleted_at desc"
+	return spec
+}()
+
+// List возвращает страницу списка планов без архивных
+func (r *PlanRepository) List(ctx context.Context, filter models.PlanFilter, page models.PageRequest) ([]*models.Plan, string, error) {
+	const op = "repository.postgres.PlanRepository.List"
+
+	q := &listQuery{}
+	q.where("deleted_at IS NULL")
+	if filter.ActiveOnly {
+		q.where("is_active = true")
+	}
+	if filter.MinPrice != nil {
+		q.where("price_month >= " + q.arg(*filter.MinPrice))
+	}
+	if filter.MaxPrice != nil {
+		q.where("price_month <= " + q.arg(*filter.MaxPrice))
+	}
+	q.whereTime("created_at", filter.Created)
+
+	plans, next, err := listPage(ctx, r.db.Pool, planPage, q, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return plans, next, nil
+}
+
+// ListArchived возвращает страницу архивных планов, по умолчанию начиная с последних архивированных
+func (r *PlanRepository) ListArchived(ctx context.Context, page models.PageRequest) ([]*models.Plan, string, error) {
+	const op = "repository.postgres.PlanRepository.ListArchived"
+
+	q := &listQuery{}
+	q.where("deleted_at IS NOT NULL")
+
+	plans, next, err := listPage(ctx, r.db.Pool, archivedPlanPage, q, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return plans, nil
+	return plans, next, nil
 }
@@ -2,10 +2,13 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -18,6 +21,13 @@ const (
 	defaultConnectTimeout    = time.Second * 5
 )
 
+// Коды ошибок PostgreSQL, которые обрабатываются репозиториями
+const (
+	pgErrUniqueViolation     = "23505"
+	pgErrForeignKeyViolation = "23503"
+	pgErrExclusionViolation  = "23P01"
+)
+
 type Config struct {
 	Host     string
 	Port     string
@@ -41,6 +51,14 @@ func (c *Config) DSN() string {
 	)
 }
 
+// querier общий интерфейс pgxpool.Pool и pgx.Tx, чтобы одни и те же
+// запросы можно было выполнять как вне транзакции, так и внутри неё
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 type Database struct {
 	Pool *pgxpool.Pool
 	cfg  *Config
@@ -132,6 +150,58 @@ func (d *Database) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// MigrationVersion возвращает версию схемы из таблицы миграций golang-migrate.
+// dirty=true - последняя миграция применилась с ошибкой
+func (d *Database) MigrationVersion(ctx context.Context, table string) (version uint, dirty bool, err error) {
+	query := `SELECT version, dirty FROM ` + pgx.Identifier{table}.Sanitize() + ` LIMIT 1`
+
+	var v int64
+	if err := d.Pool.QueryRow(ctx, query).Scan(&v, &dirty); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("postgres: failed to get migration version: %w", err)
+	}
+
+	return uint(v), dirty, nil
+}
+
+// WithTx выполняет fn внутри транзакции: коммитит при успехе и откатывает при ошибке
+func (d *Database) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := d.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres: failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("postgres: failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 func (d *Database) Stats() *pgxpool.Stat {
 	return d.Pool.Stat()
 }
+
+// isPgError проверяет, что err является ошибкой PostgreSQL с указанным кодом
+func isPgError(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+// pgConstraint возвращает имя нарушенного ограничения из ошибки PostgreSQL
+func pgConstraint(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+	return ""
+}
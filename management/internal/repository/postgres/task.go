@@ -0,0 +1,300 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// taskColumns список колонок задачи в порядке, ожидаемом scanTask
+const taskColumns = `id, vds_id, type, status, COALESCE(error, ''), COALESCE(error_code, ''), created_at, started_at, completed_at`
+
+// TaskRepository - репозиторий для работы с фоновыми задачами
+type TaskRepository struct {
+	db *Database
+}
+
+// NewTaskRepository создает новый репозиторий задач
+func NewTaskRepository(db *Database) *TaskRepository {
+	return &TaskRepository{db: db}
+}
+
+// scanTask сканирует строку с колонками taskColumns
+func scanTask(row interface{ Scan(dest ...any) error }) (*models.Task, error) {
+	var task models.Task
+	err := row.Scan(
+		&task.ID,
+		&task.VDSID,
+		&task.Type,
+		&task.Status,
+		&task.Error,
+		&task.ErrorCode,
+		&task.CreatedAt,
+		&task.StartedAt,
+		&task.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// insertTask ставит задачу в очередь (статус pending). Используется внутри транзакций
+// других репозиториев, чтобы изменение VDS и постановка задачи были атомарными
+func insertTask(ctx context.Context, q querier, vdsID int32, taskType models.TaskType) (*models.Task, error) {
+	query := `
+		INSERT INTO tasks (vds_id, type, status)
+		VALUES ($1, $2, 'pending')
+		RETURNING ` + taskColumns
+
+	return scanTask(q.QueryRow(ctx, query, vdsID, taskType))
+}
+
+// Create ставит задачу питания в очередь с теми же проверками, что и VDSRepository.QueuePower.
+// Задачи create и delete ставятся только вместе с изменением VDS (создание, MarkDeleting) -
+// ErrTaskTypeNotAllowed
+func (r *TaskRepository) Create(ctx context.Context, vdsID int32, taskType models.TaskType) (*models.Task, error) {
+	const op = "repository.postgres.TaskRepository.Create"
+
+	if !taskType.IsPowerAction() {
+		return nil, repository.ErrTaskTypeNotAllowed
+	}
+
+	task, err := queuePower(ctx, r.db, vdsID, taskType)
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) ||
+			errors.Is(err, repository.ErrTaskInProgress) ||
+			errors.Is(err, repository.ErrInvalidVDSState) ||
+			errors.Is(err, repository.ErrVDSSuspended) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+// GetByID получает задачу по ID
+func (r *TaskRepository) GetByID(ctx context.Context, id int32) (*models.Task, error) {
+	const op = "repository.postgres.TaskRepository.GetByID"
+
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1`
+
+	task, err := scanTask(r.db.Pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+var taskPage = pageSpec[*models.Task]{
+	query:    `SELECT ` + taskColumns + ` FROM tasks`,
+	idColumn: "id",
+	id:       func(t *models.Task) int64 { return int64(t.ID) },
+	scan:     scanTask,
+	keys: map[string]sortKey[*models.Task]{
+		"id":         {column: "id"},
+		"status":     {column: "status", cast: "text", value: func(t *models.Task) string { return string(t.Status) }},
+		"created_at": {column: "created_at", cast: "timestamptz", value: func(t *models.Task) string { return cursorTime(t.CreatedAt) }},
+	},
+	defaultOrder: "created_at desc",
+}
+
+// ListByVDS возвращает страницу задач VDS, по умолчанию начиная с самых новых
+func (r *TaskRepository) ListByVDS(ctx context.Context, filter models.TaskFilter, page models.PageRequest) ([]*models.Task, string, error) {
+	const op = "repository.postgres.TaskRepository.ListByVDS"
+
+	q := &listQuery{}
+	q.where("vds_id = " + q.arg(filter.VDSID))
+	if filter.Status != nil {
+		q.where("status = " + q.arg(string(*filter.Status)))
+	}
+	if filter.Type != nil {
+		q.where("type = " + q.arg(string(*filter.Type)))
+	}
+	q.whereTime("created_at", filter.Created)
+
+	tasks, next, err := listPage(ctx, r.db.Pool, taskPage, q, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, next, nil
+}
+
+// UpdateStatus меняет статус задачи. started_at проставляется при переходе в running,
+// completed_at - при переходе в done или error. Текст и код ошибки сохраняются только для статуса error
+func (r *TaskRepository) UpdateStatus(ctx context.Context, req *models.UpdateTaskStatusRequest) (*models.Task, error) {
+	const op = "repository.postgres.TaskRepository.UpdateStatus"
+
+	var task *models.Task
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var current models.TaskStatus
+		err := tx.QueryRow(ctx, `SELECT status FROM tasks WHERE id = $1 FOR UPDATE`, req.ID).Scan(&current)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repository.ErrTaskNotFound
+			}
+			return err
+		}
+
+		if !current.CanTransitionTo(req.Status) {
+			return fmt.Errorf("%w: %s -> %s", repository.ErrInvalidTaskTransition, current, req.Status)
+		}
+
+		task, err = updateTaskStatus(ctx, tx, req)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrTaskNotFound) || errors.Is(err, repository.ErrInvalidTaskTransition) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+// GetPendingCount возвращает количество незавершённых (pending/running) задач VDS
+func (r *TaskRepository) GetPendingCount(ctx context.Context, vdsID int32) (int32, error) {
+	const op = "repository.postgres.TaskRepository.GetPendingCount"
+
+	var count int32
+	if err := r.db.Pool.QueryRow(ctx, `SELECT get_pending_tasks_count($1)`, vdsID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// CountQueued возвращает количество незавершённых задач (pending и running) по статусам
+func (r *TaskRepository) CountQueued(ctx context.Context) (map[models.TaskStatus]int64, error) {
+	const op = "repository.postgres.TaskRepository.CountQueued"
+
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT status, count(*)
+		FROM tasks
+		WHERE status IN ('pending', 'running')
+		GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	counts := map[models.TaskStatus]int64{
+		models.TaskStatusPending: 0,
+		models.TaskStatusRunning: 0,
+	}
+	for rows.Next() {
+		var status models.TaskStatus
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		counts[status] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return counts, nil
+}
+
+// ClaimNext атомарно забирает самую старую pending задачу и переводит её в running.
+// SKIP LOCKED позволяет нескольким воркерам и репликам выбирать задачи параллельно,
+// не блокируя друг друга. По каждой VDS в работу берётся только самая ранняя
+// незавершённая задача, чтобы операции над одной VM выполнялись строго по очереди
+func (r *TaskRepository) ClaimNext(ctx context.Context) (*models.Task, error) {
+	const op = "repository.postgres.TaskRepository.ClaimNext"
+
+	query := `
+		UPDATE tasks
+		SET status = 'running', started_at = now()
+		WHERE id = (
+			SELECT t.id
+			FROM tasks t
+			WHERE t.status = 'pending'
+			  AND NOT EXISTS (
+			      SELECT 1 FROM tasks r
+			      WHERE r.vds_id = t.vds_id
+			        AND (r.status = 'running' OR (r.status = 'pending' AND r.id < t.id))
+			  )
+			ORDER BY t.created_at, t.id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + taskColumns
+
+	task, err := scanTask(r.db.Pool.QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNoPendingTasks
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+// FailStale завершает с ошибкой задачи, которые находятся в running дольше olderThan.
+// VDS, застрявшие в creating из-за такой задачи, переводятся в error
+func (r *TaskRepository) FailStale(ctx context.Context, olderThan time.Duration) (int64, error) {
+	const op = "repository.postgres.TaskRepository.FailStale"
+
+	query := `
+		WITH stale AS (
+			UPDATE tasks
+			SET status = 'error',
+			    error = 'task timed out: worker did not report completion',
+			    error_code = 'internal',
+			    completed_at = now()
+			WHERE status = 'running'
+			  AND started_at < now() - $1::interval
+			RETURNING vds_id, type
+		), failed_vds AS (
+			UPDATE vds
+			SET status = 'error'
+			FROM stale
+			WHERE vds.id = stale.vds_id
+			  AND stale.type = 'create'
+			  AND vds.status = 'creating'
+			RETURNING vds.id
+		)
+		SELECT COUNT(*) FROM stale
+	`
+
+	var failed int64
+	if err := r.db.Pool.QueryRow(ctx, query, olderThan).Scan(&failed); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return failed, nil
+}
+
+// updateTaskStatus выполняет смену статуса без проверки перехода.
+// Вызывающий код отвечает за блокировку строки и валидацию
+func updateTaskStatus(ctx context.Context, q querier, req *models.UpdateTaskStatusRequest) (*models.Task, error) {
+	query := `
+		UPDATE tasks
+		SET status = $2::varchar,
+		    error = CASE WHEN $2::varchar = 'error' THEN $3::text ELSE NULL END,
+		    error_code = CASE WHEN $2::varchar = 'error' THEN NULLIF($4::varchar, '') ELSE NULL END,
+		    started_at = CASE WHEN $2::varchar = 'running' THEN COALESCE(started_at, now()) ELSE started_at END,
+		    completed_at = CASE WHEN $2::varchar IN ('done', 'error') THEN now() ELSE completed_at END
+		WHERE id = $1
+		RETURNING ` + taskColumns
+
+	return scanTask(q.QueryRow(ctx, query, req.ID, req.Status, req.Error, req.ErrorCode))
+}
@@ -0,0 +1,611 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// advisoryLockNodeCreate пространство advisory-локов для создания VDS на ноде
+const advisoryLockNodeCreate = 1
+
+// vdsColumns список колонок VDS в порядке, ожидаемом scanVDS
+const vdsColumns = `id, user_id, plan_id, node_id, proxmox_vm_id, status,
+	COALESCE(host(ipv4), ''), COALESCE(host(ipv6), ''), created_at, expires_at,
+	COALESCE(reservation_id, ''), COALESCE(billing_amount, 0), COALESCE(billing_status, ''), suspended_at,
+	auto_renew_token IS NOT NULL`
+
+// VDSRepository - репозиторий для работы с VDS
+type VDSRepository struct {
+	db *Database
+	// reservedVMIDs VMID, занятые в кластере не VDS (шаблоны клонирования)
+	reservedVMIDs []int32
+}
+
+// NewVDSRepository создает новый репозиторий VDS. reservedVMIDs не выдаются новым VDS
+func NewVDSRepository(db *Database, reservedVMIDs ...int32) *VDSRepository {
+	return &VDSRepository{db: db, reservedVMIDs: reservedVMIDs}
+}
+
+// scanVDS сканирует строку с колонками vdsColumns
+func scanVDS(row interface{ Scan(dest ...any) error }) (*models.VDS, error) {
+	var vds models.VDS
+	err := row.Scan(
+		&vds.ID,
+		&vds.UserID,
+		&vds.PlanID,
+		&vds.NodeID,
+		&vds.ProxmoxVMID,
+		&vds.Status,
+		&vds.IPv4,
+		&vds.IPv6,
+		&vds.CreatedAt,
+		&vds.ExpiresAt,
+		&vds.ReservationID,
+		&vds.BillingAmount,
+		&vds.BillingStatus,
+		&vds.SuspendedAt,
+		&vds.AutoRenew,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &vds, nil
+}
+
+// Create создаёт VDS в статусе creating, выдаёт ей адреса из пулов ноды и ставит задачу create
+// в одной транзакции. IPv4 обязателен, IPv6 выдаётся, если у ноды есть IPv6 пул.
+// Если ресурсов ноды не хватает на тариф - ErrInsufficientResources.
+// Если VDS с тем же резервированием оплаты уже есть - ErrReservationUsed
+func (r *VDSRepository) Create(ctx context.Context, req *models.CreateVDSRequest) (*models.VDS, *models.Task, error) {
+	const op = "repository.postgres.VDSRepository.Create"
+
+	var vds *models.VDS
+	var task *models.Task
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		// Сериализуем создания на ноде: параллельные запросы не превысят её ресурсы
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1, $2)`, advisoryLockNodeCreate, req.NodeID); err != nil {
+			return err
+		}
+		if err := checkNodeCapacity(ctx, tx, req.NodeID, req.PlanID); err != nil {
+			return err
+		}
+
+		vmID, err := r.nextVMID(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO vds (user_id, plan_id, node_id, proxmox_vm_id, status, expires_at,
+			                 reservation_id, billing_amount, billing_status)
+			VALUES ($1, $2, $3, $4, 'creating', $5,
+			        NULLIF($6, ''), CASE WHEN $6 <> '' THEN $7::bigint END, CASE WHEN $6 <> '' THEN 'reserved' END)
+			RETURNING ` + vdsColumns
+
+		vds, err = scanVDS(tx.QueryRow(ctx, query,
+			req.UserID,
+			req.PlanID,
+			req.NodeID,
+			vmID,
+			req.ExpiresAt,
+			req.ReservationID,
+			req.BillingAmount,
+		))
+		if err != nil {
+			return err
+		}
+
+		vds, err = allocateVDSAddrs(ctx, tx, vds, true, true)
+		if err != nil {
+			return err
+		}
+
+		task, err = insertTask(ctx, tx, vds.ID, models.TaskTypeCreate)
+		return err
+	})
+
+	if err != nil {
+		if isPgError(err, pgErrUniqueViolation) && pgConstraint(err) == "idx_vds_reservation_id" {
+			return nil, nil, repository.ErrReservationUsed
+		}
+		if isPgError(err, pgErrForeignKeyViolation) {
+			if pgConstraint(err) == "vds_node_id_fkey" {
+				return nil, nil, repository.ErrNodeNotFound
+			}
+			return nil, nil, repository.ErrPlanNotFound
+		}
+		if errors.Is(err, repository.ErrNoFreeIP) || errors.Is(err, repository.ErrInsufficientResources) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return vds, task, nil
+}
+
+// nextVMID выдаёт VMID из общей последовательности: номер VM уникален во всём кластере Proxmox,
+// поэтому VDS на разных нодах не получат один номер. Зарезервированные номера пропускаются
+func (r *VDSRepository) nextVMID(ctx context.Context, q querier) (int32, error) {
+	for {
+		var vmID int32
+		if err := q.QueryRow(ctx, `SELECT nextval('vds_proxmox_vm_id_seq')`).Scan(&vmID); err != nil {
+			return 0, err
+		}
+		if !slices.Contains(r.reservedVMIDs, vmID) {
+			return vmID, nil
+		}
+	}
+}
+
+// checkNodeCapacity проверяет по node_utilization, что VDS тарифа помещается на ноду.
+// Для неактивной или несуществующей ноды проверка пропускается - её отклонит сервис или FK
+func checkNodeCapacity(ctx context.Context, q querier, nodeID, planID int32) error {
+	var fits bool
+	err := q.QueryRow(ctx, `
+		SELECT u.max_cpu - u.used_cpu >= p.cpu
+		   AND u.max_ram - u.used_ram >= p.ram_mb
+		   AND u.max_disk - u.used_disk >= p.disk_gb
+		FROM node_utilization u, plans p
+		WHERE u.id = $1 AND p.id = $2`, nodeID, planID).Scan(&fits)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !fits {
+		return repository.ErrInsufficientResources
+	}
+	return nil
+}
+
+// GetByID получает VDS по ID
+func (r *VDSRepository) GetByID(ctx context.Context, id int32) (*models.VDS, error) {
+	const op = "repository.postgres.VDSRepository.GetByID"
+
+	query := `SELECT ` + vdsColumns + ` FROM vds WHERE id = $1`
+
+	vds, err := scanVDS(r.db.Pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrVDSNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return vds, nil
+}
+
+// GetByReservationID получает VDS, оплаченную резервированием reservationID
+func (r *VDSRepository) GetByReservationID(ctx context.Context, reservationID string) (*models.VDS, error) {
+	const op = "repository.postgres.VDSRepository.GetByReservationID"
+
+	query := `SELECT ` + vdsColumns + ` FROM vds WHERE reservation_id = $1`
+
+	vds, err := scanVDS(r.db.Pool.QueryRow(ctx, query, reservationID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrVDSNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return vds, nil
+}
+
+var vdsPage = pageSpec[*models.VDS]{
+	query:    `SELECT ` + vdsColumns + ` FROM vds`,
+	idColumn: "id",
+	id:       func(v *models.VDS) int64 { return int64(v.ID) },
+	scan:     scanVDS,
+	keys: map[string]sortKey[*models.VDS]{
+		"id":         {column: "id"},
+		"status":     {column: "status", cast: "text", value: func(v *models.VDS) string { return string(v.Status) }},
+		"created_at": {column: "created_at", cast: "timestamptz", value: func(v *models.VDS) string { return cursorTime(v.CreatedAt) }},
+		"expires_at": {column: "expires_at", cast: "timestamptz", value: func(v *models.VDS) string { return cursorTime(v.ExpiresAt) }},
+	},
+	defaultOrder: "id",
+}
+
+// ListByUser возвращает страницу VDS пользователя. Без фильтра по статусу удалённые VDS не возвращаются
+func (r *VDSRepository) ListByUser(ctx context.Context, filter models.VDSFilter, page models.PageRequest) ([]*models.VDS, string, error) {
+	const op = "repository.postgres.VDSRepository.ListByUser"
+
+	q := &listQuery{}
+	q.where("user_id = " + q.arg(filter.UserID))
+	if filter.Status != nil {
+		q.where("status = " + q.arg(string(*filter.Status)))
+	} else {
+		q.where("status <> 'deleted'")
+	}
+	if filter.NodeID != nil {
+		q.where("node_id = " + q.arg(*filter.NodeID))
+	}
+	if filter.PlanID != nil {
+		q.where("plan_id = " + q.arg(*filter.PlanID))
+	}
+	q.whereTime("created_at", filter.Created)
+
+	list, next, err := listPage(ctx, r.db.Pool, vdsPage, q, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return list, next, nil
+}
+
+// UpdateStatus обновляет статус VDS. Статус deleted ставит только MarkDeleted вместе
+// с освобождением адресов - ErrInvalidVDSState. Удалённая VDS считается не найденной
+func (r *VDSRepository) UpdateStatus(ctx context.Context, id int32, status models.VDSStatus) (*models.VDS, error) {
+	const op = "repository.postgres.VDSRepository.UpdateStatus"
+
+	if status == models.VDSStatusDeleted {
+		return nil, repository.ErrInvalidVDSState
+	}
+
+	query := `UPDATE vds SET status = $2 WHERE id = $1 AND status <> 'deleted' RETURNING ` + vdsColumns
+
+	vds, err := scanVDS(r.db.Pool.QueryRow(ctx, query, id, status))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrVDSNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return vds, nil
+}
+
+// AllocateIP назначает VDS адреса из пулов её ноды. Явно переданный адрес закрепляется за VDS
+// вместо прежнего адреса того же семейства. Если адреса не переданы, VDS получает следующие
+// свободные адреса тех семейств, которых у неё ещё нет
+func (r *VDSRepository) AllocateIP(ctx context.Context, req *models.AllocateIPRequest) (*models.VDS, error) {
+	const op = "repository.postgres.VDSRepository.AllocateIP"
+
+	var vds *models.VDS
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		vds, err = scanVDS(tx.QueryRow(ctx, `SELECT `+vdsColumns+` FROM vds WHERE id = $1 FOR UPDATE`, req.VDSID))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repository.ErrVDSNotFound
+			}
+			return err
+		}
+		if vds.Status == models.VDSStatusDeleted {
+			return repository.ErrVDSNotFound
+		}
+
+		if req.IPv4 == "" && req.IPv6 == "" {
+			vds, err = allocateVDSAddrs(ctx, tx, vds, vds.IPv4 == "", vds.IPv6 == "")
+			return err
+		}
+
+		for _, raw := range []string{req.IPv4, req.IPv6} {
+			if raw == "" {
+				continue
+			}
+			addr, err := netip.ParseAddr(raw)
+			if err != nil {
+				return err
+			}
+			if err := assignIP(ctx, tx, vds.ID, vds.NodeID, addr); err != nil {
+				return err
+			}
+		}
+
+		vds, err = updateVDSAddrs(ctx, tx, vds.ID, req.IPv4, req.IPv6)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) ||
+			errors.Is(err, repository.ErrNoFreeIP) ||
+			errors.Is(err, repository.ErrIPUnavailable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return vds, nil
+}
+
+// allocateVDSAddrs выдаёт VDS следующие свободные адреса нужных семейств и сохраняет их в строке VDS.
+// Отсутствие IPv4 пула у ноды считается нехваткой адресов, отсутствие IPv6 пула - нет
+func allocateVDSAddrs(ctx context.Context, q querier, vds *models.VDS, needV4, needV6 bool) (*models.VDS, error) {
+	var ipv4, ipv6 string
+
+	if needV4 {
+		addr, err := allocateNextIP(ctx, q, vds.ID, vds.NodeID, models.IPFamilyV4)
+		if err != nil {
+			if errors.Is(err, errNoIPPool) {
+				return nil, fmt.Errorf("%w: node has no ipv4 pool", repository.ErrNoFreeIP)
+			}
+			return nil, err
+		}
+		ipv4 = addr.String()
+	}
+
+	if needV6 {
+		addr, err := allocateNextIP(ctx, q, vds.ID, vds.NodeID, models.IPFamilyV6)
+		switch {
+		case err == nil:
+			ipv6 = addr.String()
+		case !errors.Is(err, errNoIPPool):
+			return nil, err
+		}
+	}
+
+	return updateVDSAddrs(ctx, q, vds.ID, ipv4, ipv6)
+}
+
+// updateVDSAddrs записывает адреса в строку VDS. Пустой адрес оставляет текущее значение
+func updateVDSAddrs(ctx context.Context, q querier, id int32, ipv4, ipv6 string) (*models.VDS, error) {
+	query := `
+		UPDATE vds
+		SET ipv4 = COALESCE(NULLIF($2, '')::inet, ipv4),
+		    ipv6 = COALESCE(NULLIF($3, '')::inet, ipv6)
+		WHERE id = $1
+		RETURNING ` + vdsColumns
+
+	return scanVDS(q.QueryRow(ctx, query, id, ipv4, ipv6))
+}
+
+// MarkDeleting переводит VDS в статус deleting и ставит задачу delete в одной транзакции.
+// Сама строка VDS не удаляется - это делает обработчик задачи после удаления VM.
+// Возвращает repository.ErrTaskInProgress, если по VDS уже есть незавершённая задача
+func (r *VDSRepository) MarkDeleting(ctx context.Context, id int32) (*models.Task, error) {
+	const op = "repository.postgres.VDSRepository.MarkDeleting"
+
+	var task *models.Task
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		task, err = markDeleting(ctx, tx, id)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) || errors.Is(err, repository.ErrTaskInProgress) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+// markDeleting переводит VDS в deleting и ставит задачу delete в транзакции q
+func markDeleting(ctx context.Context, q querier, id int32) (*models.Task, error) {
+	// Блокируем строку VDS, чтобы параллельные операции над ней выполнялись по очереди
+	var status models.VDSStatus
+	err := q.QueryRow(ctx, `SELECT status FROM vds WHERE id = $1 FOR UPDATE`, id).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrVDSNotFound
+		}
+		return nil, err
+	}
+
+	if status == models.VDSStatusDeleting {
+		return nil, repository.ErrTaskInProgress
+	}
+	if status == models.VDSStatusDeleted {
+		return nil, repository.ErrVDSNotFound
+	}
+
+	var pending int32
+	if err := q.QueryRow(ctx, `SELECT get_pending_tasks_count($1)`, id).Scan(&pending); err != nil {
+		return nil, err
+	}
+	if pending > 0 {
+		return nil, repository.ErrTaskInProgress
+	}
+
+	if _, err := q.Exec(ctx, `UPDATE vds SET status = 'deleting' WHERE id = $1`, id); err != nil {
+		return nil, err
+	}
+
+	return insertTask(ctx, q, id, models.TaskTypeDelete)
+}
+
+// QueuePower ставит задачу питания action, если статус VDS её допускает. Статус проверяется
+// под блокировкой строки VDS, поэтому параллельные вызовы не поставят две задачи.
+// Возвращает repository.ErrTaskInProgress, если по VDS уже есть незавершённая задача,
+// repository.ErrInvalidVDSState, если статус VDS не допускает action, и repository.ErrVDSSuspended
+// при запуске VDS, приостановленной за неоплату
+func (r *VDSRepository) QueuePower(ctx context.Context, id int32, action models.TaskType) (*models.Task, error) {
+	const op = "repository.postgres.VDSRepository.QueuePower"
+
+	task, err := queuePower(ctx, r.db, id, action)
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) ||
+			errors.Is(err, repository.ErrTaskInProgress) ||
+			errors.Is(err, repository.ErrInvalidVDSState) ||
+			errors.Is(err, repository.ErrVDSSuspended) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+// queuePower проверяет статус VDS под блокировкой строки и ставит задачу питания.
+// Общая часть QueuePower и TaskRepository.Create
+func queuePower(ctx context.Context, db *Database, id int32, action models.TaskType) (*models.Task, error) {
+	var task *models.Task
+
+	err := db.WithTx(ctx, func(tx pgx.Tx) error {
+		var status models.VDSStatus
+		var suspended bool
+		err := tx.QueryRow(ctx,
+			`SELECT status, suspended_at IS NOT NULL FROM vds WHERE id = $1 FOR UPDATE`, id,
+		).Scan(&status, &suspended)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repository.ErrVDSNotFound
+			}
+			return err
+		}
+
+		if status == models.VDSStatusDeleted {
+			return repository.ErrVDSNotFound
+		}
+
+		var pending int32
+		if err := tx.QueryRow(ctx, `SELECT get_pending_tasks_count($1)`, id).Scan(&pending); err != nil {
+			return err
+		}
+		if pending > 0 {
+			return repository.ErrTaskInProgress
+		}
+
+		if !status.CanPower(action) {
+			return repository.ErrInvalidVDSState
+		}
+		if suspended && (action == models.TaskTypeStart || action == models.TaskTypeRestart) {
+			return repository.ErrVDSSuspended
+		}
+
+		task, err = insertTask(ctx, tx, id, action)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// MarkDeleted переводит VDS в статус deleted и возвращает её адреса в пулы в одной транзакции
+func (r *VDSRepository) MarkDeleted(ctx context.Context, id int32) (*models.VDS, error) {
+	const op = "repository.postgres.VDSRepository.MarkDeleted"
+
+	var vds *models.VDS
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		query := `
+			UPDATE vds
+			SET status = 'deleted', ipv4 = NULL, ipv6 = NULL, auto_renew_token = NULL
+			WHERE id = $1
+			RETURNING ` + vdsColumns
+
+		var err error
+		vds, err = scanVDS(tx.QueryRow(ctx, query, id))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repository.ErrVDSNotFound
+			}
+			return err
+		}
+
+		return releaseIPs(ctx, tx, id)
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return vds, nil
+}
+
+// SettleBilling фиксирует итог резервирования оплаты VDS. Меняется только статус reserved,
+// поэтому повторный вызов после уже записанного итога возвращает false без ошибки
+func (r *VDSRepository) SettleBilling(ctx context.Context, id int32, status models.BillingStatus) (bool, error) {
+	const op = "repository.postgres.VDSRepository.SettleBilling"
+
+	result, err := r.db.Pool.Exec(ctx,
+		`UPDATE vds SET billing_status = $2 WHERE id = $1 AND billing_status = 'reserved'`,
+		id, status,
+	)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// SettleRenewal фиксирует списание оплаты продления. Меняется только статус reserved,
+// поэтому повторная запись итога возвращает false
+func (r *VDSRepository) SettleRenewal(ctx context.Context, reservationID string) (bool, error) {
+	const op = "repository.postgres.VDSRepository.SettleRenewal"
+
+	result, err := r.db.Pool.Exec(ctx,
+		`UPDATE vds_renewals SET billing_status = 'committed' WHERE reservation_id = $1 AND billing_status = 'reserved'`,
+		reservationID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// ListUnsettled возвращает резервирования VDS, задача create которых завершилась раньше olderThan,
+// и продления старше olderThan, итог оплаты которых ещё не записан. Свежие пропускаются:
+// их итог отправляет сам воркер или запрос продления
+func (r *VDSRepository) ListUnsettled(ctx context.Context, olderThan time.Duration, limit int) ([]*models.BillingSettlement, error) {
+	const op = "repository.postgres.VDSRepository.ListUnsettled"
+
+	query := `
+		SELECT vds_id, reservation_id, commit, renewal
+		FROM (
+			SELECT v.id AS vds_id, v.reservation_id, t.status = 'done' AS commit, false AS renewal, t.completed_at AS settle_after
+			FROM vds v
+			JOIN LATERAL (
+				SELECT status, completed_at
+				FROM tasks
+				WHERE vds_id = v.id AND type = 'create'
+				ORDER BY id DESC
+				LIMIT 1
+			) t ON true
+			WHERE v.billing_status = 'reserved'
+			  AND t.status IN ('done', 'error')
+			  AND t.completed_at < now() - $1::interval
+
+			UNION ALL
+
+			SELECT vds_id, reservation_id, true, true, created_at
+			FROM vds_renewals
+			WHERE billing_status = 'reserved'
+			  AND created_at < now() - $1::interval
+		) u
+		ORDER BY settle_after
+		LIMIT $2
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, olderThan, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var list []*models.BillingSettlement
+	for rows.Next() {
+		var s models.BillingSettlement
+		if err := rows.Scan(&s.VDSID, &s.ReservationID, &s.Commit, &s.Renewal); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		list = append(list, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,426 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// vdsEventColumns список колонок события в порядке, ожидаемом scanVDSEvent
+const vdsEventColumns = `id, vds_id, type, message, details, created_at`
+
+func scanVDSEvent(row interface{ Scan(dest ...any) error }) (*models.VDSEvent, error) {
+	var event models.VDSEvent
+	err := row.Scan(
+		&event.ID,
+		&event.VDSID,
+		&event.Type,
+		&event.Message,
+		&event.Details,
+		&event.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// insertVDSEvent записывает событие жизненного цикла VDS
+func insertVDSEvent(ctx context.Context, q querier, event *models.VDSEvent) error {
+	_, err := q.Exec(ctx,
+		`INSERT INTO vds_events (vds_id, type, message, details) VALUES ($1, $2, $3, $4)`,
+		event.VDSID, event.Type, event.Message, labelsOrEmpty(event.Details),
+	)
+	return err
+}
+
+// listVDS выполняет запрос, возвращающий колонки vdsColumns
+func (r *VDSRepository) listVDS(ctx context.Context, query string, args ...any) ([]*models.VDS, error) {
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*models.VDS
+	for rows.Next() {
+		vds, err := scanVDS(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, vds)
+	}
+
+	return list, rows.Err()
+}
+
+// Renew продлевает подписку VDS на months месяцев от max(expires_at, now) и снимает приостановку.
+// Приостановленная VDS ставится на запуск, если она остановлена или её остановка ещё не выполнена:
+// задачи VDS выполняются по порядку, поэтому запуск отработает после остановки. Непустой reservationID
+// записывается в той же транзакции как неподтверждённая оплата: если списание после продления не пройдёт,
+// его повторит сверка биллинга. Если подписка изменилась после чтения (expires_at не равен expectedExpiresAt) -
+// ErrSubscriptionChanged
+func (r *VDSRepository) Renew(
+	ctx context.Context,
+	id int32,
+	expectedExpiresAt time.Time,
+	months int,
+	reservationID string,
+	details map[string]string,
+) (*models.VDS, *models.Task, error) {
+	const op = "repository.postgres.VDSRepository.Renew"
+
+	var vds *models.VDS
+	var task *models.Task
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var suspended, stopping bool
+		err := tx.QueryRow(ctx, `
+			SELECT v.suspended_at IS NOT NULL,
+			       COALESCE(t.type IN ('stop', 'poweroff') AND t.status IN ('pending', 'running'), false)
+			FROM vds v
+			LEFT JOIN LATERAL (
+				SELECT type, status
+				FROM tasks
+				WHERE vds_id = v.id AND type IN ('start', 'stop', 'restart', 'poweroff')
+				ORDER BY id DESC
+				LIMIT 1
+			) t ON true
+			WHERE v.id = $1 AND v.expires_at = $2 AND v.status IN ('running', 'stopped')
+			FOR UPDATE OF v`, id, expectedExpiresAt).Scan(&suspended, &stopping)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repository.ErrSubscriptionChanged
+			}
+			return err
+		}
+
+		query := `
+			UPDATE vds
+			SET expires_at = GREATEST(expires_at, now()) + make_interval(months => $2),
+			    suspended_at = NULL
+			WHERE id = $1
+			RETURNING ` + vdsColumns
+
+		vds, err = scanVDS(tx.QueryRow(ctx, query, id, months))
+		if err != nil {
+			return err
+		}
+
+		if reservationID != "" {
+			_, err = tx.Exec(ctx, `INSERT INTO vds_renewals (reservation_id, vds_id) VALUES ($1, $2)`, reservationID, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = insertVDSEvent(ctx, tx, &models.VDSEvent{
+			VDSID:   id,
+			Type:    models.VDSEventRenewed,
+			Message: fmt.Sprintf("subscription renewed until %s", vds.ExpiresAt.UTC().Format(time.RFC3339)),
+			Details: details,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !suspended || (vds.Status != models.VDSStatusStopped && !stopping) {
+			return nil
+		}
+
+		task, err = insertTask(ctx, tx, id, models.TaskTypeStart)
+		if err != nil {
+			return err
+		}
+		return insertVDSEvent(ctx, tx, &models.VDSEvent{
+			VDSID:   id,
+			Type:    models.VDSEventResumed,
+			Message: "vds is being started after the subscription was paid",
+			Details: map[string]string{"task_id": fmt.Sprint(task.ID)},
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrSubscriptionChanged) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return vds, task, nil
+}
+
+// SetAutoRenewToken сохраняет зашифрованную авторизацию автопродления и сбрасывает отметку
+// последней попытки. nil отключает автопродление. Удаляемую VDS изменить нельзя - ErrInvalidVDSState
+func (r *VDSRepository) SetAutoRenewToken(ctx context.Context, id int32, token []byte) (*models.VDS, error) {
+	const op = "repository.postgres.VDSRepository.SetAutoRenewToken"
+
+	query := `
+		UPDATE vds
+		SET auto_renew_token = $2, renew_attempted_at = NULL
+		WHERE id = $1 AND status NOT IN ('deleting', 'deleted')
+		RETURNING ` + vdsColumns
+
+	vds, err := scanVDS(r.db.Pool.QueryRow(ctx, query, id, token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrInvalidVDSState
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return vds, nil
+}
+
+// GetAutoRenewToken возвращает зашифрованную авторизацию автопродления, nil - автопродление выключено
+func (r *VDSRepository) GetAutoRenewToken(ctx context.Context, id int32) ([]byte, error) {
+	const op = "repository.postgres.VDSRepository.GetAutoRenewToken"
+
+	var token []byte
+	err := r.db.Pool.QueryRow(ctx, `SELECT auto_renew_token FROM vds WHERE id = $1`, id).Scan(&token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrVDSNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+// RotateAutoRenewToken заменяет авторизацию автопродления на token, если она всё ещё равна old.
+// false - владелец за это время отключил или заново включил автопродление
+func (r *VDSRepository) RotateAutoRenewToken(ctx context.Context, id int32, old, token []byte) (bool, error) {
+	const op = "repository.postgres.VDSRepository.RotateAutoRenewToken"
+
+	result, err := r.db.Pool.Exec(ctx,
+		`UPDATE vds SET auto_renew_token = $3 WHERE id = $1 AND auto_renew_token = $2`,
+		id, old, token,
+	)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// ClaimRenewable отмечает попытку автопродления и возвращает работающие и остановленные VDS
+// с включённым автопродлением, подписка которых истекает раньше renewBefore. VDS с попыткой
+// моложе retryAfter пропускаются, поэтому параллельные реплики не продлевают одну VDS дважды
+func (r *VDSRepository) ClaimRenewable(
+	ctx context.Context,
+	renewBefore time.Time,
+	retryAfter time.Duration,
+	limit int,
+) ([]*models.VDS, error) {
+	const op = "repository.postgres.VDSRepository.ClaimRenewable"
+
+	query := `
+		UPDATE vds
+		SET renew_attempted_at = now()
+		WHERE id IN (
+			SELECT id
+			FROM vds
+			WHERE status IN ('running', 'stopped')
+			  AND auto_renew_token IS NOT NULL
+			  AND expires_at < $1
+			  AND (renew_attempted_at IS NULL OR renew_attempted_at < now() - $2::interval)
+			ORDER BY expires_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + vdsColumns
+
+	list, err := r.listVDS(ctx, query, renewBefore, retryAfter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return list, nil
+}
+
+// ListSuspendable возвращает не приостановленные работающие и остановленные VDS,
+// подписка которых истекла раньше expiredBefore
+func (r *VDSRepository) ListSuspendable(ctx context.Context, expiredBefore time.Time, limit int) ([]*models.VDS, error) {
+	const op = "repository.postgres.VDSRepository.ListSuspendable"
+
+	query := `
+		SELECT ` + vdsColumns + `
+		FROM vds
+		WHERE status IN ('running', 'stopped')
+		  AND suspended_at IS NULL
+		  AND expires_at < $1
+		ORDER BY expires_at, id
+		LIMIT $2`
+
+	list, err := r.listVDS(ctx, query, expiredBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return list, nil
+}
+
+// Suspend приостанавливает VDS с подпиской, истёкшей раньше expiredBefore: работающая VDS ставится
+// на остановку, остановленная только помечается. Если по VDS есть незавершённая задача - ErrTaskInProgress,
+// если VDS уже продлена или приостановлена - ErrSubscriptionChanged
+func (r *VDSRepository) Suspend(ctx context.Context, id int32, expiredBefore time.Time, event *models.VDSEvent) (*models.Task, error) {
+	const op = "repository.postgres.VDSRepository.Suspend"
+
+	var task *models.Task
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var status models.VDSStatus
+		err := tx.QueryRow(ctx, `
+			SELECT status
+			FROM vds
+			WHERE id = $1 AND expires_at < $2 AND suspended_at IS NULL AND status IN ('running', 'stopped')
+			FOR UPDATE`, id, expiredBefore).Scan(&status)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repository.ErrSubscriptionChanged
+			}
+			return err
+		}
+
+		var pending int32
+		if err := tx.QueryRow(ctx, `SELECT get_pending_tasks_count($1)`, id).Scan(&pending); err != nil {
+			return err
+		}
+		if pending > 0 {
+			return repository.ErrTaskInProgress
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE vds SET suspended_at = now() WHERE id = $1`, id); err != nil {
+			return err
+		}
+
+		if status == models.VDSStatusRunning {
+			task, err = insertTask(ctx, tx, id, models.TaskTypeStop)
+			if err != nil {
+				return err
+			}
+		}
+
+		return insertVDSEvent(ctx, tx, event)
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrSubscriptionChanged) || errors.Is(err, repository.ErrTaskInProgress) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+// ListDeletable возвращает VDS, подписка которых истекла раньше expiredBefore и которые ещё не удаляются
+func (r *VDSRepository) ListDeletable(ctx context.Context, expiredBefore time.Time, limit int) ([]*models.VDS, error) {
+	const op = "repository.postgres.VDSRepository.ListDeletable"
+
+	query := `
+		SELECT ` + vdsColumns + `
+		FROM vds
+		WHERE status IN ('running', 'stopped', 'error')
+		  AND expires_at < $1
+		ORDER BY expires_at, id
+		LIMIT $2`
+
+	list, err := r.listVDS(ctx, query, expiredBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return list, nil
+}
+
+// QueueExpiredDeletion ставит на удаление VDS с подпиской, истёкшей раньше expiredBefore,
+// и записывает событие в той же транзакции. Если VDS уже продлена - ErrSubscriptionChanged
+func (r *VDSRepository) QueueExpiredDeletion(ctx context.Context, id int32, expiredBefore time.Time, event *models.VDSEvent) (*models.Task, error) {
+	const op = "repository.postgres.VDSRepository.QueueExpiredDeletion"
+
+	var task *models.Task
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var expired bool
+		err := tx.QueryRow(ctx, `SELECT expires_at < $2 FROM vds WHERE id = $1 FOR UPDATE`, id, expiredBefore).Scan(&expired)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repository.ErrVDSNotFound
+			}
+			return err
+		}
+		if !expired {
+			return repository.ErrSubscriptionChanged
+		}
+
+		task, err = markDeleting(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return insertVDSEvent(ctx, tx, event)
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) ||
+			errors.Is(err, repository.ErrTaskInProgress) ||
+			errors.Is(err, repository.ErrSubscriptionChanged) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+// AddEvent записывает событие жизненного цикла VDS
+func (r *VDSRepository) AddEvent(ctx context.Context, event *models.VDSEvent) error {
+	const op = "repository.postgres.VDSRepository.AddEvent"
+
+	if err := insertVDSEvent(ctx, r.db.Pool, event); err != nil {
+		if isPgError(err, pgErrForeignKeyViolation) {
+			return repository.ErrVDSNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+var vdsEventPage = pageSpec[*models.VDSEvent]{
+	query:    `SELECT ` + vdsEventColumns + ` FROM vds_events`,
+	idColumn: "id",
+	id:       func(e *models.VDSEvent) int64 { return e.ID },
+	scan:     scanVDSEvent,
+	keys: map[string]sortKey[*models.VDSEvent]{
+		"id":         {column: "id"},
+		"created_at": {column: "created_at", cast: "timestamptz", value: func(e *models.VDSEvent) string { return cursorTime(e.CreatedAt) }},
+	},
+	defaultOrder: "id",
+}
+
+// ListEvents возвращает страницу журнала жизненного цикла VDS, по умолчанию от старых событий к новым
+func (r *VDSRepository) ListEvents(ctx context.Context, filter models.VDSEventFilter, page models.PageRequest) ([]*models.VDSEvent, string, error) {
+	const op = "repository.postgres.VDSRepository.ListEvents"
+
+	q := &listQuery{}
+	q.where("vds_id = " + q.arg(filter.VDSID))
+	if filter.Type != nil {
+		q.where("type = " + q.arg(string(*filter.Type)))
+	}
+	q.whereTime("created_at", filter.Created)
+
+	events, next, err := listPage(ctx, r.db.Pool, vdsEventPage, q, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, next, nil
+}
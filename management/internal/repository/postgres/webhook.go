@@ -0,0 +1,375 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// webhookColumns список колонок подписки в порядке, ожидаемом scanWebhook
+const webhookColumns = `id, user_id, url, secret, event_types, is_active, created_at, updated_at`
+
+// webhookDeliveryColumns список колонок доставки в порядке, ожидаемом scanWebhookDelivery
+const webhookDeliveryColumns = `id, webhook_id, event_id, event_type, payload, status, attempts,
+	COALESCE(last_status_code, 0), COALESCE(last_error, ''), next_attempt_at, created_at, delivered_at`
+
+// WebhookRepository - репозиторий подписок на события и журнала их доставки
+type WebhookRepository struct {
+	db *Database
+}
+
+// NewWebhookRepository создает новый репозиторий подписок
+func NewWebhookRepository(db *Database) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// scanWebhook сканирует строку с колонками webhookColumns
+func scanWebhook(row interface{ Scan(dest ...any) error }) (*models.Webhook, error) {
+	var w models.Webhook
+	err := row.Scan(
+		&w.ID,
+		&w.UserID,
+		&w.URL,
+		&w.Secret,
+		&w.EventTypes,
+		&w.IsActive,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// scanWebhookDelivery сканирует строку с колонками webhookDeliveryColumns
+func scanWebhookDelivery(row interface{ Scan(dest ...any) error }) (*models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	err := row.Scan(
+		&d.ID,
+		&d.WebhookID,
+		&d.EventID,
+		&d.EventType,
+		&d.Payload,
+		&d.Status,
+		&d.Attempts,
+		&d.LastStatusCode,
+		&d.LastError,
+		&d.NextAttemptAt,
+		&d.CreatedAt,
+		&d.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Create создает подписку
+func (r *WebhookRepository) Create(ctx context.Context, req *models.CreateWebhookRequest) (*models.Webhook, error) {
+	const op = "repository.postgres.WebhookRepository.Create"
+
+	query := `
+		INSERT INTO webhooks (user_id, url, secret, event_types)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + webhookColumns
+
+	eventTypes := req.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	webhook, err := scanWebhook(r.db.Pool.QueryRow(ctx, query, req.UserID, req.URL, req.Secret, eventTypes))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+// GetByID получает подписку по ID
+func (r *WebhookRepository) GetByID(ctx context.Context, id int32) (*models.Webhook, error) {
+	const op = "repository.postgres.WebhookRepository.GetByID"
+
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	webhook, err := scanWebhook(r.db.Pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+// Update обновляет подписку
+func (r *WebhookRepository) Update(ctx context.Context, req *models.UpdateWebhookRequest) (*models.Webhook, error) {
+	const op = "repository.postgres.WebhookRepository.Update"
+
+	q := &listQuery{}
+	var setClauses []string
+	if req.URL != nil {
+		setClauses = append(setClauses, "url = "+q.arg(*req.URL))
+	}
+	if req.Secret != nil {
+		setClauses = append(setClauses, "secret = "+q.arg(*req.Secret))
+	}
+	if req.EventTypes != nil {
+		setClauses = append(setClauses, "event_types = "+q.arg(req.EventTypes))
+	}
+	if req.IsActive != nil {
+		setClauses = append(setClauses, "is_active = "+q.arg(*req.IsActive))
+	}
+
+	if len(setClauses) == 0 {
+		return r.GetByID(ctx, req.ID)
+	}
+	setClauses = append(setClauses, "updated_at = now()")
+
+	query := `UPDATE webhooks SET ` + strings.Join(setClauses, ", ") +
+		` WHERE id = ` + q.arg(req.ID) + ` RETURNING ` + webhookColumns
+
+	webhook, err := scanWebhook(r.db.Pool.QueryRow(ctx, query, q.args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+// Delete удаляет подписку вместе с журналом её доставок
+func (r *WebhookRepository) Delete(ctx context.Context, id int32) error {
+	const op = "repository.postgres.WebhookRepository.Delete"
+
+	result, err := r.db.Pool.Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return repository.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+var webhookPage = pageSpec[*models.Webhook]{
+	query:    `SELECT ` + webhookColumns + ` FROM webhooks`,
+	idColumn: "id",
+	id:       func(w *models.Webhook) int64 { return int64(w.ID) },
+	scan:     scanWebhook,
+	keys: map[string]sortKey[*models.Webhook]{
+		"id":         {column: "id"},
+		"created_at": {column: "created_at", cast: "timestamptz", value: func(w *models.Webhook) string { return cursorTime(w.CreatedAt) }},
+	},
+	defaultOrder: "id",
+}
+
+// List возвращает страницу списка подписок
+func (r *WebhookRepository) List(ctx context.Context, filter models.WebhookFilter, page models.PageRequest) ([]*models.Webhook, string, error) {
+	const op = "repository.postgres.WebhookRepository.List"
+
+	q := &listQuery{}
+	if filter.UserID != nil {
+		q.where("user_id = " + q.arg(*filter.UserID))
+	}
+	if filter.ActiveOnly {
+		q.where("is_active = true")
+	}
+
+	webhooks, next, err := listPage(ctx, r.db.Pool, webhookPage, q, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhooks, next, nil
+}
+
+// Fanout создаёт доставки события всем активным подпискам на его тип. Событие VDS или её задачи
+// (vdsID не nil) получают только подписки владельца VDS, события тарифов - все подписки.
+// Повторный вызов с тем же событием новых доставок не создаёт. Возвращает число созданных доставок
+func (r *WebhookRepository) Fanout(ctx context.Context, eventID int64, eventType string, payload []byte, vdsID *int32) (int64, error) {
+	const op = "repository.postgres.WebhookRepository.Fanout"
+
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+		SELECT w.id, $1, $2, $3
+		FROM webhooks w
+		WHERE w.is_active = true
+		  AND (cardinality(w.event_types) = 0 OR $2 = ANY(w.event_types))
+		  AND ($4::integer IS NULL OR w.user_id = (SELECT user_id FROM vds WHERE id = $4))
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, eventID, eventType, string(payload), vdsID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// ClaimDeliveries берёт в работу на время lease до limit доставок активных подписок,
+// готовых к отправке. Доставки, взятые другой репликой, пропускаются
+func (r *WebhookRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDispatch, error) {
+	const op = "repository.postgres.WebhookRepository.ClaimDeliveries"
+
+	query := `
+		UPDATE webhook_deliveries d
+		SET locked_until = now() + $2::interval
+		FROM webhooks w
+		WHERE w.id = d.webhook_id
+		  AND d.id IN (
+			SELECT pd.id
+			FROM webhook_deliveries pd
+			JOIN webhooks pw ON pw.id = pd.webhook_id
+			WHERE pd.status = 'pending'
+			  AND pd.next_attempt_at <= now()
+			  AND (pd.locked_until IS NULL OR pd.locked_until < now())
+			  AND pw.is_active = true
+			ORDER BY pd.next_attempt_at, pd.id
+			LIMIT $1
+			FOR UPDATE OF pd SKIP LOCKED
+		)
+		RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+		          COALESCE(d.last_status_code, 0), COALESCE(d.last_error, ''), d.next_attempt_at,
+		          d.created_at, d.delivered_at, w.url, w.secret
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var dispatches []*models.WebhookDispatch
+	for rows.Next() {
+		var d models.WebhookDelivery
+		dispatch := &models.WebhookDispatch{Delivery: &d}
+		err := rows.Scan(
+			&d.ID,
+			&d.WebhookID,
+			&d.EventID,
+			&d.EventType,
+			&d.Payload,
+			&d.Status,
+			&d.Attempts,
+			&d.LastStatusCode,
+			&d.LastError,
+			&d.NextAttemptAt,
+			&d.CreatedAt,
+			&d.DeliveredAt,
+			&dispatch.URL,
+			&dispatch.Secret,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		dispatches = append(dispatches, dispatch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dispatches, nil
+}
+
+// MarkDelivered отмечает доставку успешной
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id int64, statusCode int32) error {
+	const op = "repository.postgres.WebhookRepository.MarkDelivered"
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'delivered',
+		    attempts = attempts + 1,
+		    last_status_code = $2,
+		    last_error = NULL,
+		    delivered_at = now(),
+		    locked_until = NULL
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, id, statusCode); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkFailed записывает неудачную попытку доставки. statusCode 0 - ответа не было.
+// nextAttempt nil переводит доставку в dead: повторов больше не будет
+func (r *WebhookRepository) MarkFailed(ctx context.Context, id int64, statusCode int32, reason string, nextAttempt *time.Time) error {
+	const op = "repository.postgres.WebhookRepository.MarkFailed"
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = CASE WHEN $4::timestamptz IS NULL THEN 'dead' ELSE 'pending' END,
+		    attempts = attempts + 1,
+		    last_status_code = NULLIF($2, 0),
+		    last_error = $3,
+		    next_attempt_at = COALESCE($4, next_attempt_at),
+		    locked_until = NULL
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, id, statusCode, reason, nextAttempt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+var webhookDeliveryPage = pageSpec[*models.WebhookDelivery]{
+	query:    `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries`,
+	idColumn: "id",
+	id:       func(d *models.WebhookDelivery) int64 { return d.ID },
+	scan:     scanWebhookDelivery,
+	keys: map[string]sortKey[*models.WebhookDelivery]{
+		"id":         {column: "id"},
+		"created_at": {column: "created_at", cast: "timestamptz", value: func(d *models.WebhookDelivery) string { return cursorTime(d.CreatedAt) }},
+	},
+	defaultOrder: "created_at desc",
+}
+
+// ListDeliveries возвращает страницу журнала доставок подписки, по умолчанию начиная с последних
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter, page models.PageRequest) ([]*models.WebhookDelivery, string, error) {
+	const op = "repository.postgres.WebhookRepository.ListDeliveries"
+
+	q := &listQuery{}
+	q.where("webhook_id = " + q.arg(filter.WebhookID))
+	if filter.Status != nil {
+		q.where("status = " + q.arg(string(*filter.Status)))
+	}
+	q.whereTime("created_at", filter.Created)
+
+	deliveries, next, err := listPage(ctx, r.db.Pool, webhookDeliveryPage, q, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, next, nil
+}
+
+// DeleteDeliveries удаляет завершённые доставки (delivered и dead), созданные раньше before
+func (r *WebhookRepository) DeleteDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	const op = "repository.postgres.WebhookRepository.DeleteDeliveries"
+
+	result, err := r.db.Pool.Exec(ctx,
+		`DELETE FROM webhook_deliveries WHERE status <> 'pending' AND created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+	"github.com/makhtech/management/internal/service"
+)
+
+// Service - сервис журнала аудита
+type Service struct {
+	auditRepo repository.AuditRepository
+	log       *slog.Logger
+}
+
+// New создает новый сервис журнала аудита
+func New(auditRepo repository.AuditRepository, log *slog.Logger) *Service {
+	return &Service{
+		auditRepo: auditRepo,
+		log:       log,
+	}
+}
+
+// Record добавляет запись в журнал
+func (s *Service) Record(ctx context.Context, entry *models.AuditEntry) error {
+	const op = "service.audit.Record"
+
+	if err := s.auditRepo.Append(ctx, entry); err != nil {
+		s.log.Error("failed to write audit entry",
+			slog.String("op", op),
+			slog.String("method", entry.Method),
+			slog.String("request_id", entry.RequestID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// List возвращает страницу журнала аудита
+func (s *Service) List(ctx context.Context, filter models.AuditFilter, page models.PageRequest) ([]*models.AuditEntry, string, error) {
+	const op = "service.audit.List"
+
+	log := s.log.With(slog.String("op", op))
+	log.Debug("listing audit log")
+
+	if err := service.ValidatePage(page); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if err := service.ValidateTimeRange("created_at", filter.Created); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if filter.EntityID != nil && filter.EntityType == "" {
+		return nil, "", fmt.Errorf("%s: %w: entity_id requires entity_type", op, service.ErrInvalidArgument)
+	}
+
+	entries, next, err := s.auditRepo.List(ctx, filter, page)
+	if err != nil {
+		if service.IsInvalidPage(err) {
+			log.Warn("invalid page request", slog.String("error", err.Error()))
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		log.Error("failed to list audit log", slog.String("error", err.Error()))
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("audit log listed successfully", slog.Int("count", len(entries)))
+	return entries, next, nil
+}
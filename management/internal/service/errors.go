@@ -0,0 +1,8 @@
+package service
+
+import "errors"
+
+var (
+	// ErrInvalidArgument ошибка валидации входных данных сервиса
+	ErrInvalidArgument = errors.New("invalid argument")
+)
@@ -12,5 +12,65 @@ type PlanService interface {
 	GetByID(ctx context.Context, id int32) (*models.Plan, error)
 	Update(ctx context.Context, req *models.UpdatePlanRequest) (*models.Plan, error)
 	Delete(ctx context.Context, id int32) error
-	List(ctx context.Context, activeOnly bool) ([]*models.Plan, error)
+	Archive(ctx context.Context, id int32) (*models.Plan, error)
+	// List возвращает страницу планов и токен следующей страницы (пусто - страница последняя)
+	List(ctx context.Context, filter models.PlanFilter, page models.PageRequest) ([]*models.Plan, string, error)
+	ListArchived(ctx context.Context, page models.PageRequest) ([]*models.Plan, string, error)
+}
+
+// NodeService интерфейс для работы с Proxmox нодами
+type NodeService interface {
+	Create(ctx context.Context, req *models.CreateNodeRequest) (*models.Node, error)
+	GetByID(ctx context.Context, id int32) (*models.Node, error)
+	Update(ctx context.Context, req *models.UpdateNodeRequest) (*models.Node, error)
+	Delete(ctx context.Context, id int32) error
+	List(ctx context.Context, filter models.NodeFilter, page models.PageRequest) ([]*models.Node, string, error)
+	GetUtilization(ctx context.Context, id int32) (*models.NodeUtilization, error)
+}
+
+// VDSService интерфейс для управления жизненным циклом VDS
+type VDSService interface {
+	Create(ctx context.Context, req *models.CreateVDSRequest) (*models.VDS, error)
+	GetByID(ctx context.Context, id int32) (*models.VDS, error)
+	ListByUser(ctx context.Context, filter models.VDSFilter, page models.PageRequest) ([]*models.VDS, string, error)
+	UpdateStatus(ctx context.Context, id int32, status models.VDSStatus) (*models.VDS, error)
+	AllocateIP(ctx context.Context, req *models.AllocateIPRequest) (*models.VDS, error)
+	Delete(ctx context.Context, id int32) error
+	Power(ctx context.Context, id int32, action models.TaskType) (*models.Task, error)
+	Renew(ctx context.Context, req *models.RenewVDSRequest) (*models.VDS, error)
+	SetAutoRenew(ctx context.Context, req *models.SetAutoRenewRequest) (*models.VDS, error)
+	ListEvents(ctx context.Context, filter models.VDSEventFilter, page models.PageRequest) ([]*models.VDSEvent, string, error)
+}
+
+// TaskService интерфейс для работы с фоновыми задачами
+type TaskService interface {
+	Create(ctx context.Context, vdsID int32, taskType models.TaskType) (*models.Task, error)
+	GetByID(ctx context.Context, id int32) (*models.Task, error)
+	ListByVDS(ctx context.Context, filter models.TaskFilter, page models.PageRequest) ([]*models.Task, string, error)
+	UpdateStatus(ctx context.Context, req *models.UpdateTaskStatusRequest) (*models.Task, error)
+	GetPendingCount(ctx context.Context, vdsID int32) (int32, error)
+}
+
+// IPPoolService интерфейс для управления пулами IP адресов
+type IPPoolService interface {
+	Create(ctx context.Context, req *models.CreateIPPoolRequest) (*models.IPPool, error)
+	GetUsage(ctx context.Context, id int32) (*models.IPPoolUsage, error)
+	ListUsage(ctx context.Context, filter models.IPPoolFilter, page models.PageRequest) ([]*models.IPPoolUsage, string, error)
+	Delete(ctx context.Context, id int32) error
+}
+
+// AuditService интерфейс журнала аудита изменяющих вызовов
+type AuditService interface {
+	Record(ctx context.Context, entry *models.AuditEntry) error
+	List(ctx context.Context, filter models.AuditFilter, page models.PageRequest) ([]*models.AuditEntry, string, error)
+}
+
+// WebhookService интерфейс подписок на доменные события
+type WebhookService interface {
+	Create(ctx context.Context, req *models.CreateWebhookRequest) (*models.Webhook, error)
+	GetByID(ctx context.Context, id int32) (*models.Webhook, error)
+	Update(ctx context.Context, req *models.UpdateWebhookRequest) (*models.Webhook, error)
+	Delete(ctx context.Context, id int32) error
+	List(ctx context.Context, filter models.WebhookFilter, page models.PageRequest) ([]*models.Webhook, string, error)
+	ListDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter, page models.PageRequest) ([]*models.WebhookDelivery, string, error)
 }
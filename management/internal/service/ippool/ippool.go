@@ -0,0 +1,188 @@
+package ippool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+	"github.com/makhtech/management/internal/service"
+)
+
+// Service - сервис управления пулами IP адресов нод
+type Service struct {
+	poolRepo repository.IPPoolRepository
+	log      *slog.Logger
+}
+
+// New создает новый сервис пулов IP адресов
+func New(poolRepo repository.IPPoolRepository, log *slog.Logger) *Service {
+	return &Service{
+		poolRepo: poolRepo,
+		log:      log,
+	}
+}
+
+// Create создаёт пул адресов ноды
+func (s *Service) Create(ctx context.Context, req *models.CreateIPPoolRequest) (*models.IPPool, error) {
+	const op = "service.ippool.Create"
+
+	log := s.log.With(slog.String("op", op), slog.Int("node_id", int(req.NodeID)), slog.String("subnet", req.Subnet))
+	log.Info("creating new ip pool")
+
+	pool, err := parsePool(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrInvalidArgument, err.Error())
+	}
+
+	created, err := s.poolRepo.Create(ctx, pool)
+	if err != nil {
+		if errors.Is(err, repository.ErrNodeNotFound) {
+			log.Warn("node not found for ip pool")
+			return nil, repository.ErrNodeNotFound
+		}
+		if errors.Is(err, repository.ErrIPPoolOverlaps) {
+			log.Warn("ip pool overlaps an existing pool")
+			return nil, repository.ErrIPPoolOverlaps
+		}
+		log.Error("failed to create ip pool", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("ip pool created successfully", slog.Int("id", int(created.ID)))
+	return created, nil
+}
+
+// GetUsage возвращает статистику использования пула
+func (s *Service) GetUsage(ctx context.Context, id int32) (*models.IPPoolUsage, error) {
+	const op = "service.ippool.GetUsage"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)))
+	log.Debug("getting ip pool usage")
+
+	usage, err := s.poolRepo.GetUsage(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrIPPoolNotFound) {
+			log.Warn("ip pool not found")
+			return nil, repository.ErrIPPoolNotFound
+		}
+		log.Error("failed to get ip pool usage", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return usage, nil
+}
+
+// ListUsage возвращает страницу статистики использования пулов
+func (s *Service) ListUsage(ctx context.Context, filter models.IPPoolFilter, page models.PageRequest) ([]*models.IPPoolUsage, string, error) {
+	const op = "service.ippool.ListUsage"
+
+	log := s.log.With(slog.String("op", op))
+	log.Debug("listing ip pools usage")
+
+	if filter.NodeID != nil && *filter.NodeID <= 0 {
+		return nil, "", fmt.Errorf("%s: %w: invalid node id", op, service.ErrInvalidArgument)
+	}
+	if filter.Family != 0 && filter.Family != models.IPFamilyV4 && filter.Family != models.IPFamilyV6 {
+		return nil, "", fmt.Errorf("%s: %w: family must be 4 or 6", op, service.ErrInvalidArgument)
+	}
+	if err := service.ValidatePage(page); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	usage, next, err := s.poolRepo.ListUsage(ctx, filter, page)
+	if err != nil {
+		if service.IsInvalidPage(err) {
+			log.Warn("invalid page request", slog.String("error", err.Error()))
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		log.Error("failed to list ip pools usage", slog.String("error", err.Error()))
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("ip pools listed successfully", slog.Int("count", len(usage)))
+	return usage, next, nil
+}
+
+// Delete удаляет пул без выданных адресов
+func (s *Service) Delete(ctx context.Context, id int32) error {
+	const op = "service.ippool.Delete"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)))
+	log.Info("deleting ip pool")
+
+	err := s.poolRepo.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrIPPoolNotFound) {
+			log.Warn("ip pool not found for deletion")
+			return repository.ErrIPPoolNotFound
+		}
+		if errors.Is(err, repository.ErrIPPoolInUse) {
+			log.Warn("ip pool has allocated addresses, refusing to delete")
+			return repository.ErrIPPoolInUse
+		}
+		log.Error("failed to delete ip pool", slog.String("error", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("ip pool deleted successfully")
+	return nil
+}
+
+// parsePool разбирает и валидирует запрос на создание пула
+func parsePool(req *models.CreateIPPoolRequest) (*models.IPPool, error) {
+	if req.NodeID <= 0 {
+		return nil, errors.New("invalid node id")
+	}
+
+	subnet, err := netip.ParsePrefix(req.Subnet)
+	if err != nil || subnet.Addr().Is4In6() || subnet.Addr().Zone() != "" {
+		return nil, errors.New("invalid subnet")
+	}
+	if subnet != subnet.Masked() {
+		return nil, fmt.Errorf("subnet must be a network address, e.g. %s", subnet.Masked())
+	}
+
+	pool := &models.IPPool{
+		NodeID:   req.NodeID,
+		Family:   models.IPFamilyV6,
+		Subnet:   subnet,
+		IsActive: true,
+	}
+	if subnet.Addr().Is4() {
+		pool.Family = models.IPFamilyV4
+	}
+
+	pool.Gateway, err = netip.ParseAddr(req.Gateway)
+	if err != nil || !subnet.Contains(pool.Gateway) ||
+		pool.Gateway.Compare(pool.FirstUsable()) < 0 || pool.Gateway.Compare(pool.LastUsable()) > 0 {
+		return nil, errors.New("gateway must be a host address inside the subnet")
+	}
+
+	for _, r := range req.ReservedRanges {
+		start, err := netip.ParseAddr(r.Start)
+		if err != nil || !subnet.Contains(start) {
+			return nil, fmt.Errorf("reserved range start %q must be inside the subnet", r.Start)
+		}
+		end, err := netip.ParseAddr(r.End)
+		if err != nil || !subnet.Contains(end) {
+			return nil, fmt.Errorf("reserved range end %q must be inside the subnet", r.End)
+		}
+		if start.Compare(end) > 0 {
+			return nil, fmt.Errorf("reserved range %s-%s: start is after end", start, end)
+		}
+		if len(r.Description) > 255 {
+			return nil, errors.New("reserved range description must be at most 255 characters")
+		}
+		pool.ReservedRanges = append(pool.ReservedRanges, models.IPRange{
+			Start:       start,
+			End:         end,
+			Description: r.Description,
+		})
+	}
+
+	return pool, nil
+}
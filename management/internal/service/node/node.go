@@ -0,0 +1,253 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+	"github.com/makhtech/management/internal/service"
+)
+
+const (
+	// maxRegionLength ограничение колонки nodes.region
+	maxRegionLength = 50
+	maxLabelLength  = 63
+)
+
+// Service - сервис для работы с Proxmox нодами
+type Service struct {
+	nodeRepo repository.NodeRepository
+	log      *slog.Logger
+}
+
+// New создает новый сервис нод
+func New(nodeRepo repository.NodeRepository, log *slog.Logger) *Service {
+	return &Service{
+		nodeRepo: nodeRepo,
+		log:      log,
+	}
+}
+
+// Create регистрирует новую ноду
+func (s *Service) Create(ctx context.Context, req *models.CreateNodeRequest) (*models.Node, error) {
+	const op = "service.node.Create"
+
+	log := s.log.With(slog.String("op", op), slog.String("name", req.Name))
+	log.Info("creating new node")
+
+	// Валидация
+	if req.Name == "" {
+		return nil, fmt.Errorf("%s: %w: name is required", op, service.ErrInvalidArgument)
+	}
+	if err := validateAPIURL(req.APIURL); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if req.MaxCPU <= 0 {
+		return nil, fmt.Errorf("%s: %w: max_cpu must be positive", op, service.ErrInvalidArgument)
+	}
+	if req.MaxRAM <= 0 {
+		return nil, fmt.Errorf("%s: %w: max_ram must be positive", op, service.ErrInvalidArgument)
+	}
+	if req.MaxDisk <= 0 {
+		return nil, fmt.Errorf("%s: %w: max_disk must be positive", op, service.ErrInvalidArgument)
+	}
+	if err := validatePlacement(req.Region, req.Labels); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	node, err := s.nodeRepo.Create(ctx, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrNodeExists) {
+			log.Warn("node already exists")
+			return nil, repository.ErrNodeExists
+		}
+		log.Error("failed to create node", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("node created successfully", slog.Int("id", int(node.ID)))
+	return node, nil
+}
+
+// GetByID получает ноду по ID
+func (s *Service) GetByID(ctx context.Context, id int32) (*models.Node, error) {
+	const op = "service.node.GetByID"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)))
+	log.Debug("getting node by id")
+
+	node, err := s.nodeRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNodeNotFound) {
+			log.Warn("node not found")
+			return nil, repository.ErrNodeNotFound
+		}
+		log.Error("failed to get node", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return node, nil
+}
+
+// Update обновляет существующую ноду
+func (s *Service) Update(ctx context.Context, req *models.UpdateNodeRequest) (*models.Node, error) {
+	const op = "service.node.Update"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(req.ID)))
+	log.Info("updating node")
+
+	// Валидация ID
+	if req.ID <= 0 {
+		return nil, fmt.Errorf("%s: %w: invalid node id", op, service.ErrInvalidArgument)
+	}
+
+	// Валидация опциональных полей
+	if req.APIURL != nil {
+		if err := validateAPIURL(*req.APIURL); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if req.MaxCPU != nil && *req.MaxCPU <= 0 {
+		return nil, fmt.Errorf("%s: %w: max_cpu must be positive", op, service.ErrInvalidArgument)
+	}
+	if req.MaxRAM != nil && *req.MaxRAM <= 0 {
+		return nil, fmt.Errorf("%s: %w: max_ram must be positive", op, service.ErrInvalidArgument)
+	}
+	if req.MaxDisk != nil && *req.MaxDisk <= 0 {
+		return nil, fmt.Errorf("%s: %w: max_disk must be positive", op, service.ErrInvalidArgument)
+	}
+	if req.Region != nil {
+		if err := validatePlacement(*req.Region, nil); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err := validatePlacement("", req.Labels); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	node, err := s.nodeRepo.Update(ctx, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrNodeNotFound) {
+			log.Warn("node not found for update")
+			return nil, repository.ErrNodeNotFound
+		}
+		log.Error("failed to update node", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("node updated successfully")
+	return node, nil
+}
+
+// Delete удаляет ноду по ID. Нода, на которой есть VDS, не удаляется
+func (s *Service) Delete(ctx context.Context, id int32) error {
+	const op = "service.node.Delete"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)))
+	log.Info("deleting node")
+
+	if id <= 0 {
+		return fmt.Errorf("%s: %w: invalid node id", op, service.ErrInvalidArgument)
+	}
+
+	err := s.nodeRepo.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNodeNotFound) {
+			log.Warn("node not found for deletion")
+			return repository.ErrNodeNotFound
+		}
+		if errors.Is(err, repository.ErrNodeInUse) {
+			log.Warn("node is in use, refusing to delete")
+			return repository.ErrNodeInUse
+		}
+		log.Error("failed to delete node", slog.String("error", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("node deleted successfully")
+	return nil
+}
+
+// List возвращает страницу списка нод
+func (s *Service) List(ctx context.Context, filter models.NodeFilter, page models.PageRequest) ([]*models.Node, string, error) {
+	const op = "service.node.List"
+
+	log := s.log.With(slog.String("op", op), slog.Bool("activeOnly", filter.ActiveOnly))
+	log.Debug("listing nodes")
+
+	if err := service.ValidatePage(page); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if err := service.ValidateTimeRange("created_at", filter.Created); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	nodes, next, err := s.nodeRepo.List(ctx, filter, page)
+	if err != nil {
+		if service.IsInvalidPage(err) {
+			log.Warn("invalid page request", slog.String("error", err.Error()))
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		log.Error("failed to list nodes", slog.String("error", err.Error()))
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("nodes listed successfully", slog.Int("count", len(nodes)))
+	return nodes, next, nil
+}
+
+// GetUtilization возвращает статистику использования ресурсов ноды
+func (s *Service) GetUtilization(ctx context.Context, id int32) (*models.NodeUtilization, error) {
+	const op = "service.node.GetUtilization"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)))
+	log.Debug("getting node utilization")
+
+	utilization, err := s.nodeRepo.GetUtilization(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNodeNotFound) {
+			log.Warn("node not found or inactive")
+			return nil, repository.ErrNodeNotFound
+		}
+		log.Error("failed to get node utilization", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return utilization, nil
+}
+
+// validatePlacement проверяет регион и метки ноды, используемые при размещении VDS
+func validatePlacement(region string, labels map[string]string) error {
+	if len(region) > maxRegionLength {
+		return fmt.Errorf("%w: region must be at most %d characters", service.ErrInvalidArgument, maxRegionLength)
+	}
+
+	for key, value := range labels {
+		if key == "" || len(key) > maxLabelLength {
+			return fmt.Errorf("%w: label keys must be 1-%d characters", service.ErrInvalidArgument, maxLabelLength)
+		}
+		if len(value) > maxLabelLength {
+			return fmt.Errorf("%w: label %q value must be at most %d characters", service.ErrInvalidArgument, key, maxLabelLength)
+		}
+	}
+
+	return nil
+}
+
+// validateAPIURL проверяет, что api_url - абсолютный http(s) URL Proxmox API
+func validateAPIURL(apiURL string) error {
+	if apiURL == "" {
+		return fmt.Errorf("%w: api_url is required", service.ErrInvalidArgument)
+	}
+
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("%w: api_url must be an absolute http(s) url", service.ErrInvalidArgument)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// ValidatePage проверяет размер страницы. Токен и order_by проверяет репозиторий:
+// только он знает допустимые поля сортировки
+func ValidatePage(page models.PageRequest) error {
+	if page.Size < 0 || page.Size > models.MaxPageSize {
+		return fmt.Errorf("%w: page_size must be between 0 and %d", ErrInvalidArgument, models.MaxPageSize)
+	}
+	return nil
+}
+
+// ValidateTimeRange проверяет, что начало диапазона раньше конца
+func ValidateTimeRange(name string, r models.TimeRange) error {
+	if r.From != nil && r.To != nil && !r.From.Before(*r.To) {
+		return fmt.Errorf("%w: %s: start must be before end", ErrInvalidArgument, name)
+	}
+	return nil
+}
+
+// IsInvalidPage ошибка репозитория из-за неверного page_token или order_by в запросе клиента
+func IsInvalidPage(err error) bool {
+	return errors.Is(err, repository.ErrInvalidPageToken) || errors.Is(err, repository.ErrInvalidOrderBy)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/makhtech/management/internal/domain/models"
 	"github.com/makhtech/management/internal/repository"
+	"github.com/makhtech/management/internal/service"
 )
 
 // Service - сервис для работы с планами
@@ -33,19 +34,19 @@ func (s *Service) Create(ctx context.Context, req *models.CreatePlanRequest) (*m
 
 	// Валидация
 	if req.Name == "" {
-		return nil, fmt.Errorf("%s: name is required", op)
+		return nil, fmt.Errorf("%s: %w: name is required", op, service.ErrInvalidArgument)
 	}
 	if req.CPU <= 0 {
-		return nil, fmt.Errorf("%s: cpu must be positive", op)
+		return nil, fmt.Errorf("%s: %w: cpu must be positive", op, service.ErrInvalidArgument)
 	}
 	if req.RAMMB <= 0 {
-		return nil, fmt.Errorf("%s: ram_mb must be positive", op)
+		return nil, fmt.Errorf("%s: %w: ram_mb must be positive", op, service.ErrInvalidArgument)
 	}
 	if req.DiskGB <= 0 {
-		return nil, fmt.Errorf("%s: disk_gb must be positive", op)
+		return nil, fmt.Errorf("%s: %w: disk_gb must be positive", op, service.ErrInvalidArgument)
 	}
 	if req.PriceMonth < 0 {
-		return nil, fmt.Errorf("%s: price_month must be non-negative", op)
+		return nil, fmt.Errorf("%s: %w: price_month must be non-negative", op, service.ErrInvalidArgument)
 	}
 
 	plan, err := s.planRepo.Create(ctx, req)
@@ -87,24 +88,24 @@ func (s *Service) Update(ctx context.Context, req *models.UpdatePlanRequest) (*m
 
 	// Валидация ID
 	if req.ID <= 0 {
-		return nil, fmt.Errorf("%s: invalid plan id", op)
+		return nil, fmt.Errorf("%s: %w: invalid plan id", op, service.ErrInvalidArgument)
 	}
 
 	// Валидация опциональных полей
 	if req.Name != nil && *req.Name == "" {
-		return nil, fmt.Errorf("%s: name cannot be empty", op)
+		return nil, fmt.Errorf("%s: %w: name cannot be empty", op, service.ErrInvalidArgument)
 	}
 	if req.CPU != nil && *req.CPU <= 0 {
-		return nil, fmt.Errorf("%s: cpu must be positive", op)
+		return nil, fmt.Errorf("%s: %w: cpu must be positive", op, service.ErrInvalidArgument)
 	}
 	if req.RAMMB != nil && *req.RAMMB <= 0 {
-		return nil, fmt.Errorf("%s: ram_mb must be positive", op)
+		return nil, fmt.Errorf("%s: %w: ram_mb must be positive", op, service.ErrInvalidArgument)
 	}
 	if req.DiskGB != nil && *req.DiskGB <= 0 {
-		return nil, fmt.Errorf("%s: disk_gb must be positive", op)
+		return nil, fmt.Errorf("%s: %w: disk_gb must be positive", op, service.ErrInvalidArgument)
 	}
 	if req.PriceMonth != nil && *req.PriceMonth < 0 {
-		return nil, fmt.Errorf("%s: price_month must be non-negative", op)
+		return nil, fmt.Errorf("%s: %w: price_month must be non-negative", op, service.ErrInvalidArgument)
 	}
 
 	plan, err := s.planRepo.Update(ctx, req)
@@ -113,6 +114,10 @@ func (s *Service) Update(ctx context.Context, req *models.UpdatePlanRequest) (*m
 			log.Warn("plan not found for update")
 			return nil, repository.ErrPlanNotFound
 		}
+		if errors.Is(err, repository.ErrPlanArchived) {
+			log.Warn("archived plan cannot be updated")
+			return nil, repository.ErrPlanArchived
+		}
 		log.Error("failed to update plan", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -121,7 +126,7 @@ func (s *Service) Update(ctx context.Context, req *models.UpdatePlanRequest) (*m
 	return plan, nil
 }
 
-// Delete удаляет план по ID
+// Delete удаляет план по ID. План, по которому есть VDS, не удаляется - его нужно архивировать
 func (s *Service) Delete(ctx context.Context, id int32) error {
 	const op = "service.plan.Delete"
 
@@ -129,7 +134,7 @@ func (s *Service) Delete(ctx context.Context, id int32) error {
 	log.Info("deleting plan")
 
 	if id <= 0 {
-		return fmt.Errorf("%s: invalid plan id", op)
+		return fmt.Errorf("%s: %w: invalid plan id", op, service.ErrInvalidArgument)
 	}
 
 	err := s.planRepo.Delete(ctx, id)
@@ -138,6 +143,10 @@ func (s *Service) Delete(ctx context.Context, id int32) error {
 			log.Warn("plan not found for deletion")
 			return repository.ErrPlanNotFound
 		}
+		if errors.Is(err, repository.ErrPlanInUse) {
+			log.Warn("plan is used by vds, deletion refused")
+			return repository.ErrPlanInUse
+		}
 		log.Error("failed to delete plan", slog.String("error", err.Error()))
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -146,19 +155,83 @@ func (s *Service) Delete(ctx context.Context, id int32) error {
 	return nil
 }
 
-// List возвращает список планов
-func (s *Service) List(ctx context.Context, activeOnly bool) ([]*models.Plan, error) {
+// Archive снимает план с продажи, сохраняя его для истории биллинга и продления купленных VDS
+func (s *Service) Archive(ctx context.Context, id int32) (*models.Plan, error) {
+	const op = "service.plan.Archive"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)))
+	log.Info("archiving plan")
+
+	if id <= 0 {
+		return nil, fmt.Errorf("%s: %w: invalid plan id", op, service.ErrInvalidArgument)
+	}
+
+	plan, err := s.planRepo.Archive(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrPlanNotFound) {
+			log.Warn("plan not found for archiving")
+			return nil, repository.ErrPlanNotFound
+		}
+		log.Error("failed to archive plan", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("plan archived successfully")
+	return plan, nil
+}
+
+// List возвращает страницу списка планов
+func (s *Service) List(ctx context.Context, filter models.PlanFilter, page models.PageRequest) ([]*models.Plan, string, error) {
 	const op = "service.plan.List"
 
-	log := s.log.With(slog.String("op", op), slog.Bool("activeOnly", activeOnly))
+	log := s.log.With(slog.String("op", op), slog.Bool("activeOnly", filter.ActiveOnly))
 	log.Debug("listing plans")
 
-	plans, err := s.planRepo.List(ctx, activeOnly)
+	if err := service.ValidatePage(page); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if err := service.ValidateTimeRange("created_at", filter.Created); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, "", fmt.Errorf("%s: %w: min_price must not exceed max_price", op, service.ErrInvalidArgument)
+	}
+
+	plans, next, err := s.planRepo.List(ctx, filter, page)
 	if err != nil {
+		if service.IsInvalidPage(err) {
+			log.Warn("invalid page request", slog.String("error", err.Error()))
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
 		log.Error("failed to list plans", slog.String("error", err.Error()))
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 
 	log.Debug("plans listed successfully", slog.Int("count", len(plans)))
-	return plans, nil
+	return plans, next, nil
+}
+
+// ListArchived возвращает страницу архивных планов
+func (s *Service) ListArchived(ctx context.Context, page models.PageRequest) ([]*models.Plan, string, error) {
+	const op = "service.plan.ListArchived"
+
+	log := s.log.With(slog.String("op", op))
+	log.Debug("listing archived plans")
+
+	if err := service.ValidatePage(page); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	plans, next, err := s.planRepo.ListArchived(ctx, page)
+	if err != nil {
+		if service.IsInvalidPage(err) {
+			log.Warn("invalid page request", slog.String("error", err.Error()))
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		log.Error("failed to list archived plans", slog.String("error", err.Error()))
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("archived plans listed successfully", slog.Int("count", len(plans)))
+	return plans, next, nil
 }
@@ -0,0 +1,182 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+	"github.com/makhtech/management/internal/service"
+)
+
+// Service - сервис для работы с фоновыми задачами
+type Service struct {
+	taskRepo repository.TaskRepository
+	log      *slog.Logger
+}
+
+// New создает новый сервис задач
+func New(taskRepo repository.TaskRepository, log *slog.Logger) *Service {
+	return &Service{
+		taskRepo: taskRepo,
+		log:      log,
+	}
+}
+
+// Create ставит задачу питания VDS в очередь. Задачи create и delete ставятся только через VDS
+func (s *Service) Create(ctx context.Context, vdsID int32, taskType models.TaskType) (*models.Task, error) {
+	const op = "service.task.Create"
+
+	log := s.log.With(slog.String("op", op), slog.Int("vds_id", int(vdsID)), slog.String("type", string(taskType)))
+	log.Info("creating new task")
+
+	if vdsID <= 0 {
+		return nil, fmt.Errorf("%s: %w: invalid vds id", op, service.ErrInvalidArgument)
+	}
+	if !taskType.IsValid() {
+		return nil, fmt.Errorf("%s: %w: unknown task type", op, service.ErrInvalidArgument)
+	}
+
+	task, err := s.taskRepo.Create(ctx, vdsID, taskType)
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) {
+			log.Warn("vds not found for task")
+			return nil, repository.ErrVDSNotFound
+		}
+		if errors.Is(err, repository.ErrTaskTypeNotAllowed) {
+			log.Warn("task type cannot be queued directly")
+			return nil, repository.ErrTaskTypeNotAllowed
+		}
+		if errors.Is(err, repository.ErrTaskInProgress) {
+			log.Warn("vds has a task in progress, refusing to queue task")
+			return nil, repository.ErrTaskInProgress
+		}
+		if errors.Is(err, repository.ErrInvalidVDSState) {
+			log.Warn("vds status does not allow task")
+			return nil, repository.ErrInvalidVDSState
+		}
+		if errors.Is(err, repository.ErrVDSSuspended) {
+			log.Warn("vds is suspended for non-payment, refusing to start")
+			return nil, repository.ErrVDSSuspended
+		}
+		log.Error("failed to create task", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("task created successfully", slog.Int("id", int(task.ID)))
+	return task, nil
+}
+
+// GetByID получает задачу по ID
+func (s *Service) GetByID(ctx context.Context, id int32) (*models.Task, error) {
+	const op = "service.task.GetByID"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)))
+	log.Debug("getting task by id")
+
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			log.Warn("task not found")
+			return nil, repository.ErrTaskNotFound
+		}
+		log.Error("failed to get task", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return task, nil
+}
+
+// ListByVDS возвращает страницу задач VDS
+func (s *Service) ListByVDS(ctx context.Context, filter models.TaskFilter, page models.PageRequest) ([]*models.Task, string, error) {
+	const op = "service.task.ListByVDS"
+
+	log := s.log.With(slog.String("op", op), slog.Int("vds_id", int(filter.VDSID)))
+	log.Debug("listing tasks by vds")
+
+	if filter.VDSID <= 0 {
+		return nil, "", fmt.Errorf("%s: %w: invalid vds id", op, service.ErrInvalidArgument)
+	}
+	if filter.Status != nil && *filter.Status == "" {
+		return nil, "", fmt.Errorf("%s: %w: unknown status", op, service.ErrInvalidArgument)
+	}
+	if filter.Type != nil && *filter.Type == "" {
+		return nil, "", fmt.Errorf("%s: %w: unknown task type", op, service.ErrInvalidArgument)
+	}
+	if err := service.ValidatePage(page); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if err := service.ValidateTimeRange("created_at", filter.Created); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	tasks, next, err := s.taskRepo.ListByVDS(ctx, filter, page)
+	if err != nil {
+		if service.IsInvalidPage(err) {
+			log.Warn("invalid page request", slog.String("error", err.Error()))
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		log.Error("failed to list tasks", slog.String("error", err.Error()))
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("tasks listed successfully", slog.Int("count", len(tasks)))
+	return tasks, next, nil
+}
+
+// UpdateStatus меняет статус задачи с проверкой допустимости перехода
+func (s *Service) UpdateStatus(ctx context.Context, req *models.UpdateTaskStatusRequest) (*models.Task, error) {
+	const op = "service.task.UpdateStatus"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(req.ID)), slog.String("status", string(req.Status)))
+	log.Info("updating task status")
+
+	if req.ID <= 0 {
+		return nil, fmt.Errorf("%s: %w: invalid task id", op, service.ErrInvalidArgument)
+	}
+	if !req.Status.IsValid() {
+		return nil, fmt.Errorf("%s: %w: unknown task status", op, service.ErrInvalidArgument)
+	}
+	if req.Error != nil && req.Status != models.TaskStatusError {
+		return nil, fmt.Errorf("%s: %w: error message is only allowed for status error", op, service.ErrInvalidArgument)
+	}
+
+	task, err := s.taskRepo.UpdateStatus(ctx, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			log.Warn("task not found for status update")
+			return nil, repository.ErrTaskNotFound
+		}
+		if errors.Is(err, repository.ErrInvalidTaskTransition) {
+			log.Warn("invalid task status transition", slog.String("error", err.Error()))
+			return nil, err
+		}
+		log.Error("failed to update task status", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("task status updated successfully")
+	return task, nil
+}
+
+// GetPendingCount возвращает количество незавершённых задач VDS
+func (s *Service) GetPendingCount(ctx context.Context, vdsID int32) (int32, error) {
+	const op = "service.task.GetPendingCount"
+
+	log := s.log.With(slog.String("op", op), slog.Int("vds_id", int(vdsID)))
+	log.Debug("getting pending tasks count")
+
+	if vdsID <= 0 {
+		return 0, fmt.Errorf("%s: %w: invalid vds id", op, service.ErrInvalidArgument)
+	}
+
+	count, err := s.taskRepo.GetPendingCount(ctx, vdsID)
+	if err != nil {
+		log.Error("failed to get pending tasks count", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,691 @@
+package vds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	"github.com/makhtech/management/internal/billing"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/placement"
+	"github.com/makhtech/management/internal/repository"
+	"github.com/makhtech/management/internal/service"
+)
+
+// defaultSubscriptionPeriod срок подписки, если expires_at не передан.
+// Для платных тарифов срок всегда такой, он же оплачивается при создании
+const defaultSubscriptionPeriod = 1 // месяц
+
+// maxPlacementAttempts сколько нод-кандидатов пробуется при автоматическом размещении.
+// Кандидат может отпасть, если параллельный запрос занял его ресурсы или адреса
+const maxPlacementAttempts = 3
+
+// Service - сервис управления жизненным циклом VDS
+type Service struct {
+	vdsRepo   repository.VDSRepository
+	planRepo  repository.PlanRepository
+	nodeRepo  repository.NodeRepository
+	scheduler *placement.Scheduler
+	// billing nil - VDS создаются без оплаты
+	billing *billing.Billing
+	log     *slog.Logger
+}
+
+// New создает новый сервис VDS
+func New(
+	vdsRepo repository.VDSRepository,
+	planRepo repository.PlanRepository,
+	nodeRepo repository.NodeRepository,
+	scheduler *placement.Scheduler,
+	billing *billing.Billing,
+	log *slog.Logger,
+) *Service {
+	return &Service{
+		vdsRepo:   vdsRepo,
+		planRepo:  planRepo,
+		nodeRepo:  nodeRepo,
+		scheduler: scheduler,
+		billing:   billing,
+		log:       log,
+	}
+}
+
+// Create создаёт VDS в статусе creating и ставит задачу create на её развёртывание.
+// Без node_id нода выбирается планировщиком размещения. Месячная цена тарифа резервируется
+// на балансе пользователя до создания VDS и списывается по итогу задачи create
+func (s *Service) Create(ctx context.Context, req *models.CreateVDSRequest) (*models.VDS, error) {
+	const op = "service.vds.Create"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("user_id", int(req.UserID)),
+		slog.Int("plan_id", int(req.PlanID)),
+		slog.Int("node_id", int(req.NodeID)),
+	)
+	log.Info("creating new vds")
+
+	// Валидация
+	if req.UserID <= 0 {
+		return nil, fmt.Errorf("%s: %w: invalid user id", op, service.ErrInvalidArgument)
+	}
+	if req.PlanID <= 0 {
+		return nil, fmt.Errorf("%s: %w: invalid plan id", op, service.ErrInvalidArgument)
+	}
+	if req.NodeID < 0 {
+		return nil, fmt.Errorf("%s: %w: invalid node id", op, service.ErrInvalidArgument)
+	}
+	if !req.ExpiresAt.IsZero() && !req.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%s: %w: expires_at must be in the future", op, service.ErrInvalidArgument)
+	}
+
+	plan, err := s.planRepo.GetByID(ctx, req.PlanID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPlanNotFound) {
+			return nil, repository.ErrPlanNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !plan.IsActive {
+		return nil, fmt.Errorf("%s: %w: plan is not available for purchase", op, service.ErrInvalidArgument)
+	}
+
+	if err := s.reserve(ctx, req, plan); err != nil {
+		if errors.Is(err, repository.ErrInsufficientFunds) {
+			log.Warn("insufficient funds for vds")
+			return nil, repository.ErrInsufficientFunds
+		}
+		if errors.Is(err, service.ErrInvalidArgument) {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		log.Error("failed to reserve payment for vds", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if req.ExpiresAt.IsZero() {
+		req.ExpiresAt = time.Now().AddDate(0, defaultSubscriptionPeriod, 0)
+	}
+
+	var vds *models.VDS
+	var task *models.Task
+	if req.NodeID == 0 {
+		vds, task, err = s.createPlaced(ctx, req, plan, log)
+	} else {
+		vds, task, err = s.createOnNode(ctx, req)
+	}
+	if err != nil && req.ReservationID != "" {
+		if errors.Is(err, repository.ErrReservationUsed) {
+			// Повтор запроса с тем же request_id: VDS по этому резервированию уже создана
+			log.Info("vds for reservation already exists", slog.String("reservation_id", req.ReservationID))
+			return s.vdsRepo.GetByReservationID(ctx, req.ReservationID)
+		}
+		s.billing.Release(req.ReservationID)
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidArgument) {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if errors.Is(err, repository.ErrPlanNotFound) || errors.Is(err, repository.ErrNodeNotFound) {
+			return nil, err
+		}
+		if errors.Is(err, repository.ErrInsufficientResources) {
+			log.Warn("not enough free resources for vds", slog.String("error", err.Error()))
+			return nil, repository.ErrInsufficientResources
+		}
+		if errors.Is(err, repository.ErrNoFreeIP) {
+			log.Warn("no free ip addresses on node", slog.String("error", err.Error()))
+			return nil, err
+		}
+		log.Error("failed to create vds", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("vds created successfully",
+		slog.Int("id", int(vds.ID)),
+		slog.Int("placed_node_id", int(vds.NodeID)),
+		slog.Int("proxmox_vm_id", int(vds.ProxmoxVMID)),
+		slog.Int("task_id", int(task.ID)),
+	)
+	return vds, nil
+}
+
+// reserve резервирует месячную цену тарифа на балансе пользователя и записывает резервирование в req.
+// Оплачивается ровно один период подписки и только с баланса владельца VDS
+func (s *Service) reserve(ctx context.Context, req *models.CreateVDSRequest, plan *models.Plan) error {
+	amount := billing.AmountFromPrice(plan.PriceMonth)
+	if s.billing == nil || amount == 0 {
+		return nil
+	}
+	if !req.ExpiresAt.IsZero() {
+		return fmt.Errorf("%w: expires_at cannot be set for a paid plan", service.ErrInvalidArgument)
+	}
+	if err := checkPayer(req.AccessToken, req.PayerID, req.UserID); err != nil {
+		return err
+	}
+	// Ключ резервирования выводится из request_id: без него повтор запроса зарезервировал бы оплату ещё раз
+	if req.RequestID == "" {
+		return fmt.Errorf("%w: request_id or idempotency-key is required for a paid plan", service.ErrInvalidArgument)
+	}
+
+	reservationID, err := s.billing.Reserve(ctx, req.AccessToken, amount, billing.IdempotencyKey(req),
+		fmt.Sprintf("VDS plan %q, 1 month", plan.Name))
+	if err != nil {
+		return err
+	}
+
+	req.ReservationID = reservationID
+	req.BillingAmount = amount
+	return nil
+}
+
+// checkPayer проверяет, что оплату резервирует сам владелец VDS: SSO резервирует средства
+// на балансе владельца токена, а не пользователя, для которого создаётся или продлевается VDS
+func checkPayer(accessToken string, payerID, ownerID int32) error {
+	if accessToken == "" {
+		return fmt.Errorf("%w: user access token is required to pay for vds", service.ErrInvalidArgument)
+	}
+	if payerID != ownerID {
+		return fmt.Errorf("%w: paid vds can only be paid for by its owner", service.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// createOnNode создаёт VDS на явно указанной ноде
+func (s *Service) createOnNode(ctx context.Context, req *models.CreateVDSRequest) (*models.VDS, *models.Task, error) {
+	node, err := s.nodeRepo.GetByID(ctx, req.NodeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !node.IsActive {
+		return nil, nil, fmt.Errorf("%w: node is not active", service.ErrInvalidArgument)
+	}
+
+	return s.vdsRepo.Create(ctx, req)
+}
+
+// createPlaced выбирает ноду планировщиком и создаёт на ней VDS. Если кандидат отпал
+// между выбором и созданием (кончились ресурсы или адреса), пробуется следующий
+func (s *Service) createPlaced(
+	ctx context.Context,
+	req *models.CreateVDSRequest,
+	plan *models.Plan,
+	log *slog.Logger,
+) (*models.VDS, *models.Task, error) {
+	candidates, err := s.scheduler.Candidates(ctx, placement.RequirementsFromPlan(plan), placement.Constraints{
+		Region: req.Region,
+		Labels: req.NodeLabels,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, node := range candidates {
+		if i == maxPlacementAttempts {
+			break
+		}
+
+		placed := *req
+		placed.NodeID = node.NodeID
+
+		vds, task, err := s.vdsRepo.Create(ctx, &placed)
+		if err == nil {
+			return vds, task, nil
+		}
+		if !errors.Is(err, repository.ErrInsufficientResources) && !errors.Is(err, repository.ErrNoFreeIP) {
+			return nil, nil, err
+		}
+
+		log.Warn("placement candidate rejected, trying next",
+			slog.Int("candidate_node_id", int(node.NodeID)),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return nil, nil, repository.ErrInsufficientResources
+}
+
+// GetByID получает VDS по ID
+func (s *Service) GetByID(ctx context.Context, id int32) (*models.VDS, error) {
+	const op = "service.vds.GetByID"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)))
+	log.Debug("getting vds by id")
+
+	vds, err := s.vdsRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) {
+			log.Warn("vds not found")
+			return nil, repository.ErrVDSNotFound
+		}
+		log.Error("failed to get vds", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return vds, nil
+}
+
+// ListByUser возвращает страницу VDS пользователя
+func (s *Service) ListByUser(ctx context.Context, filter models.VDSFilter, page models.PageRequest) ([]*models.VDS, string, error) {
+	const op = "service.vds.ListByUser"
+
+	log := s.log.With(slog.String("op", op), slog.Int("user_id", int(filter.UserID)))
+	log.Debug("listing vds by user")
+
+	if filter.UserID <= 0 {
+		return nil, "", fmt.Errorf("%s: %w: invalid user id", op, service.ErrInvalidArgument)
+	}
+	if filter.Status != nil && *filter.Status == "" {
+		return nil, "", fmt.Errorf("%s: %w: unknown status", op, service.ErrInvalidArgument)
+	}
+	if err := service.ValidatePage(page); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if err := service.ValidateTimeRange("created_at", filter.Created); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	list, next, err := s.vdsRepo.ListByUser(ctx, filter, page)
+	if err != nil {
+		if service.IsInvalidPage(err) {
+			log.Warn("invalid page request", slog.String("error", err.Error()))
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		log.Error("failed to list vds", slog.String("error", err.Error()))
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("vds listed successfully", slog.Int("count", len(list)))
+	return list, next, nil
+}
+
+// UpdateStatus обновляет статус VDS
+func (s *Service) UpdateStatus(ctx context.Context, id int32, status models.VDSStatus) (*models.VDS, error) {
+	const op = "service.vds.UpdateStatus"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)), slog.String("status", string(status)))
+	log.Info("updating vds status")
+
+	if id <= 0 {
+		return nil, fmt.Errorf("%s: %w: invalid vds id", op, service.ErrInvalidArgument)
+	}
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%s: %w: unknown vds status", op, service.ErrInvalidArgument)
+	}
+	if status == models.VDSStatusDeleted {
+		return nil, fmt.Errorf("%s: %w: use DeleteVDS to delete vds", op, service.ErrInvalidArgument)
+	}
+
+	vds, err := s.vdsRepo.UpdateStatus(ctx, id, status)
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) {
+			log.Warn("vds not found for status update")
+			return nil, repository.ErrVDSNotFound
+		}
+		log.Error("failed to update vds status", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("vds status updated successfully")
+	return vds, nil
+}
+
+// AllocateIP назначает VDS IP адреса из пулов её ноды.
+// Если адреса не переданы, выдаются следующие свободные для недостающих семейств
+func (s *Service) AllocateIP(ctx context.Context, req *models.AllocateIPRequest) (*models.VDS, error) {
+	const op = "service.vds.AllocateIP"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(req.VDSID)))
+	log.Info("allocating ip for vds")
+
+	if req.VDSID <= 0 {
+		return nil, fmt.Errorf("%s: %w: invalid vds id", op, service.ErrInvalidArgument)
+	}
+	if req.IPv4 != "" {
+		addr, err := netip.ParseAddr(req.IPv4)
+		if err != nil || !addr.Is4() {
+			return nil, fmt.Errorf("%s: %w: invalid ipv4 address", op, service.ErrInvalidArgument)
+		}
+	}
+	if req.IPv6 != "" {
+		addr, err := netip.ParseAddr(req.IPv6)
+		if err != nil || !addr.Is6() || addr.Is4In6() {
+			return nil, fmt.Errorf("%s: %w: invalid ipv6 address", op, service.ErrInvalidArgument)
+		}
+	}
+
+	vds, err := s.vdsRepo.AllocateIP(ctx, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) {
+			log.Warn("vds not found for ip allocation")
+			return nil, repository.ErrVDSNotFound
+		}
+		if errors.Is(err, repository.ErrNoFreeIP) || errors.Is(err, repository.ErrIPUnavailable) {
+			log.Warn("ip allocation failed", slog.String("error", err.Error()))
+			return nil, err
+		}
+		log.Error("failed to allocate ip", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("ip allocated successfully")
+	return vds, nil
+}
+
+// Delete переводит VDS в статус deleting и ставит задачу delete.
+// Фактическое удаление VM выполняет обработчик задачи
+func (s *Service) Delete(ctx context.Context, id int32) error {
+	const op = "service.vds.Delete"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)))
+	log.Info("deleting vds")
+
+	if id <= 0 {
+		return fmt.Errorf("%s: %w: invalid vds id", op, service.ErrInvalidArgument)
+	}
+
+	task, err := s.vdsRepo.MarkDeleting(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) {
+			log.Warn("vds not found for deletion")
+			return repository.ErrVDSNotFound
+		}
+		if errors.Is(err, repository.ErrTaskInProgress) {
+			log.Warn("vds has a task in progress, refusing to delete")
+			return repository.ErrTaskInProgress
+		}
+		log.Error("failed to delete vds", slog.String("error", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("vds deletion queued", slog.Int("task_id", int(task.ID)))
+	return nil
+}
+
+// Power ставит задачу питания VDS: start, stop, restart или poweroff.
+// Статус VDS должен допускать операцию, а других незавершённых задач по VDS быть не должно
+func (s *Service) Power(ctx context.Context, id int32, action models.TaskType) (*models.Task, error) {
+	const op = "service.vds.Power"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)), slog.String("action", string(action)))
+	log.Info("queuing vds power action")
+
+	if id <= 0 {
+		return nil, fmt.Errorf("%s: %w: invalid vds id", op, service.ErrInvalidArgument)
+	}
+	if !action.IsPowerAction() {
+		return nil, fmt.Errorf("%s: %w: %q is not a power action", op, service.ErrInvalidArgument, action)
+	}
+
+	task, err := s.vdsRepo.QueuePower(ctx, id, action)
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) {
+			log.Warn("vds not found for power action")
+			return nil, repository.ErrVDSNotFound
+		}
+		if errors.Is(err, repository.ErrTaskInProgress) {
+			log.Warn("vds has a task in progress, refusing power action")
+			return nil, repository.ErrTaskInProgress
+		}
+		if errors.Is(err, repository.ErrInvalidVDSState) {
+			log.Warn("vds status does not allow power action")
+			return nil, repository.ErrInvalidVDSState
+		}
+		if errors.Is(err, repository.ErrVDSSuspended) {
+			log.Warn("vds is suspended for non-payment, refusing to start")
+			return nil, repository.ErrVDSSuspended
+		}
+		log.Error("failed to queue vds power action", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("vds power action queued", slog.Int("task_id", int(task.ID)))
+	return task, nil
+}
+
+// Renew продлевает подписку VDS на один период от max(expires_at, now) и снимает приостановку за неоплату.
+// Цена тарифа резервируется на балансе владельца и списывается после продления
+func (s *Service) Renew(ctx context.Context, req *models.RenewVDSRequest) (*models.VDS, error) {
+	const op = "service.vds.Renew"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(req.VDSID)))
+	log.Info("renewing vds subscription")
+
+	if req.VDSID <= 0 {
+		return nil, fmt.Errorf("%s: %w: invalid vds id", op, service.ErrInvalidArgument)
+	}
+
+	vds, err := s.vdsRepo.GetByID(ctx, req.VDSID)
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) {
+			log.Warn("vds not found for renewal")
+			return nil, repository.ErrVDSNotFound
+		}
+		log.Error("failed to get vds", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if vds.Status != models.VDSStatusRunning && vds.Status != models.VDSStatusStopped {
+		log.Warn("vds status does not allow renewal", slog.String("status", string(vds.Status)))
+		return nil, repository.ErrInvalidVDSState
+	}
+
+	plan, err := s.planRepo.GetByID(ctx, vds.PlanID)
+	if err != nil {
+		log.Error("failed to get plan for renewal", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	amount := billing.AmountFromPrice(plan.PriceMonth)
+	details := map[string]string{
+		"plan_id":    fmt.Sprint(plan.ID),
+		"amount":     fmt.Sprint(amount),
+		"expires_at": vds.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+
+	var reservationID string
+	if s.billing != nil && amount > 0 {
+		if err := checkPayer(req.AccessToken, req.PayerID, vds.UserID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		// Ключ привязан к текущему сроку подписки: повтор запроса до продления получает то же резервирование
+		key := fmt.Sprintf("vds-renew-%d-%d", vds.ID, vds.ExpiresAt.Unix())
+		reservationID, err = s.billing.Reserve(ctx, req.AccessToken, amount, key,
+			fmt.Sprintf("VDS #%d renewal, plan %q, 1 month", vds.ID, plan.Name))
+		if err != nil {
+			if errors.Is(err, repository.ErrInsufficientFunds) {
+				log.Warn("insufficient funds to renew vds")
+				s.addEvent(ctx, log, &models.VDSEvent{
+					VDSID:   vds.ID,
+					Type:    models.VDSEventRenewalFailed,
+					Message: "insufficient funds to renew the subscription",
+					Details: details,
+				})
+				return nil, repository.ErrInsufficientFunds
+			}
+			log.Error("failed to reserve payment for renewal", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		details["reservation_id"] = reservationID
+	}
+
+	renewed, task, err := s.vdsRepo.Renew(ctx, vds.ID, vds.ExpiresAt, defaultSubscriptionPeriod, reservationID, details)
+	if err != nil {
+		if reservationID != "" {
+			s.releaseRenewal(ctx, log, vds, reservationID)
+		}
+		if errors.Is(err, repository.ErrSubscriptionChanged) {
+			log.Warn("vds subscription changed during renewal")
+			return nil, repository.ErrSubscriptionChanged
+		}
+		log.Error("failed to renew subscription", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if reservationID != "" {
+		s.billing.CommitRenewal(vds.ID, reservationID)
+	}
+
+	log = log.With(slog.Time("renewed_until", renewed.ExpiresAt))
+	if task != nil {
+		log = log.With(slog.Int("start_task_id", int(task.ID)))
+	}
+	log.Info("vds subscription renewed")
+	return renewed, nil
+}
+
+// releaseRenewal отменяет резервирование, которое не попало в продление. Если подписку
+// уже продлил параллельный запрос с тем же ключом, резервирование принадлежит ему и не отменяется
+func (s *Service) releaseRenewal(ctx context.Context, log *slog.Logger, vds *models.VDS, reservationID string) {
+	current, err := s.vdsRepo.GetByID(context.WithoutCancel(ctx), vds.ID)
+	if err == nil && current.ExpiresAt.After(vds.ExpiresAt) {
+		log.Debug("subscription already renewed by a concurrent request")
+		return
+	}
+	if err != nil && !errors.Is(err, repository.ErrVDSNotFound) {
+		log.Error("failed to reload vds after renewal failure", slog.String("error", err.Error()))
+		return
+	}
+
+	s.billing.Release(reservationID)
+}
+
+// SetAutoRenew включает или отключает автопродление подписки VDS. Для включения refresh token
+// владельца обменивается в SSO, а новый токен хранится зашифрованным: по нему планировщик
+// резервирует оплату продления на балансе владельца
+func (s *Service) SetAutoRenew(ctx context.Context, req *models.SetAutoRenewRequest) (*models.VDS, error) {
+	const op = "service.vds.SetAutoRenew"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(req.VDSID)), slog.Bool("enabled", req.Enabled))
+	log.Info("setting vds auto-renewal")
+
+	if req.VDSID <= 0 {
+		return nil, fmt.Errorf("%s: %w: invalid vds id", op, service.ErrInvalidArgument)
+	}
+	if req.Enabled && req.RefreshToken == "" {
+		return nil, fmt.Errorf("%s: %w: refresh_token is required to enable auto-renewal", op, service.ErrInvalidArgument)
+	}
+
+	vds, err := s.vdsRepo.GetByID(ctx, req.VDSID)
+	if err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) {
+			log.Warn("vds not found")
+			return nil, repository.ErrVDSNotFound
+		}
+		log.Error("failed to get vds", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var token []byte
+	if req.Enabled {
+		if s.billing == nil {
+			return nil, billing.ErrAutoRenewUnavailable
+		}
+		token, err = s.billing.AuthorizeAutoRenew(ctx, vds, req.RefreshToken)
+		if err != nil {
+			if errors.Is(err, billing.ErrAutoRenewRevoked) {
+				log.Warn("refresh token rejected for auto-renewal", slog.String("error", err.Error()))
+				return nil, fmt.Errorf("%s: %w: refresh token is not valid for the vds owner", op, service.ErrInvalidArgument)
+			}
+			log.Error("failed to authorize auto-renewal", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	updated, err := s.vdsRepo.SetAutoRenewToken(ctx, vds.ID, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidVDSState) {
+			log.Warn("vds is being deleted")
+			return nil, repository.ErrInvalidVDSState
+		}
+		log.Error("failed to store auto-renewal", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("vds auto-renewal updated")
+	return updated, nil
+}
+
+// AutoRenew продлевает подписку VDS от имени владельца по сохранённой авторизации автопродления.
+// Если авторизация больше не действует, автопродление отключается и в журнал VDS пишется renewal_failed
+func (s *Service) AutoRenew(ctx context.Context, vds *models.VDS) (*models.VDS, error) {
+	const op = "service.vds.AutoRenew"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(vds.ID)))
+
+	req := &models.RenewVDSRequest{VDSID: vds.ID, PayerID: vds.UserID}
+	if s.billing != nil {
+		accessToken, err := s.billing.AutoRenewAccess(ctx, vds)
+		if err != nil {
+			if errors.Is(err, billing.ErrAutoRenewRevoked) {
+				log.Warn("auto-renewal authorization revoked", slog.String("error", err.Error()))
+				s.addEvent(ctx, log, &models.VDSEvent{
+					VDSID:   vds.ID,
+					Type:    models.VDSEventRenewalFailed,
+					Message: "owner authorization for auto-renewal is no longer valid, auto-renewal was disabled",
+					Details: map[string]string{"expires_at": vds.ExpiresAt.UTC().Format(time.RFC3339)},
+				})
+			}
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		req.AccessToken = accessToken
+	}
+
+	renewed, err := s.Renew(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return renewed, nil
+}
+
+// addEvent записывает событие жизненного цикла VDS. Ошибка записи только логируется
+func (s *Service) addEvent(ctx context.Context, log *slog.Logger, event *models.VDSEvent) {
+	if err := s.vdsRepo.AddEvent(ctx, event); err != nil {
+		log.Error("failed to record vds event",
+			slog.String("type", string(event.Type)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// ListEvents возвращает журнал жизненного цикла подписки VDS: продления, приостановки, удаление
+func (s *Service) ListEvents(ctx context.Context, filter models.VDSEventFilter, page models.PageRequest) ([]*models.VDSEvent, string, error) {
+	const op = "service.vds.ListEvents"
+
+	log := s.log.With(slog.String("op", op), slog.Int("vds_id", int(filter.VDSID)))
+	log.Debug("listing vds events")
+
+	if filter.VDSID <= 0 {
+		return nil, "", fmt.Errorf("%s: %w: vds_id must be positive", op, service.ErrInvalidArgument)
+	}
+	if filter.Type != nil && *filter.Type == "" {
+		return nil, "", fmt.Errorf("%s: %w: unknown event type", op, service.ErrInvalidArgument)
+	}
+	if err := service.ValidatePage(page); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if err := service.ValidateTimeRange("created_at", filter.Created); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := s.vdsRepo.GetByID(ctx, filter.VDSID); err != nil {
+		if errors.Is(err, repository.ErrVDSNotFound) {
+			log.Warn("vds not found")
+			return nil, "", repository.ErrVDSNotFound
+		}
+		log.Error("failed to get vds", slog.String("error", err.Error()))
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	events, next, err := s.vdsRepo.ListEvents(ctx, filter, page)
+	if err != nil {
+		if service.IsInvalidPage(err) {
+			log.Warn("invalid page request", slog.String("error", err.Error()))
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		log.Error("failed to list vds events", slog.String("error", err.Error()))
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, next, nil
+}
@@ -0,0 +1,282 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+	"github.com/makhtech/management/internal/service"
+	"github.com/makhtech/management/internal/webhook"
+)
+
+const (
+	// minSecretLen наименьшая длина секрета, заданного клиентом
+	minSecretLen = 16
+	// maxSecretLen наибольшая длина секрета (secret VARCHAR(255))
+	maxSecretLen = 255
+	// maxURLLen наибольшая длина адреса (url VARCHAR(2048))
+	maxURLLen = 2048
+)
+
+// Service - сервис подписок на доменные события
+type Service struct {
+	webhookRepo repository.WebhookRepository
+	log         *slog.Logger
+}
+
+// New создает новый сервис подписок
+func New(webhookRepo repository.WebhookRepository, log *slog.Logger) *Service {
+	return &Service{
+		webhookRepo: webhookRepo,
+		log:         log,
+	}
+}
+
+// Create создает подписку. Если секрет не задан, он генерируется и возвращается в подписке
+func (s *Service) Create(ctx context.Context, req *models.CreateWebhookRequest) (*models.Webhook, error) {
+	const op = "service.webhook.Create"
+
+	log := s.log.With(slog.String("op", op), slog.Int("user_id", int(req.UserID)))
+	log.Info("creating webhook")
+
+	if req.UserID <= 0 {
+		return nil, fmt.Errorf("%s: %w: user_id is required", op, service.ErrInvalidArgument)
+	}
+	if err := validateURL(req.URL); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if req.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		req.Secret = secret
+	} else if err := validateSecret(req.Secret); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	eventTypes, err := normalizeEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	req.EventTypes = eventTypes
+
+	webhook, err := s.webhookRepo.Create(ctx, req)
+	if err != nil {
+		log.Error("failed to create webhook", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("webhook created successfully", slog.Int("id", int(webhook.ID)))
+	return webhook, nil
+}
+
+// GetByID получает подписку по ID
+func (s *Service) GetByID(ctx context.Context, id int32) (*models.Webhook, error) {
+	const op = "service.webhook.GetByID"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)))
+	log.Debug("getting webhook by id")
+
+	webhook, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			log.Warn("webhook not found")
+			return nil, repository.ErrWebhookNotFound
+		}
+		log.Error("failed to get webhook", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+// Update обновляет подписку
+func (s *Service) Update(ctx context.Context, req *models.UpdateWebhookRequest) (*models.Webhook, error) {
+	const op = "service.webhook.Update"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(req.ID)))
+	log.Info("updating webhook")
+
+	if req.ID <= 0 {
+		return nil, fmt.Errorf("%s: %w: invalid webhook id", op, service.ErrInvalidArgument)
+	}
+	if req.URL != nil {
+		if err := validateURL(*req.URL); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if req.Secret != nil {
+		if err := validateSecret(*req.Secret); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if req.EventTypes != nil {
+		eventTypes, err := normalizeEventTypes(req.EventTypes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		req.EventTypes = eventTypes
+	}
+
+	webhook, err := s.webhookRepo.Update(ctx, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			log.Warn("webhook not found for update")
+			return nil, repository.ErrWebhookNotFound
+		}
+		log.Error("failed to update webhook", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("webhook updated successfully")
+	return webhook, nil
+}
+
+// Delete удаляет подписку вместе с журналом её доставок
+func (s *Service) Delete(ctx context.Context, id int32) error {
+	const op = "service.webhook.Delete"
+
+	log := s.log.With(slog.String("op", op), slog.Int("id", int(id)))
+	log.Info("deleting webhook")
+
+	if id <= 0 {
+		return fmt.Errorf("%s: %w: invalid webhook id", op, service.ErrInvalidArgument)
+	}
+
+	if err := s.webhookRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			log.Warn("webhook not found for deletion")
+			return repository.ErrWebhookNotFound
+		}
+		log.Error("failed to delete webhook", slog.String("error", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("webhook deleted successfully")
+	return nil
+}
+
+// List возвращает страницу списка подписок
+func (s *Service) List(ctx context.Context, filter models.WebhookFilter, page models.PageRequest) ([]*models.Webhook, string, error) {
+	const op = "service.webhook.List"
+
+	log := s.log.With(slog.String("op", op))
+	log.Debug("listing webhooks")
+
+	if err := service.ValidatePage(page); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	webhooks, next, err := s.webhookRepo.List(ctx, filter, page)
+	if err != nil {
+		if service.IsInvalidPage(err) {
+			log.Warn("invalid page request", slog.String("error", err.Error()))
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		log.Error("failed to list webhooks", slog.String("error", err.Error()))
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("webhooks listed successfully", slog.Int("count", len(webhooks)))
+	return webhooks, next, nil
+}
+
+// ListDeliveries возвращает страницу журнала доставок подписки
+func (s *Service) ListDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter, page models.PageRequest) ([]*models.WebhookDelivery, string, error) {
+	const op = "service.webhook.ListDeliveries"
+
+	log := s.log.With(slog.String("op", op), slog.Int("webhook_id", int(filter.WebhookID)))
+	log.Debug("listing webhook deliveries")
+
+	if filter.WebhookID <= 0 {
+		return nil, "", fmt.Errorf("%s: %w: invalid webhook id", op, service.ErrInvalidArgument)
+	}
+	if filter.Status != nil && !filter.Status.IsValid() {
+		return nil, "", fmt.Errorf("%s: %w: unknown delivery status %q", op, service.ErrInvalidArgument, *filter.Status)
+	}
+	if err := service.ValidatePage(page); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if err := service.ValidateTimeRange("created_at", filter.Created); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	deliveries, next, err := s.webhookRepo.ListDeliveries(ctx, filter, page)
+	if err != nil {
+		if service.IsInvalidPage(err) {
+			log.Warn("invalid page request", slog.String("error", err.Error()))
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+		log.Error("failed to list webhook deliveries", slog.String("error", err.Error()))
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("webhook deliveries listed successfully", slog.Int("count", len(deliveries)))
+	return deliveries, next, nil
+}
+
+// validateURL проверяет, что адрес абсолютный http(s) и не указывает явно на внутреннюю сеть.
+// Имена, разрешающиеся во внутренние адреса, отклоняет Dispatcher при соединении
+func validateURL(raw string) error {
+	if raw == "" {
+		return fmt.Errorf("%w: url is required", service.ErrInvalidArgument)
+	}
+	if len(raw) > maxURLLen {
+		return fmt.Errorf("%w: url must be at most %d characters", service.ErrInvalidArgument, maxURLLen)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https url", service.ErrInvalidArgument)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: url must not contain credentials", service.ErrInvalidArgument)
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: url must point to a public address", service.ErrInvalidArgument)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !webhook.IsPublicAddr(addr) {
+		return fmt.Errorf("%w: url must point to a public address", service.ErrInvalidArgument)
+	}
+	return nil
+}
+
+func validateSecret(secret string) error {
+	if len(secret) < minSecretLen || len(secret) > maxSecretLen {
+		return fmt.Errorf("%w: secret must be %d to %d characters", service.ErrInvalidArgument, minSecretLen, maxSecretLen)
+	}
+	return nil
+}
+
+// normalizeEventTypes проверяет типы событий и убирает повторы. Пустой список - все события
+func normalizeEventTypes(eventTypes []string) ([]string, error) {
+	result := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		if !models.IsKnownEvent(t) {
+			return nil, fmt.Errorf("%w: unknown event type %q", service.ErrInvalidArgument, t)
+		}
+		if !slices.Contains(result, t) {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+// generateSecret случайный секрет: 32 байта в hex
+func generateSecret() (string, error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
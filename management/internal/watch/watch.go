@@ -0,0 +1,233 @@
+package watch
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/makhtech/management/internal/repository/postgres"
+)
+
+// Kind тип сущности, за изменениями которой следят подписчики
+type Kind int
+
+const (
+	KindTask Kind = iota
+	KindVDS
+)
+
+// channels каналы LISTEN/NOTIFY для каждого типа сущности
+var channels = map[string]Kind{
+	postgres.TaskStatusChannel: KindTask,
+	postgres.VDSStatusChannel:  KindVDS,
+}
+
+// Config конфигурация Hub
+type Config struct {
+	// RetryInterval пауза перед переподключением после потери соединения
+	RetryInterval time.Duration
+}
+
+type topic struct {
+	kind Kind
+	id   int32
+}
+
+// Subscription подписка на изменения одной сущности
+type Subscription struct {
+	hub   *Hub
+	topic topic
+	ch    chan struct{}
+}
+
+// C сигналы об изменении. Сигналы не копятся: несколько изменений подряд дают один сигнал,
+// поэтому после сигнала подписчик перечитывает текущее состояние сам
+func (s *Subscription) C() <-chan struct{} {
+	return s.ch
+}
+
+// Done закрывается при остановке Hub: подписчики должны завершить работу,
+// иначе долгие потоки задержат остановку сервера
+func (s *Subscription) Done() <-chan struct{} {
+	return s.hub.done
+}
+
+// Close отменяет подписку
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+func (s *Subscription) notify() {
+	select {
+	case s.ch <- struct{}{}:
+	default:
+	}
+}
+
+// Hub слушает уведомления Postgres об изменении статусов задач и VDS и раздаёт их подписчикам
+// этой реплики. Уведомления приходят со всех реплик, потому что их отправляют триггеры в БД.
+// После переподключения сигнал получают все подписчики: пропущенные уведомления не восстановить
+type Hub struct {
+	db  *postgres.Database
+	cfg Config
+	log *slog.Logger
+
+	mu   sync.Mutex
+	subs map[topic]map[*Subscription]struct{}
+
+	done   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New создаёт Hub
+func New(db *postgres.Database, cfg Config, log *slog.Logger) *Hub {
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = 5 * time.Second
+	}
+
+	return &Hub{
+		db:   db,
+		cfg:  cfg,
+		log:  log,
+		subs: make(map[topic]map[*Subscription]struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// Subscribe подписывается на изменения сущности kind с ID id
+func (h *Hub) Subscribe(kind Kind, id int32) *Subscription {
+	sub := &Subscription{
+		hub:   h,
+		topic: topic{kind: kind, id: id},
+		ch:    make(chan struct{}, 1),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.subs[sub.topic]
+	if !ok {
+		subs = make(map[*Subscription]struct{})
+		h.subs[sub.topic] = subs
+	}
+	subs[sub] = struct{}{}
+
+	return sub
+}
+
+func (h *Hub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs := h.subs[sub.topic]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subs, sub.topic)
+	}
+}
+
+// Start запускает прослушивание уведомлений
+func (h *Hub) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+
+	h.wg.Add(1)
+	go h.run(ctx)
+
+	h.log.Info("watch hub started")
+}
+
+// Stop останавливает прослушивание и закрывает Done всех подписок
+func (h *Hub) Stop() {
+	if h.cancel == nil {
+		return
+	}
+	close(h.done)
+	h.cancel()
+	h.wg.Wait()
+
+	h.log.Info("watch hub stopped")
+}
+
+func (h *Hub) run(ctx context.Context) {
+	defer h.wg.Done()
+
+	names := make([]string, 0, len(channels))
+	for name := range channels {
+		names = append(names, name)
+	}
+
+	for {
+		h.listen(ctx, names)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(h.cfg.RetryInterval):
+		}
+	}
+}
+
+// listen держит одно соединение LISTEN до его потери или остановки Hub
+func (h *Hub) listen(ctx context.Context, names []string) {
+	l, err := h.db.Listen(ctx, names...)
+	if err != nil {
+		if ctx.Err() == nil {
+			h.log.Error("failed to listen for notifications", slog.String("error", err.Error()))
+		}
+		return
+	}
+	defer l.Close()
+
+	// Пока соединения не было, изменения могли пройти мимо
+	h.broadcast()
+
+	for {
+		channel, payload, err := l.Wait(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				h.log.Warn("notification connection lost, reconnecting", slog.String("error", err.Error()))
+			}
+			return
+		}
+
+		kind, ok := channels[channel]
+		if !ok {
+			continue
+		}
+		id, err := strconv.ParseInt(payload, 10, 32)
+		if err != nil {
+			h.log.Warn("invalid notification payload",
+				slog.String("channel", channel),
+				slog.String("payload", payload),
+			)
+			continue
+		}
+
+		h.dispatch(topic{kind: kind, id: int32(id)})
+	}
+}
+
+func (h *Hub) dispatch(t topic) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs[t] {
+		sub.notify()
+	}
+}
+
+// broadcast сигналит всем подписчикам
+func (h *Hub) broadcast() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, subs := range h.subs {
+		for sub := range subs {
+			sub.notify()
+		}
+	}
+}
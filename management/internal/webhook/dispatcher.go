@@ -0,0 +1,301 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+const (
+	// cleanupInterval как часто удаляются завершённые доставки старше Retention
+	cleanupInterval = time.Hour
+	// maxDrainBody сколько байт ответа дочитывается, чтобы соединение можно было переиспользовать
+	maxDrainBody = 64 << 10
+	// userAgent заголовок User-Agent запросов доставки
+	userAgent = "makhtech-management-webhooks/1"
+)
+
+// Config конфигурация Dispatcher
+type Config struct {
+	// Interval пауза между проверками очереди, когда отправлять нечего
+	Interval time.Duration
+	// BatchSize сколько доставок берётся за один запрос
+	BatchSize int
+	// Concurrency сколько доставок отправляется одновременно
+	Concurrency int
+	// Timeout время на один запрос к получателю
+	Timeout time.Duration
+	// Lease на сколько доставка закрепляется за репликой, должен быть больше Timeout
+	Lease time.Duration
+	// MaxAttempts после стольких неудачных попыток доставка переходит в dead
+	MaxAttempts int32
+	// BaseBackoff пауза перед первым повтором, дальше она удваивается
+	BaseBackoff time.Duration
+	// MaxBackoff наибольшая пауза между повторами
+	MaxBackoff time.Duration
+	// Retention сколько хранятся завершённые доставки
+	Retention time.Duration
+	// Client HTTP клиент запросов, nil - клиент с Timeout, который соединяется только
+	// с публичными адресами и не переходит по редиректам
+	Client *http.Client
+}
+
+// Dispatcher отправляет доставки подписок подписанными POST запросами. Доставка успешна,
+// если получатель ответил 2xx. Остальные ответы и сетевые ошибки повторяются с
+// экспоненциальной паузой, после MaxAttempts попыток доставка переходит в dead.
+// Порядок доставок не гарантируется: получатель упорядочивает события по ID
+type Dispatcher struct {
+	repo   repository.WebhookRepository
+	cfg    Config
+	client *http.Client
+	log    *slog.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New создаёт Dispatcher
+func New(repo repository.WebhookRepository, cfg Config, log *slog.Logger) *Dispatcher {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 8
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.Lease <= cfg.Timeout {
+		cfg.Lease = cfg.Timeout + time.Minute
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 10 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = time.Hour
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = 30 * 24 * time.Hour
+	}
+
+	client := cfg.Client
+	if client == nil {
+		client = newClient(cfg.Timeout)
+	}
+
+	return &Dispatcher{
+		repo:   repo,
+		cfg:    cfg,
+		client: client,
+		log:    log,
+	}
+}
+
+// Start запускает отправку доставок
+func (d *Dispatcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+
+	d.wg.Add(1)
+	go d.run(ctx)
+
+	d.log.Info("webhook dispatcher started",
+		slog.Duration("interval", d.cfg.Interval),
+		slog.Int("concurrency", d.cfg.Concurrency),
+		slog.Int("max_attempts", int(d.cfg.MaxAttempts)),
+	)
+}
+
+// Stop останавливает отправку. Доставки, прерванные остановкой, будут отправлены
+// повторно после Lease
+func (d *Dispatcher) Stop() {
+	if d.cancel == nil {
+		return
+	}
+	d.cancel()
+	d.wg.Wait()
+	d.client.CloseIdleConnections()
+
+	d.log.Info("webhook dispatcher stopped")
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.drain(ctx)
+
+			if time.Since(lastCleanup) >= cleanupInterval {
+				d.cleanup(ctx)
+				lastCleanup = time.Now()
+			}
+		}
+	}
+}
+
+// drain отправляет доставки, пока они есть
+func (d *Dispatcher) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		dispatches, err := d.repo.ClaimDeliveries(ctx, d.cfg.BatchSize, d.cfg.Lease)
+		if err != nil {
+			if ctx.Err() == nil {
+				d.log.Error("failed to claim webhook deliveries", slog.String("error", err.Error()))
+			}
+			return
+		}
+		if len(dispatches) == 0 {
+			return
+		}
+
+		sem := make(chan struct{}, d.cfg.Concurrency)
+		var wg sync.WaitGroup
+		for _, dispatch := range dispatches {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				d.deliver(ctx, dispatch)
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+// deliver делает одну попытку доставки и записывает её итог
+func (d *Dispatcher) deliver(ctx context.Context, dispatch *models.WebhookDispatch) {
+	delivery := dispatch.Delivery
+	log := d.log.With(
+		slog.Int64("delivery_id", delivery.ID),
+		slog.Int("webhook_id", int(delivery.WebhookID)),
+		slog.Int64("event_id", delivery.EventID),
+		slog.String("type", delivery.EventType),
+	)
+
+	statusCode, err := d.send(ctx, dispatch)
+	if err != nil && ctx.Err() != nil {
+		// Остановка: доставка вернётся в очередь после Lease, попытку не считаем
+		return
+	}
+
+	// Итог попытки записываем и при остановке
+	mctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if err == nil {
+		if merr := d.repo.MarkDelivered(mctx, delivery.ID, statusCode); merr != nil {
+			// Доставка будет отправлена повторно после Lease
+			log.Error("failed to mark webhook delivery delivered", slog.String("error", merr.Error()))
+		}
+		return
+	}
+
+	attempt := delivery.Attempts + 1
+	var next *time.Time
+	// Непубличный адрес не станет доступным при повторе
+	if attempt < d.cfg.MaxAttempts && !errors.Is(err, ErrForbiddenAddress) {
+		at := time.Now().Add(d.backoff(delivery.Attempts))
+		next = &at
+		log.Warn("webhook delivery failed",
+			slog.Int("attempt", int(attempt)),
+			slog.Int("status_code", int(statusCode)),
+			slog.Time("next_attempt", at),
+			slog.String("error", err.Error()),
+		)
+	} else {
+		log.Error("webhook delivery exhausted retries, moved to dead",
+			slog.Int("attempts", int(attempt)),
+			slog.Int("status_code", int(statusCode)),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	if merr := d.repo.MarkFailed(mctx, delivery.ID, statusCode, err.Error(), next); merr != nil {
+		log.Error("failed to record webhook delivery failure", slog.String("error", merr.Error()))
+	}
+}
+
+// send отправляет доставку и возвращает HTTP статус ответа, 0 - ответа не было
+func (d *Dispatcher) send(ctx context.Context, dispatch *models.WebhookDispatch) (int32, error) {
+	delivery := dispatch.Delivery
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dispatch.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderDeliveryID, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderEventID, strconv.FormatInt(delivery.EventID, 10))
+	req.Header.Set(HeaderEventType, delivery.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(dispatch.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Тело ответа не сохраняется: last_error видит владелец подписки
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBody))
+
+	statusCode := int32(resp.StatusCode)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return statusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return statusCode, nil
+}
+
+// backoff пауза перед следующей попыткой: BaseBackoff, x2, x4... не больше MaxBackoff
+func (d *Dispatcher) backoff(attempts int32) time.Duration {
+	if attempts >= 30 {
+		return d.cfg.MaxBackoff
+	}
+	delay := d.cfg.BaseBackoff << attempts
+	if delay <= 0 || delay > d.cfg.MaxBackoff {
+		return d.cfg.MaxBackoff
+	}
+	return delay
+}
+
+func (d *Dispatcher) cleanup(ctx context.Context) {
+	deleted, err := d.repo.DeleteDeliveries(ctx, time.Now().Add(-d.cfg.Retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			d.log.Error("failed to delete old webhook deliveries", slog.String("error", err.Error()))
+		}
+		return
+	}
+	if deleted > 0 {
+		d.log.Debug("old webhook deliveries deleted", slog.Int64("count", deleted))
+	}
+}
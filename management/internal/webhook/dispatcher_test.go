@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+const testSecret = "0123456789abcdef"
+
+// fakeRepo записывает итоги попыток доставки. Остальные методы WebhookRepository не вызываются
+type fakeRepo struct {
+	repository.WebhookRepository
+
+	mu        sync.Mutex
+	delivered []int32
+	failed    []failedAttempt
+}
+
+type failedAttempt struct {
+	statusCode int32
+	reason     string
+	next       *time.Time
+}
+
+func (r *fakeRepo) MarkDelivered(_ context.Context, _ int64, statusCode int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.delivered = append(r.delivered, statusCode)
+	return nil
+}
+
+func (r *fakeRepo) MarkFailed(_ context.Context, _ int64, statusCode int32, reason string, next *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failed = append(r.failed, failedAttempt{statusCode: statusCode, reason: reason, next: next})
+	return nil
+}
+
+func newTestDispatcher(repo *fakeRepo, client *http.Client) *Dispatcher {
+	return New(repo, Config{
+		MaxAttempts: 3,
+		BaseBackoff: time.Second,
+		Client:      client,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func testDispatch(url string, attempts int32) *models.WebhookDispatch {
+	return &models.WebhookDispatch{
+		Delivery: &models.WebhookDelivery{
+			ID:        7,
+			WebhookID: 3,
+			EventID:   42,
+			EventType: models.EventVDSCreated,
+			Payload:   []byte(`{"id":42,"type":"VDSCreated"}`),
+			Attempts:  attempts,
+		},
+		URL:    url,
+		Secret: testSecret,
+	}
+}
+
+func TestDeliverSigned(t *testing.T) {
+	var gotEventID, gotDeliveryID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := VerifyRequest(r, testSecret, time.Minute); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		gotEventID = r.Header.Get(HeaderEventID)
+		gotDeliveryID = r.Header.Get(HeaderDeliveryID)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	repo := &fakeRepo{}
+	newTestDispatcher(repo, srv.Client()).deliver(context.Background(), testDispatch(srv.URL, 0))
+
+	if len(repo.delivered) != 1 || repo.delivered[0] != http.StatusNoContent {
+		t.Fatalf("delivered = %v, failed = %+v", repo.delivered, repo.failed)
+	}
+	if gotEventID != "42" || gotDeliveryID != "7" {
+		t.Fatalf("event id = %q, delivery id = %q", gotEventID, gotDeliveryID)
+	}
+}
+
+func TestDeliverFailure(t *testing.T) {
+	const leaked = "db password is hunter2"
+
+	tests := []struct {
+		name     string
+		secret   string
+		attempts int32
+		wantCode int32
+		wantNext bool
+	}{
+		{name: "wrong secret is retried", secret: "another-secret-value", attempts: 0, wantCode: http.StatusUnauthorized, wantNext: true},
+		{name: "server error is retried", secret: testSecret, attempts: 1, wantCode: http.StatusInternalServerError, wantNext: true},
+		{name: "last attempt goes dead", secret: testSecret, attempts: 2, wantCode: http.StatusInternalServerError, wantNext: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if _, err := VerifyRequest(r, testSecret, time.Minute); err != nil {
+					http.Error(w, leaked, http.StatusUnauthorized)
+					return
+				}
+				http.Error(w, leaked, http.StatusInternalServerError)
+			}))
+			defer srv.Close()
+
+			dispatch := testDispatch(srv.URL, tt.attempts)
+			dispatch.Secret = tt.secret
+
+			repo := &fakeRepo{}
+			newTestDispatcher(repo, srv.Client()).deliver(context.Background(), dispatch)
+
+			if len(repo.delivered) != 0 || len(repo.failed) != 1 {
+				t.Fatalf("delivered = %v, failed = %+v", repo.delivered, repo.failed)
+			}
+			got := repo.failed[0]
+			if got.statusCode != tt.wantCode {
+				t.Fatalf("status code = %d, want %d", got.statusCode, tt.wantCode)
+			}
+			if (got.next != nil) != tt.wantNext {
+				t.Fatalf("next attempt = %v, want retry %v", got.next, tt.wantNext)
+			}
+			if strings.Contains(got.reason, leaked) {
+				t.Fatalf("reason %q contains the receiver response body", got.reason)
+			}
+			if !strings.Contains(got.reason, strconv.Itoa(int(tt.wantCode))) {
+				t.Fatalf("reason %q does not mention the status code", got.reason)
+			}
+		})
+	}
+}
+
+func TestDeliverRejectsPrivateAddress(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+	}))
+	defer srv.Close()
+
+	// Клиент по умолчанию: httptest слушает loopback
+	repo := &fakeRepo{}
+	newTestDispatcher(repo, nil).deliver(context.Background(), testDispatch(srv.URL, 0))
+
+	if hits.Load() != 0 {
+		t.Fatal("request reached a loopback receiver")
+	}
+	if len(repo.failed) != 1 {
+		t.Fatalf("delivered = %v, failed = %+v", repo.delivered, repo.failed)
+	}
+	if repo.failed[0].next != nil {
+		t.Fatal("delivery to a private address is retried")
+	}
+	if !strings.Contains(repo.failed[0].reason, ErrForbiddenAddress.Error()) {
+		t.Fatalf("reason = %q", repo.failed[0].reason)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	d := New(&fakeRepo{}, Config{BaseBackoff: 10 * time.Second, MaxBackoff: time.Minute}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, 20 * time.Second},
+		{2, 40 * time.Second},
+		{3, time.Minute},
+		{62, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/makhtech/management/internal/outbox"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// Префиксы ordering_key: vds:<id> общий у событий VDS и её задач, plan:<id> - у событий тарифов
+const (
+	vdsOrderingPrefix  = "vds:"
+	planOrderingPrefix = "plan:"
+)
+
+// FanoutSink - outbox sink, который раскладывает событие в доставки подписок.
+// Сам ничего не отправляет: доставки отправляет Dispatcher
+type FanoutSink struct {
+	repo repository.WebhookRepository
+	log  *slog.Logger
+}
+
+// NewFanoutSink создаёт sink подписок
+func NewFanoutSink(repo repository.WebhookRepository, log *slog.Logger) *FanoutSink {
+	return &FanoutSink{repo: repo, log: log}
+}
+
+// Publish создаёт доставки события. Повтор того же события доставки не дублирует
+func (s *FanoutSink) Publish(ctx context.Context, msg outbox.Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	vdsID, ok := eventScope(msg.OrderingKey)
+	if !ok {
+		// Неизвестно, кому принадлежит событие: не отправляем его никому
+		s.log.Warn("webhook fanout skipped: unknown ordering key",
+			slog.Int64("event_id", msg.ID),
+			slog.String("ordering_key", msg.OrderingKey),
+		)
+		return nil
+	}
+
+	created, err := s.repo.Fanout(ctx, msg.ID, msg.Type, body, vdsID)
+	if err != nil {
+		return err
+	}
+	if created > 0 {
+		s.log.Debug("webhook deliveries created",
+			slog.Int64("event_id", msg.ID),
+			slog.String("type", msg.Type),
+			slog.Int64("count", created),
+		)
+	}
+	return nil
+}
+
+func (s *FanoutSink) Close() error {
+	return nil
+}
+
+// eventScope ID VDS, владельцу которой адресовано событие, или nil для публичных событий тарифов.
+// false - ключ не распознан
+func eventScope(key string) (*int32, bool) {
+	if strings.HasPrefix(key, planOrderingPrefix) {
+		return nil, true
+	}
+	rest, ok := strings.CutPrefix(key, vdsOrderingPrefix)
+	if !ok {
+		return nil, false
+	}
+	id, err := strconv.ParseInt(rest, 10, 32)
+	if err != nil {
+		return nil, false
+	}
+	vdsID := int32(id)
+	return &vdsID, true
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Заголовки запроса доставки
+const (
+	// HeaderDeliveryID ID доставки, одинаковый у всех повторов
+	HeaderDeliveryID = "X-Webhook-Delivery"
+	// HeaderEventID ID события: получатель отбрасывает повторы по нему
+	HeaderEventID = "X-Webhook-Event-Id"
+	// HeaderEventType тип события
+	HeaderEventType = "X-Webhook-Event"
+	// HeaderTimestamp время отправки в секундах Unix, входит в подпись
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature подпись v1=<hex HMAC-SHA256(secret, timestamp + "." + body)>
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// signatureVersion префикс подписи. Новая схема подписи получит новую версию
+const signatureVersion = "v1="
+
+var (
+	ErrMissingSignature = errors.New("webhook: missing signature headers")
+	ErrInvalidSignature = errors.New("webhook: signature mismatch")
+	ErrStaleTimestamp   = errors.New("webhook: timestamp is outside the tolerance")
+)
+
+// Sign подпись тела body, отправленного в момент timestamp, для заголовка X-Webhook-Signature
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return signatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify проверяет подпись и время отправки доставки. tolerance ограничивает расхождение
+// timestamp с now и защищает от повторной отправки перехваченного запроса, 0 - не проверять
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration, now time.Time) error {
+	ts, sig := header.Get(HeaderTimestamp), header.Get(HeaderSignature)
+	if ts == "" || sig == "" {
+		return ErrMissingSignature
+	}
+	timestamp, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrMissingSignature
+	}
+	if tolerance > 0 && now.Sub(time.Unix(timestamp, 0)).Abs() > tolerance {
+		return ErrStaleTimestamp
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(Sign(secret, timestamp, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyRequest читает тело запроса доставки и проверяет его подпись. Для получателей на Go
+func VerifyRequest(r *http.Request, secret string, tolerance time.Duration) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := Verify(secret, r.Header, body, tolerance, time.Now()); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	const secret = "0123456789abcdef"
+	body := []byte(`{"id":1,"type":"VDSCreated"}`)
+	now := time.Unix(1_700_000_000, 0)
+
+	signed := func(secret string, ts time.Time, body []byte) http.Header {
+		h := http.Header{}
+		h.Set(HeaderTimestamp, strconv.FormatInt(ts.Unix(), 10))
+		h.Set(HeaderSignature, Sign(secret, ts.Unix(), body))
+		return h
+	}
+
+	tests := []struct {
+		name      string
+		header    http.Header
+		body      []byte
+		tolerance time.Duration
+		wantErr   error
+	}{
+		{
+			name:      "valid",
+			header:    signed(secret, now, body),
+			body:      body,
+			tolerance: 5 * time.Minute,
+		},
+		{
+			name:      "wrong secret",
+			header:    signed("another-secret-value", now, body),
+			body:      body,
+			tolerance: 5 * time.Minute,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "tampered body",
+			header:    signed(secret, now, body),
+			body:      []byte(`{"id":1,"type":"VDSDeleted"}`),
+			tolerance: 5 * time.Minute,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name: "timestamp replaced",
+			header: func() http.Header {
+				h := signed(secret, now.Add(-time.Hour), body)
+				h.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+				return h
+			}(),
+			body:      body,
+			tolerance: 5 * time.Minute,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "stale timestamp",
+			header:    signed(secret, now.Add(-time.Hour), body),
+			body:      body,
+			tolerance: 5 * time.Minute,
+			wantErr:   ErrStaleTimestamp,
+		},
+		{
+			name:   "stale timestamp without tolerance",
+			header: signed(secret, now.Add(-time.Hour), body),
+			body:   body,
+		},
+		{
+			name:    "missing signature",
+			header:  http.Header{HeaderTimestamp: {strconv.FormatInt(now.Unix(), 10)}},
+			body:    body,
+			wantErr: ErrMissingSignature,
+		},
+		{
+			name: "signature without version",
+			header: func() http.Header {
+				h := signed(secret, now, body)
+				h.Set(HeaderSignature, h.Get(HeaderSignature)[len(signatureVersion):])
+				return h
+			}(),
+			body:    body,
+			wantErr: ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(secret, tt.header, tt.body, tt.tolerance, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSignKnownVector(t *testing.T) {
+	// HMAC-SHA256("secret", "1700000000.{}"): получатели на других языках сверяются с этим значением
+	want := "v1=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163"
+	if got := Sign("secret", 1700000000, []byte(`{}`)); got != want {
+		t.Fatalf("Sign() = %q, want %q", got, want)
+	}
+}
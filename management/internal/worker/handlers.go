@@ -0,0 +1,314 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/makhtech/management/internal/clients/proxmox"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+const (
+	// statusUpdateTimeout время на перевод VDS в error после неудачной задачи
+	statusUpdateTimeout = 5 * time.Second
+	// cleanupTimeout время на удаление VM, оставшейся от неудачного создания
+	cleanupTimeout = 2 * time.Minute
+)
+
+// VDSConfig параметры развёртывания VM в Proxmox
+type VDSConfig struct {
+	// TemplateID vmid шаблона, из которого клонируются VM
+	TemplateID int32
+	// Storage хранилище дисков VM. Пусто - как у шаблона
+	Storage string
+	// Disk имя диска VM, который расширяется до размера тарифа
+	Disk string
+	// TaskPollInterval интервал опроса статуса задач Proxmox
+	TaskPollInterval time.Duration
+}
+
+// VDSHandlers обработчики задач жизненного цикла VDS
+type VDSHandlers struct {
+	vdsRepo  repository.VDSRepository
+	nodeRepo repository.NodeRepository
+	planRepo repository.PlanRepository
+	proxmox  *proxmox.Pool
+	cfg      VDSConfig
+	log      *slog.Logger
+}
+
+// NewVDSHandlers создаёт обработчики задач VDS
+func NewVDSHandlers(
+	vdsRepo repository.VDSRepository,
+	nodeRepo repository.NodeRepository,
+	planRepo repository.PlanRepository,
+	proxmoxPool *proxmox.Pool,
+	cfg VDSConfig,
+	log *slog.Logger,
+) *VDSHandlers {
+	if cfg.Disk == "" {
+		cfg.Disk = "scsi0"
+	}
+	if cfg.TaskPollInterval <= 0 {
+		cfg.TaskPollInterval = 2 * time.Second
+	}
+
+	return &VDSHandlers{
+		vdsRepo:  vdsRepo,
+		nodeRepo: nodeRepo,
+		planRepo: planRepo,
+		proxmox:  proxmoxPool,
+		cfg:      cfg,
+		log:      log,
+	}
+}
+
+// Register регистрирует обработчики для всех типов задач VDS
+func (h *VDSHandlers) Register(p *Pool) {
+	p.Register(models.TaskTypeCreate, HandlerFunc(h.Create))
+	p.Register(models.TaskTypeDelete, HandlerFunc(h.Delete))
+	p.Register(models.TaskTypeStart, HandlerFunc(h.Start))
+	p.Register(models.TaskTypeStop, HandlerFunc(h.Stop))
+	p.Register(models.TaskTypeRestart, HandlerFunc(h.Restart))
+	p.Register(models.TaskTypePowerOff, HandlerFunc(h.PowerOff))
+}
+
+// vmTarget VDS вместе с нодой и клиентом Proxmox этой ноды
+type vmTarget struct {
+	vds    *models.VDS
+	node   *models.Node
+	client *proxmox.Client
+}
+
+// Create клонирует VM из шаблона, применяет ресурсы тарифа и запускает её
+func (h *VDSHandlers) Create(ctx context.Context, task *models.Task) error {
+	const op = "worker.VDSHandlers.Create"
+
+	t, err := h.target(ctx, task)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = h.create(ctx, t)
+	if err != nil {
+		h.markFailed(t.vds.ID)
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return h.setStatus(ctx, t.vds.ID, models.VDSStatusRunning)
+}
+
+func (h *VDSHandlers) create(ctx context.Context, t *vmTarget) (err error) {
+	plan, err := h.planRepo.GetByID(ctx, t.vds.PlanID)
+	if err != nil {
+		return err
+	}
+
+	vmid := t.vds.ProxmoxVMID
+	upid, err := t.client.CloneVM(ctx, t.node.Name, h.cfg.TemplateID, vmid, proxmox.CloneOptions{
+		Name:    fmt.Sprintf("vds-%d", t.vds.ID),
+		Full:    true,
+		Storage: h.cfg.Storage,
+	})
+	if err != nil {
+		return fmt.Errorf("clone vm: %w", err)
+	}
+	// Клон уже создаётся: недоделанную VM удаляем, иначе повтор упрётся в занятый VMID
+	defer func() {
+		if err != nil {
+			h.removeClone(ctx, t)
+		}
+	}()
+	if err := t.client.WaitTask(ctx, t.node.Name, upid, h.cfg.TaskPollInterval); err != nil {
+		return fmt.Errorf("clone vm: %w", err)
+	}
+
+	err = t.client.ConfigureVM(ctx, t.node.Name, vmid, proxmox.VMConfig{
+		Cores:    plan.CPU,
+		MemoryMB: plan.RAMMB,
+	})
+	if err != nil {
+		return fmt.Errorf("configure vm: %w", err)
+	}
+
+	if err := t.client.ResizeDisk(ctx, t.node.Name, vmid, h.cfg.Disk, plan.DiskGB); err != nil {
+		return fmt.Errorf("resize disk: %w", err)
+	}
+
+	upid, err = t.client.StartVM(ctx, t.node.Name, vmid)
+	if err != nil {
+		return fmt.Errorf("start vm: %w", err)
+	}
+	if err := t.client.WaitTask(ctx, t.node.Name, upid, h.cfg.TaskPollInterval); err != nil {
+		return fmt.Errorf("start vm: %w", err)
+	}
+
+	return nil
+}
+
+// removeClone останавливает и удаляет VM после неудачного создания. Контекст задачи
+// к этому моменту может быть уже отменён, поэтому удаление идёт в отдельном контексте
+func (h *VDSHandlers) removeClone(ctx context.Context, t *vmTarget) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+	defer cancel()
+
+	log := h.log.With(slog.Int("vds_id", int(t.vds.ID)), slog.Int("vmid", int(t.vds.ProxmoxVMID)))
+	if err := h.destroy(ctx, t); err != nil {
+		log.Error("failed to remove vm after failed create", slog.String("error", err.Error()))
+		return
+	}
+	log.Info("vm removed after failed create")
+}
+
+// Delete останавливает и удаляет VM и освобождает адреса VDS.
+// Отсутствие VM в Proxmox считается успешным удалением
+func (h *VDSHandlers) Delete(ctx context.Context, task *models.Task) error {
+	const op = "worker.VDSHandlers.Delete"
+
+	t, err := h.target(ctx, task)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = h.destroy(ctx, t)
+	if err != nil {
+		h.markFailed(t.vds.ID)
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// VM удалена - освобождаем адреса VDS вместе с переходом в deleted
+	if _, err := h.vdsRepo.MarkDeleted(ctx, t.vds.ID); err != nil {
+		return fmt.Errorf("%s: mark vds deleted: %w", op, err)
+	}
+	return nil
+}
+
+func (h *VDSHandlers) destroy(ctx context.Context, t *vmTarget) error {
+	vmid := t.vds.ProxmoxVMID
+
+	status, err := t.client.GetVMStatus(ctx, t.node.Name, vmid)
+	if err != nil {
+		if errors.Is(err, proxmox.ErrVMNotFound) {
+			h.log.Warn("vm already absent in proxmox", slog.Int("vds_id", int(t.vds.ID)))
+			return nil
+		}
+		return fmt.Errorf("get vm status: %w", err)
+	}
+
+	if status.Status == "running" {
+		upid, err := t.client.StopVM(ctx, t.node.Name, vmid)
+		if err != nil {
+			return fmt.Errorf("stop vm: %w", err)
+		}
+		if err := t.client.WaitTask(ctx, t.node.Name, upid, h.cfg.TaskPollInterval); err != nil {
+			return fmt.Errorf("stop vm: %w", err)
+		}
+	}
+
+	upid, err := t.client.DestroyVM(ctx, t.node.Name, vmid)
+	if err != nil {
+		return fmt.Errorf("destroy vm: %w", err)
+	}
+	if err := t.client.WaitTask(ctx, t.node.Name, upid, h.cfg.TaskPollInterval); err != nil {
+		return fmt.Errorf("destroy vm: %w", err)
+	}
+
+	return nil
+}
+
+// Start запускает VM
+func (h *VDSHandlers) Start(ctx context.Context, task *models.Task) error {
+	return h.power(ctx, task, "worker.VDSHandlers.Start", (*proxmox.Client).StartVM, models.VDSStatusRunning)
+}
+
+// Stop корректно выключает VM
+func (h *VDSHandlers) Stop(ctx context.Context, task *models.Task) error {
+	return h.power(ctx, task, "worker.VDSHandlers.Stop", (*proxmox.Client).ShutdownVM, models.VDSStatusStopped)
+}
+
+// Restart перезагружает VM
+func (h *VDSHandlers) Restart(ctx context.Context, task *models.Task) error {
+	return h.power(ctx, task, "worker.VDSHandlers.Restart", (*proxmox.Client).RebootVM, models.VDSStatusRunning)
+}
+
+// PowerOff немедленно останавливает VM, как отключение питания. Для зависших VM,
+// которые не отвечают на ACPI
+func (h *VDSHandlers) PowerOff(ctx context.Context, task *models.Task) error {
+	return h.power(ctx, task, "worker.VDSHandlers.PowerOff", (*proxmox.Client).StopVM, models.VDSStatusStopped)
+}
+
+// power выполняет операцию питания и переводит VDS в статус status.
+// При ошибке статус VDS не меняется: VM остаётся в прежнем состоянии
+func (h *VDSHandlers) power(
+	ctx context.Context,
+	task *models.Task,
+	op string,
+	action func(c *proxmox.Client, ctx context.Context, node string, vmid int32) (string, error),
+	status models.VDSStatus,
+) error {
+	t, err := h.target(ctx, task)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	upid, err := action(t.client, ctx, t.node.Name, t.vds.ProxmoxVMID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := t.client.WaitTask(ctx, t.node.Name, upid, h.cfg.TaskPollInterval); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return h.setStatus(ctx, t.vds.ID, status)
+}
+
+// target загружает VDS задачи, её ноду и клиент Proxmox.
+// Имя ноды в БД должно совпадать с именем ноды в кластере Proxmox
+func (h *VDSHandlers) target(ctx context.Context, task *models.Task) (*vmTarget, error) {
+	vds, err := h.vdsRepo.GetByID(ctx, task.VDSID)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := h.nodeRepo.GetByID(ctx, vds.NodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := h.proxmox.Get(node.APIURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmTarget{vds: vds, node: node, client: client}, nil
+}
+
+// setStatus переводит VDS в итоговый статус после успешного выполнения задачи
+func (h *VDSHandlers) setStatus(ctx context.Context, vdsID int32, status models.VDSStatus) error {
+	if _, err := h.vdsRepo.UpdateStatus(ctx, vdsID, status); err != nil {
+		return fmt.Errorf("update vds status: %w", err)
+	}
+
+	h.log.Debug("vds status updated by task",
+		slog.Int("vds_id", int(vdsID)),
+		slog.String("status", string(status)),
+	)
+	return nil
+}
+
+// markFailed переводит VDS в error. Контекст задачи к этому моменту может быть уже отменён
+func (h *VDSHandlers) markFailed(vdsID int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), statusUpdateTimeout)
+	defer cancel()
+
+	if _, err := h.vdsRepo.UpdateStatus(ctx, vdsID, models.VDSStatusError); err != nil {
+		h.log.Error("failed to mark vds as failed",
+			slog.Int("vds_id", int(vdsID)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
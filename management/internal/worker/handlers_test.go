@@ -0,0 +1,276 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/makhtech/management/internal/clients/proxmox"
+	"github.com/makhtech/management/internal/clients/proxmox/proxmoxtest"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+const (
+	testTokenID     = "management@pve!worker"
+	testTokenSecret = "secret"
+	testTemplate    = 9000
+	testVMID        = 101
+)
+
+var testNode = &models.Node{ID: 1, Name: "pve1", APIURL: "https://pve1:8006/api2/json", IsActive: true}
+
+// fakeVDSRepo хранит одну VDS в памяти. Остальные методы VDSRepository не вызываются
+type fakeVDSRepo struct {
+	repository.VDSRepository
+
+	vds     models.VDS
+	deleted bool
+}
+
+func (r *fakeVDSRepo) GetByID(_ context.Context, id int32) (*models.VDS, error) {
+	if id != r.vds.ID {
+		return nil, repository.ErrVDSNotFound
+	}
+	vds := r.vds
+	return &vds, nil
+}
+
+func (r *fakeVDSRepo) UpdateStatus(_ context.Context, id int32, status models.VDSStatus) (*models.VDS, error) {
+	r.vds.Status = status
+	return r.GetByID(context.Background(), id)
+}
+
+func (r *fakeVDSRepo) MarkDeleted(_ context.Context, id int32) (*models.VDS, error) {
+	r.deleted = true
+	return r.UpdateStatus(context.Background(), id, models.VDSStatusDeleted)
+}
+
+type fakeNodeRepo struct {
+	repository.NodeRepository
+}
+
+func (fakeNodeRepo) GetByID(context.Context, int32) (*models.Node, error) {
+	node := *testNode
+	return &node, nil
+}
+
+type fakePlanRepo struct {
+	repository.PlanRepository
+
+	plan models.Plan
+}
+
+func (r fakePlanRepo) GetByID(context.Context, int32) (*models.Plan, error) {
+	plan := r.plan
+	return &plan, nil
+}
+
+type handlersEnv struct {
+	handlers *VDSHandlers
+	vdsRepo  *fakeVDSRepo
+	srv      *proxmoxtest.Server
+	task     *models.Task
+}
+
+func newHandlersEnv(t *testing.T, status models.VDSStatus, plan models.Plan) *handlersEnv {
+	t.Helper()
+
+	srv := proxmoxtest.NewServer(testTokenID, testTokenSecret)
+	srv.AddTemplate(testTemplate)
+	t.Cleanup(srv.Close)
+
+	vdsRepo := &fakeVDSRepo{vds: models.VDS{ID: 1, PlanID: plan.ID, NodeID: testNode.ID, ProxmoxVMID: testVMID, Status: status}}
+	pool := proxmox.NewPool(proxmox.Config{TokenID: testTokenID, TokenSecret: testTokenSecret, Endpoint: srv.APIURL()})
+
+	h := NewVDSHandlers(vdsRepo, fakeNodeRepo{}, fakePlanRepo{plan: plan}, pool, VDSConfig{
+		TemplateID:       testTemplate,
+		TaskPollInterval: time.Millisecond,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	return &handlersEnv{
+		handlers: h,
+		vdsRepo:  vdsRepo,
+		srv:      srv,
+		task:     &models.Task{ID: 10, VDSID: 1},
+	}
+}
+
+// withVM создаёт VM VDS в fake сервере в состоянии status
+func (e *handlersEnv) withVM(t *testing.T, status string) {
+	t.Helper()
+
+	ctx := context.Background()
+	c, err := proxmox.New(e.srv.APIURL(), proxmox.Config{TokenID: testTokenID, TokenSecret: testTokenSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upid, err := c.CloneVM(ctx, testNode.Name, testTemplate, testVMID, proxmox.CloneOptions{})
+	if err == nil {
+		err = c.WaitTask(ctx, testNode.Name, upid, time.Millisecond)
+	}
+	if err == nil && status == "running" {
+		upid, err = c.StartVM(ctx, testNode.Name, testVMID)
+		if err == nil {
+			err = c.WaitTask(ctx, testNode.Name, upid, time.Millisecond)
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+var testPlan = models.Plan{ID: 2, CPU: 2, RAMMB: 4096, DiskGB: 40}
+
+func TestCreate(t *testing.T) {
+	env := newHandlersEnv(t, models.VDSStatusCreating, testPlan)
+
+	if err := env.handlers.Create(context.Background(), env.task); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	vm, ok := env.srv.GetVM(testNode.Name, testVMID)
+	if !ok {
+		t.Fatal("vm was not created")
+	}
+	if vm.Name != "vds-1" || vm.Status != "running" || vm.Cores != 2 || vm.MemoryMB != 4096 || vm.DiskGB != 40 {
+		t.Fatalf("vm = %+v", vm)
+	}
+	if env.vdsRepo.vds.Status != models.VDSStatusRunning {
+		t.Fatalf("vds status = %s, want running", env.vdsRepo.vds.Status)
+	}
+}
+
+func TestCreateFailureRemovesClone(t *testing.T) {
+	tests := []struct {
+		name  string
+		plan  models.Plan
+		setup func(env *handlersEnv)
+	}{
+		{
+			name:  "clone task fails",
+			plan:  testPlan,
+			setup: func(env *handlersEnv) { env.srv.FailNext("clone", "clone failed: storage is full") },
+		},
+		{
+			// fake не уменьшает диск шаблона (10G)
+			name: "resize fails",
+			plan: models.Plan{ID: 2, CPU: 1, RAMMB: 1024, DiskGB: 5},
+		},
+		{
+			name:  "start fails",
+			plan:  testPlan,
+			setup: func(env *handlersEnv) { env.srv.FailNext("start", "start failed: kvm: not enough memory") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newHandlersEnv(t, models.VDSStatusCreating, tt.plan)
+			if tt.setup != nil {
+				tt.setup(env)
+			}
+
+			if err := env.handlers.Create(context.Background(), env.task); err == nil {
+				t.Fatal("Create succeeded")
+			}
+			if vm, ok := env.srv.GetVM(testNode.Name, testVMID); ok {
+				t.Fatalf("vm left after failed create: %+v", vm)
+			}
+			if env.vdsRepo.vds.Status != models.VDSStatusError {
+				t.Fatalf("vds status = %s, want error", env.vdsRepo.vds.Status)
+			}
+
+			// После разового сбоя повтор задачи не упирается в VM от прошлой попытки
+			if tt.setup != nil {
+				if err := env.handlers.Create(context.Background(), env.task); err != nil {
+					t.Fatalf("retried Create: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name string
+		vm   string
+	}{
+		{name: "running vm", vm: "running"},
+		{name: "stopped vm", vm: "stopped"},
+		{name: "vm already absent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newHandlersEnv(t, models.VDSStatusDeleting, testPlan)
+			if tt.vm != "" {
+				env.withVM(t, tt.vm)
+			}
+
+			if err := env.handlers.Delete(context.Background(), env.task); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			if _, ok := env.srv.GetVM(testNode.Name, testVMID); ok {
+				t.Fatal("vm was not destroyed")
+			}
+			if !env.vdsRepo.deleted {
+				t.Fatal("vds was not marked deleted")
+			}
+		})
+	}
+}
+
+func TestPower(t *testing.T) {
+	tests := []struct {
+		name       string
+		vm         string
+		handler    func(h *VDSHandlers) HandlerFunc
+		fail       string
+		wantVM     string
+		wantStatus models.VDSStatus
+		wantErr    bool
+	}{
+		{name: "start", vm: "stopped", handler: func(h *VDSHandlers) HandlerFunc { return h.Start }, wantVM: "running", wantStatus: models.VDSStatusRunning},
+		{name: "stop", vm: "running", handler: func(h *VDSHandlers) HandlerFunc { return h.Stop }, wantVM: "stopped", wantStatus: models.VDSStatusStopped},
+		{name: "restart", vm: "running", handler: func(h *VDSHandlers) HandlerFunc { return h.Restart }, wantVM: "running", wantStatus: models.VDSStatusRunning},
+		{name: "poweroff", vm: "running", handler: func(h *VDSHandlers) HandlerFunc { return h.PowerOff }, wantVM: "stopped", wantStatus: models.VDSStatusStopped},
+		{
+			name:       "failed start keeps status",
+			vm:         "stopped",
+			handler:    func(h *VDSHandlers) HandlerFunc { return h.Start },
+			fail:       "start",
+			wantVM:     "stopped",
+			wantStatus: models.VDSStatusStopped,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := models.VDSStatusRunning
+			if tt.vm == "stopped" {
+				status = models.VDSStatusStopped
+			}
+			env := newHandlersEnv(t, status, testPlan)
+			env.withVM(t, tt.vm)
+			if tt.fail != "" {
+				env.srv.FailNext(tt.fail, "task failed")
+			}
+
+			err := tt.handler(env.handlers)(context.Background(), env.task)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if vm, _ := env.srv.GetVM(testNode.Name, testVMID); vm.Status != tt.wantVM {
+				t.Fatalf("vm status = %s, want %s", vm.Status, tt.wantVM)
+			}
+			if env.vdsRepo.vds.Status != tt.wantStatus {
+				t.Fatalf("vds status = %s, want %s", env.vdsRepo.vds.Status, tt.wantStatus)
+			}
+		})
+	}
+}
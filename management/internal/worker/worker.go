@@ -0,0 +1,292 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/makhtech/management/internal/clients/proxmox"
+	"github.com/makhtech/management/internal/domain/models"
+	"github.com/makhtech/management/internal/repository"
+)
+
+// completeTimeout время на запись результата задачи в БД после завершения обработчика
+const completeTimeout = 10 * time.Second
+
+// Handler выполняет задачу определённого типа.
+// Возвращённая ошибка сохраняется в задаче, сама задача переводится в error
+type Handler interface {
+	Handle(ctx context.Context, task *models.Task) error
+}
+
+// HandlerFunc позволяет использовать обычную функцию как Handler
+type HandlerFunc func(ctx context.Context, task *models.Task) error
+
+// Handle вызывает f(ctx, task)
+func (f HandlerFunc) Handle(ctx context.Context, task *models.Task) error {
+	return f(ctx, task)
+}
+
+// CompletionFunc вызывается после записи результата задачи в БД.
+// task содержит итоговый статус задачи
+type CompletionFunc func(ctx context.Context, task *models.Task)
+
+// Config конфигурация пула воркеров
+type Config struct {
+	// Concurrency количество задач, обрабатываемых одновременно
+	Concurrency int
+	// PollInterval пауза между опросами очереди, когда задач нет
+	PollInterval time.Duration
+	// TaskTimeout максимальное время выполнения одной задачи
+	TaskTimeout time.Duration
+}
+
+// Pool забирает pending задачи из очереди и передаёт их обработчикам по типу задачи.
+// Задачи выбираются через FOR UPDATE SKIP LOCKED, поэтому несколько реплик сервиса
+// могут работать с одной очередью одновременно
+type Pool struct {
+	taskRepo repository.TaskRepository
+	cfg      Config
+	log      *slog.Logger
+
+	mu          sync.RWMutex
+	handlers    map[models.TaskType]Handler
+	completions []CompletionFunc
+
+	cancel context.CancelFunc
+	// taskCtx родительский контекст задач, abort отменяет его, когда Stop не дождался задач
+	taskCtx context.Context
+	abort   context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// New создаёт пул воркеров. Обработчики регистрируются через Register до вызова Start
+func New(taskRepo repository.TaskRepository, cfg Config, log *slog.Logger) *Pool {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 2 * time.Second
+	}
+	if cfg.TaskTimeout <= 0 {
+		cfg.TaskTimeout = 10 * time.Minute
+	}
+
+	return &Pool{
+		taskRepo: taskRepo,
+		cfg:      cfg,
+		log:      log,
+		handlers: make(map[models.TaskType]Handler),
+	}
+}
+
+// Register назначает обработчик для типа задачи
+func (p *Pool) Register(taskType models.TaskType, h Handler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.handlers[taskType] = h
+}
+
+// OnComplete добавляет функцию, вызываемую после завершения каждой задачи
+func (p *Pool) OnComplete(fn CompletionFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.completions = append(p.completions, fn)
+}
+
+// Start запускает воркеры и фоновую очистку зависших задач
+func (p *Pool) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.taskCtx, p.abort = context.WithCancel(context.Background())
+
+	for i := 0; i < p.cfg.Concurrency; i++ {
+		p.wg.Add(1)
+		go p.run(ctx, i)
+	}
+
+	p.wg.Add(1)
+	go p.reapStale(ctx)
+
+	p.log.Info("task worker pool started",
+		slog.Int("concurrency", p.cfg.Concurrency),
+		slog.Duration("poll_interval", p.cfg.PollInterval),
+		slog.Duration("task_timeout", p.cfg.TaskTimeout),
+	)
+}
+
+// Stop прекращает выбор новых задач и дожидается завершения уже запущенных.
+// Если ctx истёк раньше, контексты задач отменяются, и прерванные задачи
+// записываются с ошибкой, пока соединение с БД ещё открыто
+func (p *Pool) Stop(ctx context.Context) {
+	if p.cancel == nil {
+		return
+	}
+	p.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		p.log.Warn("shutdown deadline reached, aborting running tasks")
+		p.abort()
+		<-done
+	}
+	p.abort()
+
+	p.log.Info("task worker pool stopped")
+}
+
+// run основной цикл воркера: забирает задачи, пока они есть, иначе ждёт PollInterval
+func (p *Pool) run(ctx context.Context, id int) {
+	defer p.wg.Done()
+
+	log := p.log.With(slog.Int("worker", id))
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		task, err := p.taskRepo.ClaimNext(ctx)
+		if err != nil {
+			if !errors.Is(err, repository.ErrNoPendingTasks) && ctx.Err() == nil {
+				log.Error("failed to claim task", slog.String("error", err.Error()))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(p.cfg.PollInterval):
+			}
+			continue
+		}
+
+		p.process(log, task)
+	}
+}
+
+// process выполняет задачу и записывает результат.
+// Контекст задачи не зависит от цикла выбора задач, чтобы Stop дожидался завершения текущих задач,
+// и отменяется только по дедлайну остановки
+func (p *Pool) process(log *slog.Logger, task *models.Task) {
+	log = log.With(
+		slog.Int("task_id", int(task.ID)),
+		slog.Int("vds_id", int(task.VDSID)),
+		slog.String("type", string(task.Type)),
+	)
+	log.Info("processing task")
+
+	ctx, cancel := context.WithTimeout(p.taskCtx, p.cfg.TaskTimeout)
+	err := p.handle(ctx, task)
+	cancel()
+	if err != nil && p.taskCtx.Err() != nil {
+		// Операция на стороне Proxmox могла успеть выполниться, но дождаться её нельзя
+		err = fmt.Errorf("task interrupted by service shutdown: %w", err)
+	}
+
+	req := &models.UpdateTaskStatusRequest{
+		ID:     task.ID,
+		Status: models.TaskStatusDone,
+	}
+	if err != nil {
+		msg := err.Error()
+		req.Status = models.TaskStatusError
+		req.Error = &msg
+		req.ErrorCode = errorCode(err)
+		log.Warn("task failed", slog.String("error", msg))
+	}
+
+	// Результат записывается и после отмены задачи остановкой
+	ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), completeTimeout)
+	defer cancel()
+
+	completed, err := p.taskRepo.UpdateStatus(ctx, req)
+	if err != nil {
+		log.Error("failed to record task result", slog.String("error", err.Error()))
+		return
+	}
+
+	if req.Status == models.TaskStatusDone {
+		log.Info("task completed successfully")
+	}
+
+	p.complete(completed)
+}
+
+// complete вызывает функции, подписанные через OnComplete
+func (p *Pool) complete(task *models.Task) {
+	p.mu.RLock()
+	completions := p.completions
+	p.mu.RUnlock()
+
+	for _, fn := range completions {
+		ctx, cancel := context.WithTimeout(context.Background(), completeTimeout)
+		fn(ctx, task)
+		cancel()
+	}
+}
+
+// handle находит обработчик задачи и вызывает его, превращая панику в ошибку
+func (p *Pool) handle(ctx context.Context, task *models.Task) (err error) {
+	p.mu.RLock()
+	h, ok := p.handlers[task.Type]
+	p.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("no handler registered for task type %q", task.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panic: %v", r)
+		}
+	}()
+
+	return h.Handle(ctx, task)
+}
+
+// errorCode определяет класс ошибки задачи для клиента
+func errorCode(err error) models.TaskErrorCode {
+	if errors.Is(err, proxmox.ErrProxmox) {
+		return models.TaskErrorCodeProxmox
+	}
+	return models.TaskErrorCodeInternal
+}
+
+// reapStale периодически завершает с ошибкой задачи, которые слишком долго висят в running.
+// Такое возможно, если реплика упала посреди обработки и не записала результат
+func (p *Pool) reapStale(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.cfg.TaskTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Запас по времени, чтобы не задеть задачи, которые ещё выполняются на живом воркере
+			failed, err := p.taskRepo.FailStale(ctx, 2*p.cfg.TaskTimeout)
+			if err != nil {
+				if ctx.Err() == nil {
+					p.log.Error("failed to reap stale tasks", slog.String("error", err.Error()))
+				}
+				continue
+			}
+			if failed > 0 {
+				p.log.Warn("stale tasks marked as failed", slog.Int64("count", failed))
+			}
+		}
+	}
+}
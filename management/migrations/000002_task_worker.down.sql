@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_tasks_vds_status;
+DROP INDEX IF EXISTS idx_tasks_pending;
+
+UPDATE vds SET status = 'deleting' WHERE status = 'deleted';
+
+ALTER TABLE vds DROP CONSTRAINT vds_status_check;
+ALTER TABLE vds ADD CONSTRAINT vds_status_check CHECK (
+    status IN ('creating', 'running', 'stopped', 'error', 'deleting')
+    );
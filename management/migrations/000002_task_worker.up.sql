@@ -0,0 +1,14 @@
+-- ============================================================================
+-- TASK WORKER
+-- ============================================================================
+
+-- Терминальный статус VDS: VM удалена обработчиком задачи delete,
+-- строка остаётся для истории задач и биллинга
+ALTER TABLE vds DROP CONSTRAINT vds_status_check;
+ALTER TABLE vds ADD CONSTRAINT vds_status_check CHECK (
+    status IN ('creating', 'running', 'stopped', 'error', 'deleting', 'deleted')
+    );
+
+-- Индекс для выборки задач воркерами (SELECT ... FOR UPDATE SKIP LOCKED)
+CREATE INDEX idx_tasks_pending ON tasks(created_at, id) WHERE status = 'pending';
+CREATE INDEX idx_tasks_vds_status ON tasks(vds_id, status);
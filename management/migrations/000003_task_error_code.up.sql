@@ -0,0 +1,4 @@
+-- Класс ошибки задачи, чтобы клиент мог отличить сбой Proxmox от внутренней ошибки
+ALTER TABLE tasks ADD COLUMN error_code VARCHAR(32);
+
+COMMENT ON COLUMN tasks.error_code IS 'Error class for failed tasks: internal, proxmox_error';
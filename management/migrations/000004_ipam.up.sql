@@ -0,0 +1,83 @@
+-- ============================================================================
+-- IPAM: пулы IPv4/IPv6 адресов нод
+-- ============================================================================
+CREATE TABLE ip_pools (
+                          id SERIAL PRIMARY KEY,
+                          node_id INTEGER NOT NULL REFERENCES nodes(id) ON DELETE RESTRICT,
+                          family SMALLINT NOT NULL CHECK (family IN (4, 6)),
+                          subnet CIDR NOT NULL,
+                          gateway INET NOT NULL,
+                          is_active BOOLEAN NOT NULL DEFAULT true,
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                          CONSTRAINT ip_pools_family_check CHECK (family(subnet) = family),
+                          CONSTRAINT ip_pools_gateway_check CHECK (gateway << subnet),
+                          -- Подсети не пересекаются ни на одной ноде, поэтому адрес однозначно определяет пул
+                          CONSTRAINT ip_pools_subnet_excl EXCLUDE USING gist (subnet inet_ops WITH &&)
+);
+
+CREATE INDEX idx_ip_pools_node_family ON ip_pools(node_id, family) WHERE is_active = true;
+
+COMMENT ON TABLE ip_pools IS 'IPv4/IPv6 subnets available for VDS on a node';
+
+CREATE TABLE ip_pool_reserved_ranges (
+                                         id SERIAL PRIMARY KEY,
+                                         pool_id INTEGER NOT NULL REFERENCES ip_pools(id) ON DELETE CASCADE,
+                                         start_ip INET NOT NULL,
+                                         end_ip INET NOT NULL,
+                                         description VARCHAR(255) NOT NULL DEFAULT '',
+
+                                         CONSTRAINT ip_pool_reserved_ranges_order_check CHECK (start_ip <= end_ip)
+);
+
+CREATE INDEX idx_ip_pool_reserved_ranges_pool_id ON ip_pool_reserved_ranges(pool_id);
+
+COMMENT ON TABLE ip_pool_reserved_ranges IS 'Address ranges inside a pool that are never handed out';
+
+CREATE TABLE ip_allocations (
+                                id SERIAL PRIMARY KEY,
+                                pool_id INTEGER NOT NULL REFERENCES ip_pools(id) ON DELETE RESTRICT,
+                                vds_id INTEGER NOT NULL REFERENCES vds(id) ON DELETE CASCADE,
+                                address INET NOT NULL UNIQUE,
+                                allocated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_ip_allocations_pool_id ON ip_allocations(pool_id);
+CREATE INDEX idx_ip_allocations_vds_id ON ip_allocations(vds_id);
+
+COMMENT ON TABLE ip_allocations IS 'Addresses currently assigned to VDS';
+
+-- ============================================================================
+-- SEED DATA: пулы демо нод
+-- ============================================================================
+INSERT INTO ip_pools (node_id, family, subnet, gateway)
+SELECT n.id, p.family, p.subnet::cidr, p.gateway::inet
+FROM (VALUES
+          ('node-eu-01', 4, '185.22.45.0/24', '185.22.45.1'),
+          ('node-eu-01', 6, '2a01:4f8:c17:1b4::/64', '2a01:4f8:c17:1b4::fffe'),
+          ('node-eu-02', 4, '195.88.73.0/24', '195.88.73.1'),
+          ('node-eu-02', 6, '2a01:4f9:2b:3c1::/64', '2a01:4f9:2b:3c1::fffe'),
+          ('node-us-01', 4, '192.168.100.0/24', '192.168.100.1'),
+          ('node-us-01', 6, '2001:db8:1234:5678::/64', '2001:db8:1234:5678::fffe'),
+          ('node-asia-01', 4, '103.28.54.0/24', '103.28.54.1'),
+          ('node-asia-01', 6, '2404:6800:4003:c00::/64', '2404:6800:4003:c00::fffe')
+     ) AS p(node_name, family, subnet, gateway)
+         JOIN nodes n ON n.name = p.node_name;
+
+INSERT INTO ip_pool_reserved_ranges (pool_id, start_ip, end_ip, description)
+SELECT id, '185.22.45.2', '185.22.45.9', 'network equipment'
+FROM ip_pools
+WHERE subnet = '185.22.45.0/24';
+
+-- Переносим уже назначенные адреса VDS в ip_allocations, чтобы пулы их не выдали повторно
+INSERT INTO ip_allocations (pool_id, vds_id, address)
+SELECT p.id, v.id, v.ipv4
+FROM vds v
+         JOIN ip_pools p ON p.node_id = v.node_id AND v.ipv4 << p.subnet
+WHERE v.status <> 'deleted'
+UNION ALL
+SELECT p.id, v.id, v.ipv6
+FROM vds v
+         JOIN ip_pools p ON p.node_id = v.node_id AND v.ipv6 << p.subnet
+WHERE v.status <> 'deleted'
+ON CONFLICT (address) DO NOTHING;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_nodes_labels;
+DROP INDEX IF EXISTS idx_nodes_region;
+
+ALTER TABLE nodes DROP COLUMN IF EXISTS labels;
+ALTER TABLE nodes DROP COLUMN IF EXISTS region;
@@ -0,0 +1,17 @@
+-- ============================================================================
+-- Размещение VDS: регион и метки нод для ограничений автоматического выбора
+-- ============================================================================
+ALTER TABLE nodes ADD COLUMN region VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE nodes ADD COLUMN labels JSONB NOT NULL DEFAULT '{}'::jsonb
+    CONSTRAINT nodes_labels_object CHECK (jsonb_typeof(labels) = 'object');
+
+CREATE INDEX idx_nodes_region ON nodes(region) WHERE is_active = true;
+CREATE INDEX idx_nodes_labels ON nodes USING gin (labels);
+
+COMMENT ON COLUMN nodes.region IS 'Region used by VDS placement constraints';
+COMMENT ON COLUMN nodes.labels IS 'Free-form key/value labels used by VDS placement constraints';
+
+UPDATE nodes SET region = 'eu' WHERE name LIKE 'node-eu-%';
+UPDATE nodes SET region = 'us' WHERE name LIKE 'node-us-%';
+UPDATE nodes SET region = 'asia' WHERE name LIKE 'node-asia-%';
+UPDATE nodes SET labels = '{"env": "dev"}' WHERE name LIKE 'node-dev-%';
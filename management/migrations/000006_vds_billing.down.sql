@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_vds_billing_reserved;
+DROP INDEX IF EXISTS idx_vds_reservation_id;
+
+ALTER TABLE vds DROP COLUMN IF EXISTS billing_status;
+ALTER TABLE vds DROP COLUMN IF EXISTS billing_amount;
+ALTER TABLE vds DROP COLUMN IF EXISTS reservation_id;
@@ -0,0 +1,15 @@
+-- ============================================================================
+-- Оплата VDS: резервирование средств в SSO и его итог
+-- ============================================================================
+ALTER TABLE vds ADD COLUMN reservation_id VARCHAR(64);
+ALTER TABLE vds ADD COLUMN billing_amount BIGINT CHECK (billing_amount >= 0);
+ALTER TABLE vds ADD COLUMN billing_status VARCHAR(16)
+    CHECK (billing_status IN ('reserved', 'committed', 'cancelled'));
+
+-- Одно резервирование оплачивает ровно одну VDS, повтор запроса с тем же ключом находит её
+CREATE UNIQUE INDEX idx_vds_reservation_id ON vds(reservation_id) WHERE reservation_id IS NOT NULL;
+CREATE INDEX idx_vds_billing_reserved ON vds(id) WHERE billing_status = 'reserved';
+
+COMMENT ON COLUMN vds.reservation_id IS 'SSO balance reservation paying for the VDS';
+COMMENT ON COLUMN vds.billing_amount IS 'Reserved amount in minor currency units (kopecks)';
+COMMENT ON COLUMN vds.billing_status IS 'Reservation outcome: reserved, committed, cancelled';
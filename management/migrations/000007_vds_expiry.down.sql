@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS vds_events;
+
+ALTER TABLE vds DROP COLUMN IF EXISTS suspended_at;
@@ -0,0 +1,23 @@
+-- ============================================================================
+-- Истечение подписки VDS: приостановка неоплаченных VDS и журнал жизненного цикла
+-- ============================================================================
+ALTER TABLE vds ADD COLUMN suspended_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN vds.suspended_at IS 'When the VDS was stopped for non-payment, NULL if not suspended';
+
+CREATE TABLE vds_events (
+                            id BIGSERIAL PRIMARY KEY,
+                            vds_id INTEGER NOT NULL REFERENCES vds(id) ON DELETE CASCADE,
+                            type VARCHAR(32) NOT NULL CHECK (type IN (
+                                'renewed', 'renewal_failed', 'suspended', 'resumed', 'deletion_queued'
+                            )),
+                            message TEXT NOT NULL,
+                            details JSONB NOT NULL DEFAULT '{}'::jsonb,
+                            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_vds_events_vds_id ON vds_events(vds_id, id);
+-- Поиск последней неудачной попытки продления
+CREATE INDEX idx_vds_events_renewal_failed ON vds_events(vds_id, created_at) WHERE type = 'renewal_failed';
+
+COMMENT ON TABLE vds_events IS 'Subscription lifecycle audit trail: renewals, suspensions, expiry deletions';
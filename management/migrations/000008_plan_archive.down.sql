@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_plans_deleted_at;
+
+DROP INDEX IF EXISTS idx_plans_price;
+CREATE INDEX idx_plans_price ON plans(price_month) WHERE is_active = true;
+
+ALTER TABLE plans DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Архив тарифов: тариф, на который ссылаются VDS, не удаляется, а скрывается
+-- из продажи и остаётся для истории биллинга и продлений
+-- ============================================================================
+ALTER TABLE plans ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN plans.deleted_at IS 'When the plan was archived, NULL if the plan is not archived';
+
+DROP INDEX IF EXISTS idx_plans_price;
+CREATE INDEX idx_plans_price ON plans(price_month) WHERE is_active = true AND deleted_at IS NULL;
+CREATE INDEX idx_plans_deleted_at ON plans(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_vds_created;
+DROP INDEX IF EXISTS idx_vds_user_id_id;
+DROP INDEX IF EXISTS idx_vds_user_created;
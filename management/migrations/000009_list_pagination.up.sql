@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Индексы под keyset-пагинацию списков: (фильтр, поле сортировки, id)
+-- ============================================================================
+CREATE INDEX idx_vds_user_created ON vds(user_id, created_at, id);
+CREATE INDEX idx_vds_user_id_id ON vds(user_id, id);
+CREATE INDEX idx_tasks_vds_created ON tasks(vds_id, created_at, id);
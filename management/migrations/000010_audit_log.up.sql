@@ -0,0 +1,37 @@
+-- ============================================================================
+-- Журнал аудита изменяющих вызовов Management API. Только добавление записей
+-- ============================================================================
+CREATE TABLE audit_log (
+                           id BIGSERIAL PRIMARY KEY,
+                           actor_id BIGINT,
+                           actor_name VARCHAR(255) NOT NULL DEFAULT '',
+                           actor_role VARCHAR(32) NOT NULL DEFAULT '',
+                           method VARCHAR(128) NOT NULL,
+                           entity_type VARCHAR(32) NOT NULL,
+                           entity_id BIGINT,
+                           diff JSONB NOT NULL DEFAULT '{}'::jsonb,
+                           request_id VARCHAR(128) NOT NULL DEFAULT '',
+                           client_ip INET,
+                           code VARCHAR(32) NOT NULL,
+                           created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_log_actor ON audit_log(actor_id, created_at, id);
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id, created_at, id);
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at, id);
+
+COMMENT ON TABLE audit_log IS 'Append-only audit trail of mutating Management API calls';
+COMMENT ON COLUMN audit_log.diff IS 'Changed fields: {"field": {"old": ..., "new": ...}}';
+COMMENT ON COLUMN audit_log.code IS 'gRPC status code of the call';
+
+-- Записи журнала нельзя изменить или удалить
+CREATE OR REPLACE FUNCTION audit_log_append_only()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,22 @@
+-- ============================================================================
+-- Ключи идемпотентности изменяющих вызовов: повтор запроса с тем же ключом
+-- получает сохранённый ответ вместо повторного выполнения
+-- ============================================================================
+CREATE TABLE idempotency_keys (
+                                  user_id BIGINT NOT NULL,
+                                  key VARCHAR(255) NOT NULL,
+                                  method VARCHAR(128) NOT NULL,
+                                  request_hash CHAR(64) NOT NULL,
+                                  status VARCHAR(16) NOT NULL DEFAULT 'in_progress' CHECK (status IN ('in_progress', 'completed')),
+                                  response BYTEA,
+                                  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                  expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                                  PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+COMMENT ON TABLE idempotency_keys IS 'Stored responses of mutating calls keyed by the idempotency-key header';
+COMMENT ON COLUMN idempotency_keys.user_id IS 'Key owner, keys of different users never collide';
+COMMENT ON COLUMN idempotency_keys.request_hash IS 'SHA-256 of the method and the request, a reused key with another payload is rejected';
+COMMENT ON COLUMN idempotency_keys.response IS 'Serialized protobuf response, NULL while the call is in progress';
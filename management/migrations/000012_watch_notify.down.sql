@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS vds_notify_status ON vds;
+DROP FUNCTION IF EXISTS notify_vds_status();
+DROP TRIGGER IF EXISTS tasks_notify_status ON tasks;
+DROP FUNCTION IF EXISTS notify_task_status();
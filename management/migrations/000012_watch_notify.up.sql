@@ -0,0 +1,31 @@
+-- ============================================================================
+-- Уведомления об изменении статусов задач и VDS для WatchTask / WatchVDS.
+-- Payload - ID строки, подписчики перечитывают её сами
+-- ============================================================================
+CREATE OR REPLACE FUNCTION notify_task_status()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('task_status', NEW.id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_notify_status
+    AFTER UPDATE OF status ON tasks
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION notify_task_status();
+
+CREATE OR REPLACE FUNCTION notify_vds_status()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('vds_status', NEW.id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER vds_notify_status
+    AFTER UPDATE OF status ON vds
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION notify_vds_status();
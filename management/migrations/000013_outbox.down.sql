@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS plans_outbox ON plans;
+DROP FUNCTION IF EXISTS outbox_plan_change();
+DROP FUNCTION IF EXISTS outbox_plan_payload(plans);
+DROP TRIGGER IF EXISTS tasks_outbox ON tasks;
+DROP FUNCTION IF EXISTS outbox_task_change();
+DROP TRIGGER IF EXISTS vds_events_outbox ON vds_events;
+DROP FUNCTION IF EXISTS outbox_vds_event();
+DROP TRIGGER IF EXISTS vds_outbox ON vds;
+DROP FUNCTION IF EXISTS outbox_vds_change();
+DROP FUNCTION IF EXISTS outbox_vds_payload(vds);
+DROP FUNCTION IF EXISTS outbox_emit(VARCHAR, VARCHAR, BIGINT, VARCHAR, JSONB);
+DROP TABLE IF EXISTS outbox;
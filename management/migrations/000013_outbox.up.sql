@@ -0,0 +1,181 @@
+-- ============================================================================
+-- Transactional outbox: доменные события VDS, задач и тарифов. Пишутся триггерами
+-- в той же транзакции, что и изменение, и публикуются relay не меньше одного раза
+-- ============================================================================
+CREATE TABLE outbox (
+                        id BIGSERIAL PRIMARY KEY,
+                        event_type VARCHAR(64) NOT NULL,
+                        aggregate_type VARCHAR(32) NOT NULL,
+                        aggregate_id BIGINT NOT NULL,
+                        ordering_key VARCHAR(64) NOT NULL,
+                        payload JSONB NOT NULL,
+                        created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                        published_at TIMESTAMP WITH TIME ZONE,
+                        attempts INTEGER NOT NULL DEFAULT 0,
+                        last_error TEXT,
+                        next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                        locked_until TIMESTAMP WITH TIME ZONE
+);
+
+-- Очередь неопубликованных событий и голова каждого ordering_key
+CREATE INDEX idx_outbox_unpublished ON outbox(id) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_ordering_key ON outbox(ordering_key, id) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_published_at ON outbox(published_at) WHERE published_at IS NOT NULL;
+
+COMMENT ON TABLE outbox IS 'Domain events written in the same transaction as the change, published at least once';
+COMMENT ON COLUMN outbox.ordering_key IS 'Events with the same key are published strictly in id order (vds:<id>, plan:<id>)';
+COMMENT ON COLUMN outbox.locked_until IS 'Lease of the relay replica that is publishing the event';
+
+CREATE OR REPLACE FUNCTION outbox_emit(
+    p_event_type VARCHAR,
+    p_aggregate_type VARCHAR,
+    p_aggregate_id BIGINT,
+    p_ordering_key VARCHAR,
+    p_payload JSONB
+) RETURNS VOID AS $$
+BEGIN
+    INSERT INTO outbox (event_type, aggregate_type, aggregate_id, ordering_key, payload)
+    VALUES (p_event_type, p_aggregate_type, p_aggregate_id, p_ordering_key, p_payload);
+END;
+$$ LANGUAGE plpgsql;
+
+-- ============================================================================
+-- VDS
+-- ============================================================================
+CREATE OR REPLACE FUNCTION outbox_vds_payload(v vds)
+RETURNS JSONB AS $$
+    SELECT jsonb_build_object(
+        'id', v.id,
+        'user_id', v.user_id,
+        'plan_id', v.plan_id,
+        'node_id', v.node_id,
+        'status', v.status,
+        'ipv4', host(v.ipv4),
+        'ipv6', host(v.ipv6),
+        'created_at', v.created_at,
+        'expires_at', v.expires_at,
+        'suspended_at', v.suspended_at
+    );
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION outbox_vds_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM outbox_emit('VDSCreated', 'vds', NEW.id, 'vds:' || NEW.id, outbox_vds_payload(NEW));
+    ELSIF OLD.status IS DISTINCT FROM NEW.status THEN
+        PERFORM outbox_emit('VDSStatusChanged', 'vds', NEW.id, 'vds:' || NEW.id,
+                            outbox_vds_payload(NEW) || jsonb_build_object('old_status', OLD.status));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER vds_outbox
+    AFTER INSERT OR UPDATE OF status ON vds
+    FOR EACH ROW EXECUTE FUNCTION outbox_vds_change();
+
+-- События подписки уже записываются в vds_events: VDSRenewed, VDSSuspended и т.д.
+CREATE OR REPLACE FUNCTION outbox_vds_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM outbox_emit(
+        'VDS' || replace(initcap(replace(NEW.type, '_', ' ')), ' ', ''),
+        'vds', NEW.vds_id, 'vds:' || NEW.vds_id,
+        jsonb_build_object(
+            'vds_id', NEW.vds_id,
+            'event_id', NEW.id,
+            'message', NEW.message,
+            'details', NEW.details,
+            'created_at', NEW.created_at
+        ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER vds_events_outbox
+    AFTER INSERT ON vds_events
+    FOR EACH ROW EXECUTE FUNCTION outbox_vds_event();
+
+-- ============================================================================
+-- TASKS: упорядочены вместе с событиями своей VDS
+-- ============================================================================
+CREATE OR REPLACE FUNCTION outbox_task_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    event_type VARCHAR;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        event_type := 'TaskCreated';
+    ELSIF OLD.status IS DISTINCT FROM NEW.status THEN
+        event_type := CASE NEW.status
+            WHEN 'running' THEN 'TaskStarted'
+            WHEN 'done' THEN 'TaskCompleted'
+            WHEN 'error' THEN 'TaskFailed'
+        END;
+    END IF;
+
+    IF event_type IS NOT NULL THEN
+        PERFORM outbox_emit(event_type, 'task', NEW.id, 'vds:' || NEW.vds_id, jsonb_build_object(
+            'id', NEW.id,
+            'vds_id', NEW.vds_id,
+            'type', NEW.type,
+            'status', NEW.status,
+            'error', NEW.error,
+            'error_code', NEW.error_code,
+            'created_at', NEW.created_at,
+            'started_at', NEW.started_at,
+            'completed_at', NEW.completed_at
+        ));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_outbox
+    AFTER INSERT OR UPDATE OF status ON tasks
+    FOR EACH ROW EXECUTE FUNCTION outbox_task_change();
+
+-- ============================================================================
+-- PLANS
+-- ============================================================================
+CREATE OR REPLACE FUNCTION outbox_plan_payload(p plans)
+RETURNS JSONB AS $$
+    SELECT jsonb_build_object(
+        'id', p.id,
+        'name', p.name,
+        'cpu', p.cpu,
+        'ram_mb', p.ram_mb,
+        'disk_gb', p.disk_gb,
+        'price_month', p.price_month,
+        'is_active', p.is_active,
+        'deleted_at', p.deleted_at
+    );
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION outbox_plan_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM outbox_emit('PlanCreated', 'plan', NEW.id, 'plan:' || NEW.id, outbox_plan_payload(NEW));
+    ELSIF TG_OP = 'DELETE' THEN
+        PERFORM outbox_emit('PlanDeleted', 'plan', OLD.id, 'plan:' || OLD.id, outbox_plan_payload(OLD));
+    ELSE
+        IF OLD.price_month IS DISTINCT FROM NEW.price_month THEN
+            PERFORM outbox_emit('PlanPriceChanged', 'plan', NEW.id, 'plan:' || NEW.id,
+                                outbox_plan_payload(NEW) || jsonb_build_object('old_price_month', OLD.price_month));
+        END IF;
+        IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            PERFORM outbox_emit('PlanArchived', 'plan', NEW.id, 'plan:' || NEW.id, outbox_plan_payload(NEW));
+        ELSIF (OLD.name, OLD.cpu, OLD.ram_mb, OLD.disk_gb, OLD.is_active)
+              IS DISTINCT FROM (NEW.name, NEW.cpu, NEW.ram_mb, NEW.disk_gb, NEW.is_active) THEN
+            PERFORM outbox_emit('PlanUpdated', 'plan', NEW.id, 'plan:' || NEW.id, outbox_plan_payload(NEW));
+        END IF;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER plans_outbox
+    AFTER INSERT OR UPDATE OR DELETE ON plans
+    FOR EACH ROW EXECUTE FUNCTION outbox_plan_change();
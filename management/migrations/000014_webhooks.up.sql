@@ -0,0 +1,45 @@
+-- ============================================================================
+-- Подписки на доменные события и журнал их доставки
+-- ============================================================================
+CREATE TABLE webhooks (
+                          id SERIAL PRIMARY KEY,
+                          user_id INTEGER NOT NULL,
+                          url VARCHAR(2048) NOT NULL,
+                          secret VARCHAR(255) NOT NULL,
+                          event_types TEXT[] NOT NULL DEFAULT '{}',
+                          is_active BOOLEAN NOT NULL DEFAULT true,
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                          updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhooks_user_id ON webhooks(user_id, id);
+CREATE INDEX idx_webhooks_active ON webhooks(id) WHERE is_active = true;
+
+COMMENT ON TABLE webhooks IS 'Subscriptions to domain events delivered by signed HTTP POST';
+COMMENT ON COLUMN webhooks.user_id IS 'Owner: receives events of own VDS and tasks and public plan events';
+COMMENT ON COLUMN webhooks.secret IS 'HMAC-SHA256 key of the X-Webhook-Signature header';
+COMMENT ON COLUMN webhooks.event_types IS 'Event types to deliver, empty - all events';
+
+CREATE TABLE webhook_deliveries (
+                                    id BIGSERIAL PRIMARY KEY,
+                                    webhook_id INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+                                    event_id BIGINT NOT NULL,
+                                    event_type VARCHAR(64) NOT NULL,
+                                    payload JSONB NOT NULL,
+                                    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+                                    attempts INTEGER NOT NULL DEFAULT 0,
+                                    last_status_code INTEGER,
+                                    last_error TEXT,
+                                    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                    locked_until TIMESTAMP WITH TIME ZONE,
+                                    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                    delivered_at TIMESTAMP WITH TIME ZONE,
+
+                                    CONSTRAINT unique_webhook_event UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at, id);
+
+COMMENT ON TABLE webhook_deliveries IS 'Delivery log of webhook events, dead - retries are exhausted';
+COMMENT ON COLUMN webhook_deliveries.event_id IS 'Outbox event id, a repeated publication does not create a second delivery';
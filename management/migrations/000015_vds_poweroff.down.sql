@@ -0,0 +1,9 @@
+-- Задачи poweroff не проходят прежний CHECK
+DELETE FROM tasks WHERE type = 'poweroff';
+
+ALTER TABLE tasks DROP CONSTRAINT tasks_type_check;
+ALTER TABLE tasks ADD CONSTRAINT tasks_type_check CHECK (
+    type IN ('create', 'delete', 'start', 'stop', 'restart')
+    );
+
+COMMENT ON COLUMN tasks.type IS 'Type of operation to perform';
@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Принудительное выключение VDS: задача poweroff
+-- ============================================================================
+ALTER TABLE tasks DROP CONSTRAINT tasks_type_check;
+ALTER TABLE tasks ADD CONSTRAINT tasks_type_check CHECK (
+    type IN ('create', 'delete', 'start', 'stop', 'restart', 'poweroff')
+    );
+
+COMMENT ON COLUMN tasks.type IS 'Type of operation to perform: stop - ACPI shutdown, poweroff - immediate stop';
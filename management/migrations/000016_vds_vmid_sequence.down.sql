@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_vds_proxmox_vm_id;
+ALTER TABLE vds ADD CONSTRAINT unique_proxmox_vm UNIQUE (node_id, proxmox_vm_id);
+
+DROP SEQUENCE IF EXISTS vds_proxmox_vm_id_seq;
+
+COMMENT ON COLUMN vds.proxmox_vm_id IS 'VM ID in Proxmox';
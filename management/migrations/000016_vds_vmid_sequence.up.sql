@@ -0,0 +1,11 @@
+-- ============================================================================
+-- VMID уникален во всём кластере Proxmox, а не на ноде: выдаём его из общей последовательности
+-- ============================================================================
+CREATE SEQUENCE vds_proxmox_vm_id_seq MINVALUE 100 OWNED BY vds.proxmox_vm_id;
+SELECT setval('vds_proxmox_vm_id_seq', GREATEST(COALESCE(MAX(proxmox_vm_id), 0) + 1, 100), false) FROM vds;
+
+ALTER TABLE vds DROP CONSTRAINT unique_proxmox_vm;
+-- VM удалённой VDS уничтожена, её номер больше не занят
+CREATE UNIQUE INDEX idx_vds_proxmox_vm_id ON vds(proxmox_vm_id) WHERE status <> 'deleted';
+
+COMMENT ON COLUMN vds.proxmox_vm_id IS 'VM ID in Proxmox, unique across the cluster';
@@ -0,0 +1,16 @@
+-- ============================================================================
+-- Оплаченные продления подписки VDS: резервирование списывается после продления,
+-- а если SSO был недоступен - сверкой биллинга
+-- ============================================================================
+CREATE TABLE vds_renewals (
+                              reservation_id VARCHAR(64) PRIMARY KEY,
+                              vds_id INTEGER NOT NULL REFERENCES vds(id) ON DELETE CASCADE,
+                              billing_status VARCHAR(16) NOT NULL DEFAULT 'reserved' CHECK (billing_status IN ('reserved', 'committed')),
+                              created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_vds_renewals_vds_id ON vds_renewals(vds_id);
+CREATE INDEX idx_vds_renewals_reserved ON vds_renewals(created_at) WHERE billing_status = 'reserved';
+
+COMMENT ON TABLE vds_renewals IS 'SSO reservations paying for VDS subscription renewals';
+COMMENT ON COLUMN vds_renewals.billing_status IS 'Reservation outcome: reserved until the payment is committed';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_vds_auto_renew;
+
+ALTER TABLE vds DROP COLUMN IF EXISTS renew_attempted_at;
+ALTER TABLE vds DROP COLUMN IF EXISTS auto_renew_token;
@@ -0,0 +1,10 @@
+-- ============================================================================
+-- Автопродление подписки VDS по сохранённой авторизации владельца
+-- ============================================================================
+ALTER TABLE vds ADD COLUMN auto_renew_token BYTEA;
+ALTER TABLE vds ADD COLUMN renew_attempted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_vds_auto_renew ON vds(expires_at) WHERE auto_renew_token IS NOT NULL;
+
+COMMENT ON COLUMN vds.auto_renew_token IS 'Encrypted SSO refresh token of the owner paying for auto-renewal, NULL - auto-renewal is off';
+COMMENT ON COLUMN vds.renew_attempted_at IS 'Last auto-renewal attempt, retries wait for expiry.retry_after';
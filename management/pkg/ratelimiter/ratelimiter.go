@@ -13,6 +13,10 @@ type TokenBucket struct {
 	rate     int           // количество токенов в секунду
 	capacity int           // максимальная ёмкость bucket
 	cleanup  time.Duration // интервал очистки неиспользуемых buckets
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
 }
 
 type bucket struct {
@@ -57,6 +61,8 @@ func New(cfg Config) *TokenBucket {
 		rate:     cfg.Rate,
 		capacity: cfg.Capacity,
 		cleanup:  cfg.CleanupInterval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
 	}
 
 	// Запускаем фоновую очистку неактивных buckets
@@ -111,13 +117,28 @@ func (tb *TokenBucket) Reset(accessToken string) {
 	delete(tb.buckets, accessToken)
 }
 
-// startCleanup запускает периодическую очистку неактивных buckets
+// Stop останавливает фоновую очистку buckets. Повторный вызов безопасен
+func (tb *TokenBucket) Stop() {
+	tb.stopOnce.Do(func() {
+		close(tb.stop)
+	})
+	<-tb.done
+}
+
+// startCleanup запускает периодическую очистку неактивных buckets до вызова Stop
 func (tb *TokenBucket) startCleanup() {
+	defer close(tb.done)
+
 	ticker := time.NewTicker(tb.cleanup)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		tb.cleanup_old_buckets()
+	for {
+		select {
+		case <-tb.stop:
+			return
+		case <-ticker.C:
+			tb.cleanup_old_buckets()
+		}
 	}
 }
 
@@ -0,0 +1,164 @@
+package ttlcache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Cache ограниченный по размеру LRU кэш, у каждой записи которого свой срок жизни
+type Cache[V any] struct {
+	mu       sync.Mutex
+	items    map[string]*list.Element
+	order    *list.List // от недавно использованных к давно использованным
+	capacity int
+	ttl      time.Duration
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+type entry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// Config конфигурация кэша
+type Config struct {
+	// Capacity максимальное количество записей, при переполнении вытесняется самая давняя
+	Capacity int
+	// TTL максимальный срок жизни записи
+	TTL time.Duration
+}
+
+// Stats счётчики кэша (для мониторинга)
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+}
+
+// New создаёт кэш
+func New[V any](cfg Config) *Cache[V] {
+	if cfg.Capacity <= 0 {
+		cfg.Capacity = 10000
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = time.Minute
+	}
+
+	return &Cache[V]{
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+		capacity: cfg.Capacity,
+		ttl:      cfg.TTL,
+	}
+}
+
+// Get возвращает значение, если запись есть и её срок не истёк
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+
+	el, ok := c.items[key]
+	if !ok {
+		c.misses.Add(1)
+		return zero, false
+	}
+
+	e := el.Value.(*entry[V])
+	if !time.Now().Before(e.expiresAt) {
+		c.remove(el)
+		c.misses.Add(1)
+		return zero, false
+	}
+
+	c.order.MoveToFront(el)
+	c.hits.Add(1)
+	return e.value, true
+}
+
+// Set сохраняет значение до expiresAt, но не дольше TTL кэша.
+// Нулевой expiresAt - запись живёт TTL. Уже истёкшая запись не сохраняется
+func (c *Cache[V]) Set(key string, value V, expiresAt time.Time) {
+	now := time.Now()
+	deadline := now.Add(c.ttl)
+	if !expiresAt.IsZero() && expiresAt.Before(deadline) {
+		deadline = expiresAt
+	}
+	if !deadline.After(now) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry[V])
+		e.value = value
+		e.expiresAt = deadline
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry[V]{key: key, value: value, expiresAt: deadline})
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+		c.evictions.Add(1)
+	}
+}
+
+// Delete удаляет запись
+func (c *Cache[V]) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+}
+
+// DeleteFunc удаляет все записи, для которых fn возвращает true
+func (c *Cache[V]) DeleteFunc(fn func(key string, value V) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		e := el.Value.(*entry[V])
+		if fn(e.key, e.value) {
+			c.remove(el)
+			removed++
+		}
+		el = next
+	}
+	return removed
+}
+
+// Stats возвращает счётчики попаданий и промахов и текущий размер кэша
+func (c *Cache[V]) Stats() Stats {
+	c.mu.Lock()
+	size := c.order.Len()
+	c.mu.Unlock()
+
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Size:      size,
+	}
+}
+
+func (c *Cache[V]) remove(el *list.Element) {
+	e := el.Value.(*entry[V])
+	delete(c.items, e.key)
+	c.order.Remove(el)
+}
@@ -38,6 +38,13 @@ const (
 	ErrorCode_ERROR_CODE_PROXMOX_ERROR          ErrorCode = 11
 	ErrorCode_ERROR_CODE_IP_ALLOCATION_FAILED   ErrorCode = 12
 	ErrorCode_ERROR_CODE_TASK_IN_PROGRESS       ErrorCode = 13
+	ErrorCode_ERROR_CODE_INSUFFICIENT_FUNDS     ErrorCode = 14
+	ErrorCode_ERROR_CODE_IP_POOL_IN_USE         ErrorCode = 15
+	ErrorCode_ERROR_CODE_VDS_SUSPENDED          ErrorCode = 16
+	ErrorCode_ERROR_CODE_INVALID_STATE          ErrorCode = 17
+	ErrorCode_ERROR_CODE_UNAUTHENTICATED        ErrorCode = 18
+	ErrorCode_ERROR_CODE_RATE_LIMITED           ErrorCode = 19
+	ErrorCode_ERROR_CODE_IDEMPOTENCY_CONFLICT   ErrorCode = 20
 )
 
 // Enum value maps for ErrorCode.
@@ -57,6 +64,13 @@ var (
 		11: "ERROR_CODE_PROXMOX_ERROR",
 		12: "ERROR_CODE_IP_ALLOCATION_FAILED",
 		13: "ERROR_CODE_TASK_IN_PROGRESS",
+		14: "ERROR_CODE_INSUFFICIENT_FUNDS",
+		15: "ERROR_CODE_IP_POOL_IN_USE",
+		16: "ERROR_CODE_VDS_SUSPENDED",
+		17: "ERROR_CODE_INVALID_STATE",
+		18: "ERROR_CODE_UNAUTHENTICATED",
+		19: "ERROR_CODE_RATE_LIMITED",
+		20: "ERROR_CODE_IDEMPOTENCY_CONFLICT",
 	}
 	ErrorCode_value = map[string]int32{
 		"ERROR_CODE_OK":                     0,
@@ -73,6 +87,13 @@ var (
 		"ERROR_CODE_PROXMOX_ERROR":          11,
 		"ERROR_CODE_IP_ALLOCATION_FAILED":   12,
 		"ERROR_CODE_TASK_IN_PROGRESS":       13,
+		"ERROR_CODE_INSUFFICIENT_FUNDS":     14,
+		"ERROR_CODE_IP_POOL_IN_USE":         15,
+		"ERROR_CODE_VDS_SUSPENDED":          16,
+		"ERROR_CODE_INVALID_STATE":          17,
+		"ERROR_CODE_UNAUTHENTICATED":        18,
+		"ERROR_CODE_RATE_LIMITED":           19,
+		"ERROR_CODE_IDEMPOTENCY_CONFLICT":   20,
 	}
 )
 
@@ -172,7 +193,7 @@ const file_management_errors_proto_rawDesc = "" +
 	"\fErrorDetails\x12)\n" +
 	"\x04code\x18\x01 \x01(\x0e2\x15.management.ErrorCodeR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
-	"\adetails\x18\x03 \x01(\tR\adetails*\xaa\x03\n" +
+	"\adetails\x18\x03 \x01(\tR\adetails*\x8a\x05\n" +
 	"\tErrorCode\x12\x11\n" +
 	"\rERROR_CODE_OK\x10\x00\x12\x16\n" +
 	"\x12ERROR_CODE_UNKNOWN\x10\x01\x12\x1f\n" +
@@ -188,7 +209,14 @@ const file_management_errors_proto_rawDesc = "" +
 	"\x12\x1c\n" +
 	"\x18ERROR_CODE_PROXMOX_ERROR\x10\v\x12#\n" +
 	"\x1fERROR_CODE_IP_ALLOCATION_FAILED\x10\f\x12\x1f\n" +
-	"\x1bERROR_CODE_TASK_IN_PROGRESS\x10\rBCZAgithub.com/makhkets/management/pkg/api/management/v1;managementv1b\x06proto3"
+	"\x1bERROR_CODE_TASK_IN_PROGRESS\x10\r\x12!\n" +
+	"\x1dERROR_CODE_INSUFFICIENT_FUNDS\x10\x0e\x12\x1d\n" +
+	"\x19ERROR_CODE_IP_POOL_IN_USE\x10\x0f\x12\x1c\n" +
+	"\x18ERROR_CODE_VDS_SUSPENDED\x10\x10\x12\x1c\n" +
+	"\x18ERROR_CODE_INVALID_STATE\x10\x11\x12\x1e\n" +
+	"\x1aERROR_CODE_UNAUTHENTICATED\x10\x12\x12\x1b\n" +
+	"\x17ERROR_CODE_RATE_LIMITED\x10\x13\x12#\n" +
+	"\x1fERROR_CODE_IDEMPOTENCY_CONFLICT\x10\x14BCZAgithub.com/makhkets/management/pkg/api/management/v1;managementv1b\x06proto3"
 
 var (
 	file_management_errors_proto_rawDescOnce sync.Once
@@ -0,0 +1,622 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: management/ip_pool.proto
+
+package managementv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type IPRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           string                 `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IPRange) Reset() {
+	*x = IPRange{}
+	mi := &file_management_ip_pool_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IPRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPRange) ProtoMessage() {}
+
+func (x *IPRange) ProtoReflect() protoreflect.Message {
+	mi := &file_management_ip_pool_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPRange.ProtoReflect.Descriptor instead.
+func (*IPRange) Descriptor() ([]byte, []int) {
+	return file_management_ip_pool_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IPRange) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *IPRange) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *IPRange) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type IPPool struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	NodeId         int32                  `protobuf:"varint,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Family         int32                  `protobuf:"varint,3,opt,name=family,proto3" json:"family,omitempty"` // 4 или 6
+	Subnet         string                 `protobuf:"bytes,4,opt,name=subnet,proto3" json:"subnet,omitempty"`
+	Gateway        string                 `protobuf:"bytes,5,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	ReservedRanges []*IPRange             `protobuf:"bytes,6,rep,name=reserved_ranges,json=reservedRanges,proto3" json:"reserved_ranges,omitempty"`
+	IsActive       bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *IPPool) Reset() {
+	*x = IPPool{}
+	mi := &file_management_ip_pool_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IPPool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPPool) ProtoMessage() {}
+
+func (x *IPPool) ProtoReflect() protoreflect.Message {
+	mi := &file_management_ip_pool_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPPool.ProtoReflect.Descriptor instead.
+func (*IPPool) Descriptor() ([]byte, []int) {
+	return file_management_ip_pool_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IPPool) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *IPPool) GetNodeId() int32 {
+	if x != nil {
+		return x.NodeId
+	}
+	return 0
+}
+
+func (x *IPPool) GetFamily() int32 {
+	if x != nil {
+		return x.Family
+	}
+	return 0
+}
+
+func (x *IPPool) GetSubnet() string {
+	if x != nil {
+		return x.Subnet
+	}
+	return ""
+}
+
+func (x *IPPool) GetGateway() string {
+	if x != nil {
+		return x.Gateway
+	}
+	return ""
+}
+
+func (x *IPPool) GetReservedRanges() []*IPRange {
+	if x != nil {
+		return x.ReservedRanges
+	}
+	return nil
+}
+
+func (x *IPPool) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *IPPool) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateIPPoolRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NodeId         int32                  `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Subnet         string                 `protobuf:"bytes,2,opt,name=subnet,proto3" json:"subnet,omitempty"`
+	Gateway        string                 `protobuf:"bytes,3,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	ReservedRanges []*IPRange             `protobuf:"bytes,4,rep,name=reserved_ranges,json=reservedRanges,proto3" json:"reserved_ranges,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateIPPoolRequest) Reset() {
+	*x = CreateIPPoolRequest{}
+	mi := &file_management_ip_pool_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIPPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIPPoolRequest) ProtoMessage() {}
+
+func (x *CreateIPPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_ip_pool_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIPPoolRequest.ProtoReflect.Descriptor instead.
+func (*CreateIPPoolRequest) Descriptor() ([]byte, []int) {
+	return file_management_ip_pool_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateIPPoolRequest) GetNodeId() int32 {
+	if x != nil {
+		return x.NodeId
+	}
+	return 0
+}
+
+func (x *CreateIPPoolRequest) GetSubnet() string {
+	if x != nil {
+		return x.Subnet
+	}
+	return ""
+}
+
+func (x *CreateIPPoolRequest) GetGateway() string {
+	if x != nil {
+		return x.Gateway
+	}
+	return ""
+}
+
+func (x *CreateIPPoolRequest) GetReservedRanges() []*IPRange {
+	if x != nil {
+		return x.ReservedRanges
+	}
+	return nil
+}
+
+type GetIPPoolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIPPoolRequest) Reset() {
+	*x = GetIPPoolRequest{}
+	mi := &file_management_ip_pool_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIPPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIPPoolRequest) ProtoMessage() {}
+
+func (x *GetIPPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_ip_pool_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIPPoolRequest.ProtoReflect.Descriptor instead.
+func (*GetIPPoolRequest) Descriptor() ([]byte, []int) {
+	return file_management_ip_pool_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetIPPoolRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListIPPoolsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	NodeId *int32                 `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3,oneof" json:"node_id,omitempty"`
+	// Размер страницы: 0 - 50, не больше 500
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token из предыдущего ответа, пусто - первая страница.
+	// Токен действителен только с теми же фильтрами и order_by
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Сортировка "поле [asc|desc]", по умолчанию "node_id". Поля: id, node_id, created_at
+	OrderBy string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// 4 или 6, 0 - оба семейства
+	Family        int32 `protobuf:"varint,5,opt,name=family,proto3" json:"family,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIPPoolsRequest) Reset() {
+	*x = ListIPPoolsRequest{}
+	mi := &file_management_ip_pool_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIPPoolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIPPoolsRequest) ProtoMessage() {}
+
+func (x *ListIPPoolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_ip_pool_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIPPoolsRequest.ProtoReflect.Descriptor instead.
+func (*ListIPPoolsRequest) Descriptor() ([]byte, []int) {
+	return file_management_ip_pool_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListIPPoolsRequest) GetNodeId() int32 {
+	if x != nil && x.NodeId != nil {
+		return *x.NodeId
+	}
+	return 0
+}
+
+func (x *ListIPPoolsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListIPPoolsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListIPPoolsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListIPPoolsRequest) GetFamily() int32 {
+	if x != nil {
+		return x.Family
+	}
+	return 0
+}
+
+type IPPoolUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Pool  *IPPool                `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	// Количество адресов считается с насыщением: для больших IPv6 подсетей total = 2^64-1
+	Total         uint64  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Reserved      uint64  `protobuf:"varint,3,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Allocated     uint64  `protobuf:"varint,4,opt,name=allocated,proto3" json:"allocated,omitempty"`
+	Free          uint64  `protobuf:"varint,5,opt,name=free,proto3" json:"free,omitempty"`
+	UsagePct      float64 `protobuf:"fixed64,6,opt,name=usage_pct,json=usagePct,proto3" json:"usage_pct,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IPPoolUsage) Reset() {
+	*x = IPPoolUsage{}
+	mi := &file_management_ip_pool_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IPPoolUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPPoolUsage) ProtoMessage() {}
+
+func (x *IPPoolUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_management_ip_pool_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPPoolUsage.ProtoReflect.Descriptor instead.
+func (*IPPoolUsage) Descriptor() ([]byte, []int) {
+	return file_management_ip_pool_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *IPPoolUsage) GetPool() *IPPool {
+	if x != nil {
+		return x.Pool
+	}
+	return nil
+}
+
+func (x *IPPoolUsage) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *IPPoolUsage) GetReserved() uint64 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *IPPoolUsage) GetAllocated() uint64 {
+	if x != nil {
+		return x.Allocated
+	}
+	return 0
+}
+
+func (x *IPPoolUsage) GetFree() uint64 {
+	if x != nil {
+		return x.Free
+	}
+	return 0
+}
+
+func (x *IPPoolUsage) GetUsagePct() float64 {
+	if x != nil {
+		return x.UsagePct
+	}
+	return 0
+}
+
+type ListIPPoolsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Pools []*IPPoolUsage         `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
+	// Токен следующей страницы, пусто - страница последняя
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIPPoolsResponse) Reset() {
+	*x = ListIPPoolsResponse{}
+	mi := &file_management_ip_pool_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIPPoolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIPPoolsResponse) ProtoMessage() {}
+
+func (x *ListIPPoolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_ip_pool_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIPPoolsResponse.ProtoReflect.Descriptor instead.
+func (*ListIPPoolsResponse) Descriptor() ([]byte, []int) {
+	return file_management_ip_pool_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListIPPoolsResponse) GetPools() []*IPPoolUsage {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+func (x *ListIPPoolsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_management_ip_pool_proto protoreflect.FileDescriptor
+
+const file_management_ip_pool_proto_rawDesc = "" +
+	"\n" +
+	"\x18management/ip_pool.proto\x12\n" +
+	"management\x1a\x1fgoogle/protobuf/timestamp.proto\"S\n" +
+	"\aIPRange\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\x91\x02\n" +
+	"\x06IPPool\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\anode_id\x18\x02 \x01(\x05R\x06nodeId\x12\x16\n" +
+	"\x06family\x18\x03 \x01(\x05R\x06family\x12\x16\n" +
+	"\x06subnet\x18\x04 \x01(\tR\x06subnet\x12\x18\n" +
+	"\agateway\x18\x05 \x01(\tR\agateway\x12<\n" +
+	"\x0freserved_ranges\x18\x06 \x03(\v2\x13.management.IPRangeR\x0ereservedRanges\x12\x1b\n" +
+	"\tis_active\x18\a \x01(\bR\bisActive\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x9e\x01\n" +
+	"\x13CreateIPPoolRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\x05R\x06nodeId\x12\x16\n" +
+	"\x06subnet\x18\x02 \x01(\tR\x06subnet\x12\x18\n" +
+	"\agateway\x18\x03 \x01(\tR\agateway\x12<\n" +
+	"\x0freserved_ranges\x18\x04 \x03(\v2\x13.management.IPRangeR\x0ereservedRanges\"\"\n" +
+	"\x10GetIPPoolRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\xad\x01\n" +
+	"\x12ListIPPoolsRequest\x12\x1c\n" +
+	"\anode_id\x18\x01 \x01(\x05H\x00R\x06nodeId\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12\x16\n" +
+	"\x06family\x18\x05 \x01(\x05R\x06familyB\n" +
+	"\n" +
+	"\b_node_id\"\xb6\x01\n" +
+	"\vIPPoolUsage\x12&\n" +
+	"\x04pool\x18\x01 \x01(\v2\x12.management.IPPoolR\x04pool\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x04R\x05total\x12\x1a\n" +
+	"\breserved\x18\x03 \x01(\x04R\breserved\x12\x1c\n" +
+	"\tallocated\x18\x04 \x01(\x04R\tallocated\x12\x12\n" +
+	"\x04free\x18\x05 \x01(\x04R\x04free\x12\x1b\n" +
+	"\tusage_pct\x18\x06 \x01(\x01R\busagePct\"l\n" +
+	"\x13ListIPPoolsResponse\x12-\n" +
+	"\x05pools\x18\x01 \x03(\v2\x17.management.IPPoolUsageR\x05pools\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageTokenBCZAgithub.com/makhkets/management/pkg/api/management/v1;managementv1b\x06proto3"
+
+var (
+	file_management_ip_pool_proto_rawDescOnce sync.Once
+	file_management_ip_pool_proto_rawDescData []byte
+)
+
+func file_management_ip_pool_proto_rawDescGZIP() []byte {
+	file_management_ip_pool_proto_rawDescOnce.Do(func() {
+		file_management_ip_pool_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_management_ip_pool_proto_rawDesc), len(file_management_ip_pool_proto_rawDesc)))
+	})
+	return file_management_ip_pool_proto_rawDescData
+}
+
+var file_management_ip_pool_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_management_ip_pool_proto_goTypes = []any{
+	(*IPRange)(nil),               // 0: management.IPRange
+	(*IPPool)(nil),                // 1: management.IPPool
+	(*CreateIPPoolRequest)(nil),   // 2: management.CreateIPPoolRequest
+	(*GetIPPoolRequest)(nil),      // 3: management.GetIPPoolRequest
+	(*ListIPPoolsRequest)(nil),    // 4: management.ListIPPoolsRequest
+	(*IPPoolUsage)(nil),           // 5: management.IPPoolUsage
+	(*ListIPPoolsResponse)(nil),   // 6: management.ListIPPoolsResponse
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_management_ip_pool_proto_depIdxs = []int32{
+	0, // 0: management.IPPool.reserved_ranges:type_name -> management.IPRange
+	7, // 1: management.IPPool.created_at:type_name -> google.protobuf.Timestamp
+	0, // 2: management.CreateIPPoolRequest.reserved_ranges:type_name -> management.IPRange
+	1, // 3: management.IPPoolUsage.pool:type_name -> management.IPPool
+	5, // 4: management.ListIPPoolsResponse.pools:type_name -> management.IPPoolUsage
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_management_ip_pool_proto_init() }
+func file_management_ip_pool_proto_init() {
+	if File_management_ip_pool_proto != nil {
+		return
+	}
+	file_management_ip_pool_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_management_ip_pool_proto_rawDesc), len(file_management_ip_pool_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_management_ip_pool_proto_goTypes,
+		DependencyIndexes: file_management_ip_pool_proto_depIdxs,
+		MessageInfos:      file_management_ip_pool_proto_msgTypes,
+	}.Build()
+	File_management_ip_pool_proto = out.File
+	file_management_ip_pool_proto_goTypes = nil
+	file_management_ip_pool_proto_depIdxs = nil
+}
@@ -7,6 +7,7 @@
 package managementv1
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
@@ -26,119 +27,210 @@ var File_management_management_proto protoreflect.FileDescriptor
 const file_management_management_proto_rawDesc = "" +
 	"\n" +
 	"\x1bmanagement/management.proto\x12\n" +
-	"management\x1a\x1bgoogle/protobuf/empty.proto\x1a\x15management/plan.proto\x1a\x15management/node.proto\x1a\x14management/vds.proto\x1a\x15management/task.proto2\xee\v\n" +
+	"management\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x15management/plan.proto\x1a\x15management/node.proto\x1a\x14management/vds.proto\x1a\x15management/task.proto\x1a\x18management/ip_pool.proto\x1a\x16management/audit.proto\x1a\x18management/webhook.proto2\xd4!\n" +
 	"\n" +
-	"Management\x12=\n" +
+	"Management\x12S\n" +
 	"\n" +
-	"CreatePlan\x12\x1d.management.CreatePlanRequest\x1a\x10.management.Plan\x127\n" +
-	"\aGetPlan\x12\x1a.management.GetPlanRequest\x1a\x10.management.Plan\x12=\n" +
+	"CreatePlan\x12\x1d.management.CreatePlanRequest\x1a\x10.management.Plan\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/plans\x12O\n" +
+	"\aGetPlan\x12\x1a.management.GetPlanRequest\x1a\x10.management.Plan\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/plans/{id}\x12X\n" +
 	"\n" +
-	"UpdatePlan\x12\x1d.management.UpdatePlanRequest\x1a\x10.management.Plan\x12H\n" +
-	"\tListPlans\x12\x1c.management.ListPlansRequest\x1a\x1d.management.ListPlansResponse\x12@\n" +
+	"UpdatePlan\x12\x1d.management.UpdatePlanRequest\x1a\x10.management.Plan\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*2\x0e/v1/plans/{id}\x12[\n" +
+	"\tListPlans\x12\x1c.management.ListPlansRequest\x1a\x1d.management.ListPlansResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/plans\x12[\n" +
 	"\n" +
-	"DeletePlan\x12\x1a.management.GetPlanRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"DeletePlan\x12\x1d.management.DeletePlanRequest\x1a\x16.google.protobuf.Empty\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/v1/plans/{id}\x12t\n" +
+	"\x11ListArchivedPlans\x12$.management.ListArchivedPlansRequest\x1a\x1d.management.ListPlansResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/archived-plans\x12S\n" +
 	"\n" +
-	"CreateNode\x12\x1d.management.CreateNodeRequest\x1a\x10.management.Node\x127\n" +
-	"\aGetNode\x12\x1a.management.GetNodeRequest\x1a\x10.management.Node\x12=\n" +
+	"CreateNode\x12\x1d.management.CreateNodeRequest\x1a\x10.management.Node\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/nodes\x12O\n" +
+	"\aGetNode\x12\x1a.management.GetNodeRequest\x1a\x10.management.Node\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/nodes/{id}\x12X\n" +
 	"\n" +
-	"UpdateNode\x12\x1d.management.UpdateNodeRequest\x1a\x10.management.Node\x12H\n" +
-	"\tListNodes\x12\x1c.management.ListNodesRequest\x1a\x1d.management.ListNodesResponse\x12@\n" +
+	"UpdateNode\x12\x1d.management.UpdateNodeRequest\x1a\x10.management.Node\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*2\x0e/v1/nodes/{id}\x12[\n" +
+	"\tListNodes\x12\x1c.management.ListNodesRequest\x1a\x1d.management.ListNodesResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/nodes\x12X\n" +
 	"\n" +
-	"DeleteNode\x12\x1a.management.GetNodeRequest\x1a\x16.google.protobuf.Empty\x12M\n" +
-	"\x12GetNodeUtilization\x12\x1a.management.GetNodeRequest\x1a\x1b.management.NodeUtilization\x12:\n" +
-	"\tCreateVDS\x12\x1c.management.CreateVDSRequest\x1a\x0f.management.VDS\x124\n" +
-	"\x06GetVDS\x12\x19.management.GetVDSRequest\x1a\x0f.management.VDS\x12N\n" +
-	"\rListVDSByUser\x12 .management.ListVDSByUserRequest\x1a\x1b.management.ListVDSResponse\x12F\n" +
-	"\x0fUpdateVDSStatus\x12\".management.UpdateVDSStatusRequest\x1a\x0f.management.VDS\x12<\n" +
+	"DeleteNode\x12\x1a.management.GetNodeRequest\x1a\x16.google.protobuf.Empty\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/v1/nodes/{id}\x12q\n" +
+	"\x12GetNodeUtilization\x12\x1a.management.GetNodeRequest\x1a\x1b.management.NodeUtilization\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/nodes/{id}/utilization\x12N\n" +
+	"\tCreateVDS\x12\x1c.management.CreateVDSRequest\x1a\x0f.management.VDS\"\x12\x82\xd3\xe4\x93\x02\f:\x01*\"\a/v1/vds\x12J\n" +
+	"\x06GetVDS\x12\x19.management.GetVDSRequest\x1a\x0f.management.VDS\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/vds/{id}\x12o\n" +
+	"\rListVDSByUser\x12 .management.ListVDSByUserRequest\x1a\x1b.management.ListVDSResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/users/{user_id}/vds\x12f\n" +
+	"\x0fUpdateVDSStatus\x12\".management.UpdateVDSStatusRequest\x1a\x0f.management.VDS\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\x1a\x13/v1/vds/{id}/status\x12\\\n" +
 	"\n" +
-	"AllocateIP\x12\x1d.management.AllocateIPRequest\x1a\x0f.management.VDS\x12A\n" +
-	"\tDeleteVDS\x12\x1c.management.DeleteVDSRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"AllocateIP\x12\x1d.management.AllocateIPRequest\x1a\x0f.management.VDS\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/vds/{vds_id}/ip\x12W\n" +
+	"\tDeleteVDS\x12\x1c.management.DeleteVDSRequest\x1a\x16.google.protobuf.Empty\"\x14\x82\xd3\xe4\x93\x02\x0e*\f/v1/vds/{id}\x12q\n" +
+	"\rListVDSEvents\x12 .management.ListVDSEventsRequest\x1a!.management.ListVDSEventsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/vds/{id}/events\x12W\n" +
+	"\bRenewVDS\x12\x1b.management.RenewVDSRequest\x1a\x0f.management.VDS\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/vds/{id}/renew\x12j\n" +
+	"\x0fSetVDSAutoRenew\x12\".management.SetVDSAutoRenewRequest\x1a\x0f.management.VDS\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/vds/{id}/auto-renew\x12d\n" +
+	"\bStartVDS\x12\x1b.management.VDSPowerRequest\x1a\x1c.management.VDSPowerResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/vds/{id}/start\x12b\n" +
+	"\aStopVDS\x12\x1b.management.VDSPowerRequest\x1a\x1c.management.VDSPowerResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/vds/{id}/stop\x12f\n" +
+	"\tRebootVDS\x12\x1b.management.VDSPowerRequest\x1a\x1c.management.VDSPowerResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/vds/{id}/reboot\x12j\n" +
+	"\vPowerOffVDS\x12\x1b.management.VDSPowerRequest\x1a\x1c.management.VDSPowerResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/vds/{id}/poweroff\x12V\n" +
+	"\bWatchVDS\x12\x1b.management.WatchVDSRequest\x1a\x0f.management.VDS\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/vds/{id}/watch0\x01\x12`\n" +
 	"\n" +
-	"CreateTask\x12\x1d.management.CreateTaskRequest\x1a\x10.management.Task\x127\n" +
-	"\aGetTask\x12\x1a.management.GetTaskRequest\x1a\x10.management.Task\x12R\n" +
-	"\x0eListTasksByVDS\x12!.management.ListTasksByVDSRequest\x1a\x1d.management.ListTasksResponse\x12I\n" +
-	"\x10UpdateTaskStatus\x12#.management.UpdateTaskStatusRequest\x1a\x10.management.Task\x12i\n" +
-	"\x14GetPendingTasksCount\x12'.management.GetPendingTasksCountRequest\x1a(.management.GetPendingTasksCountResponseBCZAgithub.com/makhkets/management/pkg/api/management/v1;managementv1b\x06proto3"
+	"CreateTask\x12\x1d.management.CreateTaskRequest\x1a\x10.management.Task\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/vds/{vds_id}/tasks\x12O\n" +
+	"\aGetTask\x12\x1a.management.GetTaskRequest\x1a\x10.management.Task\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/tasks/{id}\x12[\n" +
+	"\tWatchTask\x12\x1c.management.WatchTaskRequest\x1a\x10.management.Task\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/tasks/{id}/watch0\x01\x12r\n" +
+	"\x0eListTasksByVDS\x12!.management.ListTasksByVDSRequest\x1a\x1d.management.ListTasksResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/vds/{vds_id}/tasks\x12k\n" +
+	"\x10UpdateTaskStatus\x12#.management.UpdateTaskStatusRequest\x1a\x10.management.Task\" \x82\xd3\xe4\x93\x02\x1a:\x01*\x1a\x15/v1/tasks/{id}/status\x12\x97\x01\n" +
+	"\x14GetPendingTasksCount\x12'.management.GetPendingTasksCountRequest\x1a(.management.GetPendingTasksCountResponse\",\x82\xd3\xe4\x93\x02&\x12$/v1/vds/{vds_id}/tasks/pending-count\x12\\\n" +
+	"\fCreateIPPool\x12\x1f.management.CreateIPPoolRequest\x1a\x12.management.IPPool\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/ip-pools\x12h\n" +
+	"\x0eGetIPPoolUsage\x12\x1c.management.GetIPPoolRequest\x1a\x17.management.IPPoolUsage\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/ip-pools/{id}/usage\x12d\n" +
+	"\vListIPPools\x12\x1e.management.ListIPPoolsRequest\x1a\x1f.management.ListIPPoolsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/ip-pools\x12_\n" +
+	"\fDeleteIPPool\x12\x1c.management.GetIPPoolRequest\x1a\x16.google.protobuf.Empty\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/v1/ip-pools/{id}\x12h\n" +
+	"\fListAuditLog\x12\x1f.management.ListAuditLogRequest\x1a .management.ListAuditLogResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/audit-log\x12_\n" +
+	"\rCreateWebhook\x12 .management.CreateWebhookRequest\x1a\x13.management.Webhook\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/webhooks\x12[\n" +
+	"\n" +
+	"GetWebhook\x12\x1d.management.GetWebhookRequest\x1a\x13.management.Webhook\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/webhooks/{id}\x12d\n" +
+	"\rUpdateWebhook\x12 .management.UpdateWebhookRequest\x1a\x13.management.Webhook\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*2\x11/v1/webhooks/{id}\x12g\n" +
+	"\fListWebhooks\x12\x1f.management.ListWebhooksRequest\x1a .management.ListWebhooksResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/webhooks\x12d\n" +
+	"\rDeleteWebhook\x12 .management.DeleteWebhookRequest\x1a\x16.google.protobuf.Empty\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/v1/webhooks/{id}\x12\x9a\x01\n" +
+	"\x15ListWebhookDeliveries\x12(.management.ListWebhookDeliveriesRequest\x1a).management.ListWebhookDeliveriesResponse\",\x82\xd3\xe4\x93\x02&\x12$/v1/webhooks/{webhook_id}/deliveriesBCZAgithub.com/makhkets/management/pkg/api/management/v1;managementv1b\x06proto3"
 
 var file_management_management_proto_goTypes = []any{
-	(*CreatePlanRequest)(nil),            // 0: management.CreatePlanRequest
-	(*GetPlanRequest)(nil),               // 1: management.GetPlanRequest
-	(*UpdatePlanRequest)(nil),            // 2: management.UpdatePlanRequest
-	(*ListPlansRequest)(nil),             // 3: management.ListPlansRequest
-	(*CreateNodeRequest)(nil),            // 4: management.CreateNodeRequest
-	(*GetNodeRequest)(nil),               // 5: management.GetNodeRequest
-	(*UpdateNodeRequest)(nil),            // 6: management.UpdateNodeRequest
-	(*ListNodesRequest)(nil),             // 7: management.ListNodesRequest
-	(*CreateVDSRequest)(nil),             // 8: management.CreateVDSRequest
-	(*GetVDSRequest)(nil),                // 9: management.GetVDSRequest
-	(*ListVDSByUserRequest)(nil),         // 10: management.ListVDSByUserRequest
-	(*UpdateVDSStatusRequest)(nil),       // 11: management.UpdateVDSStatusRequest
-	(*AllocateIPRequest)(nil),            // 12: management.AllocateIPRequest
-	(*DeleteVDSRequest)(nil),             // 13: management.DeleteVDSRequest
-	(*CreateTaskRequest)(nil),            // 14: management.CreateTaskRequest
-	(*GetTaskRequest)(nil),               // 15: management.GetTaskRequest
-	(*ListTasksByVDSRequest)(nil),        // 16: management.ListTasksByVDSRequest
-	(*UpdateTaskStatusRequest)(nil),      // 17: management.UpdateTaskStatusRequest
-	(*GetPendingTasksCountRequest)(nil),  // 18: management.GetPendingTasksCountRequest
-	(*Plan)(nil),                         // 19: management.Plan
-	(*ListPlansResponse)(nil),            // 20: management.ListPlansResponse
-	(*emptypb.Empty)(nil),                // 21: google.protobuf.Empty
-	(*Node)(nil),                         // 22: management.Node
-	(*ListNodesResponse)(nil),            // 23: management.ListNodesResponse
-	(*NodeUtilization)(nil),              // 24: management.NodeUtilization
-	(*VDS)(nil),                          // 25: management.VDS
-	(*ListVDSResponse)(nil),              // 26: management.ListVDSResponse
-	(*Task)(nil),                         // 27: management.Task
-	(*ListTasksResponse)(nil),            // 28: management.ListTasksResponse
-	(*GetPendingTasksCountResponse)(nil), // 29: management.GetPendingTasksCountResponse
+	(*CreatePlanRequest)(nil),             // 0: management.CreatePlanRequest
+	(*GetPlanRequest)(nil),                // 1: management.GetPlanRequest
+	(*UpdatePlanRequest)(nil),             // 2: management.UpdatePlanRequest
+	(*ListPlansRequest)(nil),              // 3: management.ListPlansRequest
+	(*DeletePlanRequest)(nil),             // 4: management.DeletePlanRequest
+	(*ListArchivedPlansRequest)(nil),      // 5: management.ListArchivedPlansRequest
+	(*CreateNodeRequest)(nil),             // 6: management.CreateNodeRequest
+	(*GetNodeRequest)(nil),                // 7: management.GetNodeRequest
+	(*UpdateNodeRequest)(nil),             // 8: management.UpdateNodeRequest
+	(*ListNodesRequest)(nil),              // 9: management.ListNodesRequest
+	(*CreateVDSRequest)(nil),              // 10: management.CreateVDSRequest
+	(*GetVDSRequest)(nil),                 // 11: management.GetVDSRequest
+	(*ListVDSByUserRequest)(nil),          // 12: management.ListVDSByUserRequest
+	(*UpdateVDSStatusRequest)(nil),        // 13: management.UpdateVDSStatusRequest
+	(*AllocateIPRequest)(nil),             // 14: management.AllocateIPRequest
+	(*DeleteVDSRequest)(nil),              // 15: management.DeleteVDSRequest
+	(*ListVDSEventsRequest)(nil),          // 16: management.ListVDSEventsRequest
+	(*RenewVDSRequest)(nil),               // 17: management.RenewVDSRequest
+	(*SetVDSAutoRenewRequest)(nil),        // 18: management.SetVDSAutoRenewRequest
+	(*VDSPowerRequest)(nil),               // 19: management.VDSPowerRequest
+	(*WatchVDSRequest)(nil),               // 20: management.WatchVDSRequest
+	(*CreateTaskRequest)(nil),             // 21: management.CreateTaskRequest
+	(*GetTaskRequest)(nil),                // 22: management.GetTaskRequest
+	(*WatchTaskRequest)(nil),              // 23: management.WatchTaskRequest
+	(*ListTasksByVDSRequest)(nil),         // 24: management.ListTasksByVDSRequest
+	(*UpdateTaskStatusRequest)(nil),       // 25: management.UpdateTaskStatusRequest
+	(*GetPendingTasksCountRequest)(nil),   // 26: management.GetPendingTasksCountRequest
+	(*CreateIPPoolRequest)(nil),           // 27: management.CreateIPPoolRequest
+	(*GetIPPoolRequest)(nil),              // 28: management.GetIPPoolRequest
+	(*ListIPPoolsRequest)(nil),            // 29: management.ListIPPoolsRequest
+	(*ListAuditLogRequest)(nil),           // 30: management.ListAuditLogRequest
+	(*CreateWebhookRequest)(nil),          // 31: management.CreateWebhookRequest
+	(*GetWebhookRequest)(nil),             // 32: management.GetWebhookRequest
+	(*UpdateWebhookRequest)(nil),          // 33: management.UpdateWebhookRequest
+	(*ListWebhooksRequest)(nil),           // 34: management.ListWebhooksRequest
+	(*DeleteWebhookRequest)(nil),          // 35: management.DeleteWebhookRequest
+	(*ListWebhookDeliveriesRequest)(nil),  // 36: management.ListWebhookDeliveriesRequest
+	(*Plan)(nil),                          // 37: management.Plan
+	(*ListPlansResponse)(nil),             // 38: management.ListPlansResponse
+	(*emptypb.Empty)(nil),                 // 39: google.protobuf.Empty
+	(*Node)(nil),                          // 40: management.Node
+	(*ListNodesResponse)(nil),             // 41: management.ListNodesResponse
+	(*NodeUtilization)(nil),               // 42: management.NodeUtilization
+	(*VDS)(nil),                           // 43: management.VDS
+	(*ListVDSResponse)(nil),               // 44: management.ListVDSResponse
+	(*ListVDSEventsResponse)(nil),         // 45: management.ListVDSEventsResponse
+	(*VDSPowerResponse)(nil),              // 46: management.VDSPowerResponse
+	(*Task)(nil),                          // 47: management.Task
+	(*ListTasksResponse)(nil),             // 48: management.ListTasksResponse
+	(*GetPendingTasksCountResponse)(nil),  // 49: management.GetPendingTasksCountResponse
+	(*IPPool)(nil),                        // 50: management.IPPool
+	(*IPPoolUsage)(nil),                   // 51: management.IPPoolUsage
+	(*ListIPPoolsResponse)(nil),           // 52: management.ListIPPoolsResponse
+	(*ListAuditLogResponse)(nil),          // 53: management.ListAuditLogResponse
+	(*Webhook)(nil),                       // 54: management.Webhook
+	(*ListWebhooksResponse)(nil),          // 55: management.ListWebhooksResponse
+	(*ListWebhookDeliveriesResponse)(nil), // 56: management.ListWebhookDeliveriesResponse
 }
 var file_management_management_proto_depIdxs = []int32{
 	0,  // 0: management.Management.CreatePlan:input_type -> management.CreatePlanRequest
 	1,  // 1: management.Management.GetPlan:input_type -> management.GetPlanRequest
 	2,  // 2: management.Management.UpdatePlan:input_type -> management.UpdatePlanRequest
 	3,  // 3: management.Management.ListPlans:input_type -> management.ListPlansRequest
-	1,  // 4: management.Management.DeletePlan:input_type -> management.GetPlanRequest
-	4,  // 5: management.Management.CreateNode:input_type -> management.CreateNodeRequest
-	5,  // 6: management.Management.GetNode:input_type -> management.GetNodeRequest
-	6,  // 7: management.Management.UpdateNode:input_type -> management.UpdateNodeRequest
-	7,  // 8: management.Management.ListNodes:input_type -> management.ListNodesRequest
-	5,  // 9: management.Management.DeleteNode:input_type -> management.GetNodeRequest
-	5,  // 10: management.Management.GetNodeUtilization:input_type -> management.GetNodeRequest
-	8,  // 11: management.Management.CreateVDS:input_type -> management.CreateVDSRequest
-	9,  // 12: management.Management.GetVDS:input_type -> management.GetVDSRequest
-	10, // 13: management.Management.ListVDSByUser:input_type -> management.ListVDSByUserRequest
-	11, // 14: management.Management.UpdateVDSStatus:input_type -> management.UpdateVDSStatusRequest
-	12, // 15: management.Management.AllocateIP:input_type -> management.AllocateIPRequest
-	13, // 16: management.Management.DeleteVDS:input_type -> management.DeleteVDSRequest
-	14, // 17: management.Management.CreateTask:input_type -> management.CreateTaskRequest
-	15, // 18: management.Management.GetTask:input_type -> management.GetTaskRequest
-	16, // 19: management.Management.ListTasksByVDS:input_type -> management.ListTasksByVDSRequest
-	17, // 20: management.Management.UpdateTaskStatus:input_type -> management.UpdateTaskStatusRequest
-	18, // 21: management.Management.GetPendingTasksCount:input_type -> management.GetPendingTasksCountRequest
-	19, // 22: management.Management.CreatePlan:output_type -> management.Plan
-	19, // 23: management.Management.GetPlan:output_type -> management.Plan
-	19, // 24: management.Management.UpdatePlan:output_type -> management.Plan
-	20, // 25: management.Management.ListPlans:output_type -> management.ListPlansResponse
-	21, // 26: management.Management.DeletePlan:output_type -> google.protobuf.Empty
-	22, // 27: management.Management.CreateNode:output_type -> management.Node
-	22, // 28: management.Management.GetNode:output_type -> management.Node
-	22, // 29: management.Management.UpdateNode:output_type -> management.Node
-	23, // 30: management.Management.ListNodes:output_type -> management.ListNodesResponse
-	21, // 31: management.Management.DeleteNode:output_type -> google.protobuf.Empty
-	24, // 32: management.Management.GetNodeUtilization:output_type -> management.NodeUtilization
-	25, // 33: management.Management.CreateVDS:output_type -> management.VDS
-	25, // 34: management.Management.GetVDS:output_type -> management.VDS
-	26, // 35: management.Management.ListVDSByUser:output_type -> management.ListVDSResponse
-	25, // 36: management.Management.UpdateVDSStatus:output_type -> management.VDS
-	25, // 37: management.Management.AllocateIP:output_type -> management.VDS
-	21, // 38: management.Management.DeleteVDS:output_type -> google.protobuf.Empty
-	27, // 39: management.Management.CreateTask:output_type -> management.Task
-	27, // 40: management.Management.GetTask:output_type -> management.Task
-	28, // 41: management.Management.ListTasksByVDS:output_type -> management.ListTasksResponse
-	27, // 42: management.Management.UpdateTaskStatus:output_type -> management.Task
-	29, // 43: management.Management.GetPendingTasksCount:output_type -> management.GetPendingTasksCountResponse
-	22, // [22:44] is the sub-list for method output_type
-	0,  // [0:22] is the sub-list for method input_type
+	4,  // 4: management.Management.DeletePlan:input_type -> management.DeletePlanRequest
+	5,  // 5: management.Management.ListArchivedPlans:input_type -> management.ListArchivedPlansRequest
+	6,  // 6: management.Management.CreateNode:input_type -> management.CreateNodeRequest
+	7,  // 7: management.Management.GetNode:input_type -> management.GetNodeRequest
+	8,  // 8: management.Management.UpdateNode:input_type -> management.UpdateNodeRequest
+	9,  // 9: management.Management.ListNodes:input_type -> management.ListNodesRequest
+	7,  // 10: management.Management.DeleteNode:input_type -> management.GetNodeRequest
+	7,  // 11: management.Management.GetNodeUtilization:input_type -> management.GetNodeRequest
+	10, // 12: management.Management.CreateVDS:input_type -> management.CreateVDSRequest
+	11, // 13: management.Management.GetVDS:input_type -> management.GetVDSRequest
+	12, // 14: management.Management.ListVDSByUser:input_type -> management.ListVDSByUserRequest
+	13, // 15: management.Management.UpdateVDSStatus:input_type -> management.UpdateVDSStatusRequest
+	14, // 16: management.Management.AllocateIP:input_type -> management.AllocateIPRequest
+	15, // 17: management.Management.DeleteVDS:input_type -> management.DeleteVDSRequest
+	16, // 18: management.Management.ListVDSEvents:input_type -> management.ListVDSEventsRequest
+	17, // 19: management.Management.RenewVDS:input_type -> management.RenewVDSRequest
+	18, // 20: management.Management.SetVDSAutoRenew:input_type -> management.SetVDSAutoRenewRequest
+	19, // 21: management.Management.StartVDS:input_type -> management.VDSPowerRequest
+	19, // 22: management.Management.StopVDS:input_type -> management.VDSPowerRequest
+	19, // 23: management.Management.RebootVDS:input_type -> management.VDSPowerRequest
+	19, // 24: management.Management.PowerOffVDS:input_type -> management.VDSPowerRequest
+	20, // 25: management.Management.WatchVDS:input_type -> management.WatchVDSRequest
+	21, // 26: management.Management.CreateTask:input_type -> management.CreateTaskRequest
+	22, // 27: management.Management.GetTask:input_type -> management.GetTaskRequest
+	23, // 28: management.Management.WatchTask:input_type -> management.WatchTaskRequest
+	24, // 29: management.Management.ListTasksByVDS:input_type -> management.ListTasksByVDSRequest
+	25, // 30: management.Management.UpdateTaskStatus:input_type -> management.UpdateTaskStatusRequest
+	26, // 31: management.Management.GetPendingTasksCount:input_type -> management.GetPendingTasksCountRequest
+	27, // 32: management.Management.CreateIPPool:input_type -> management.CreateIPPoolRequest
+	28, // 33: management.Management.GetIPPoolUsage:input_type -> management.GetIPPoolRequest
+	29, // 34: management.Management.ListIPPools:input_type -> management.ListIPPoolsRequest
+	28, // 35: management.Management.DeleteIPPool:input_type -> management.GetIPPoolRequest
+	30, // 36: management.Management.ListAuditLog:input_type -> management.ListAuditLogRequest
+	31, // 37: management.Management.CreateWebhook:input_type -> management.CreateWebhookRequest
+	32, // 38: management.Management.GetWebhook:input_type -> management.GetWebhookRequest
+	33, // 39: management.Management.UpdateWebhook:input_type -> management.UpdateWebhookRequest
+	34, // 40: management.Management.ListWebhooks:input_type -> management.ListWebhooksRequest
+	35, // 41: management.Management.DeleteWebhook:input_type -> management.DeleteWebhookRequest
+	36, // 42: management.Management.ListWebhookDeliveries:input_type -> management.ListWebhookDeliveriesRequest
+	37, // 43: management.Management.CreatePlan:output_type -> management.Plan
+	37, // 44: management.Management.GetPlan:output_type -> management.Plan
+	37, // 45: management.Management.UpdatePlan:output_type -> management.Plan
+	38, // 46: management.Management.ListPlans:output_type -> management.ListPlansResponse
+	39, // 47: management.Management.DeletePlan:output_type -> google.protobuf.Empty
+	38, // 48: management.Management.ListArchivedPlans:output_type -> management.ListPlansResponse
+	40, // 49: management.Management.CreateNode:output_type -> management.Node
+	40, // 50: management.Management.GetNode:output_type -> management.Node
+	40, // 51: management.Management.UpdateNode:output_type -> management.Node
+	41, // 52: management.Management.ListNodes:output_type -> management.ListNodesResponse
+	39, // 53: management.Management.DeleteNode:output_type -> google.protobuf.Empty
+	42, // 54: management.Management.GetNodeUtilization:output_type -> management.NodeUtilization
+	43, // 55: management.Management.CreateVDS:output_type -> management.VDS
+	43, // 56: management.Management.GetVDS:output_type -> management.VDS
+	44, // 57: management.Management.ListVDSByUser:output_type -> management.ListVDSResponse
+	43, // 58: management.Management.UpdateVDSStatus:output_type -> management.VDS
+	43, // 59: management.Management.AllocateIP:output_type -> management.VDS
+	39, // 60: management.Management.DeleteVDS:output_type -> google.protobuf.Empty
+	45, // 61: management.Management.ListVDSEvents:output_type -> management.ListVDSEventsResponse
+	43, // 62: management.Management.RenewVDS:output_type -> management.VDS
+	43, // 63: management.Management.SetVDSAutoRenew:output_type -> management.VDS
+	46, // 64: management.Management.StartVDS:output_type -> management.VDSPowerResponse
+	46, // 65: management.Management.StopVDS:output_type -> management.VDSPowerResponse
+	46, // 66: management.Management.RebootVDS:output_type -> management.VDSPowerResponse
+	46, // 67: management.Management.PowerOffVDS:output_type -> management.VDSPowerResponse
+	43, // 68: management.Management.WatchVDS:output_type -> management.VDS
+	47, // 69: management.Management.CreateTask:output_type -> management.Task
+	47, // 70: management.Management.GetTask:output_type -> management.Task
+	47, // 71: management.Management.WatchTask:output_type -> management.Task
+	48, // 72: management.Management.ListTasksByVDS:output_type -> management.ListTasksResponse
+	47, // 73: management.Management.UpdateTaskStatus:output_type -> management.Task
+	49, // 74: management.Management.GetPendingTasksCount:output_type -> management.GetPendingTasksCountResponse
+	50, // 75: management.Management.CreateIPPool:output_type -> management.IPPool
+	51, // 76: management.Management.GetIPPoolUsage:output_type -> management.IPPoolUsage
+	52, // 77: management.Management.ListIPPools:output_type -> management.ListIPPoolsResponse
+	39, // 78: management.Management.DeleteIPPool:output_type -> google.protobuf.Empty
+	53, // 79: management.Management.ListAuditLog:output_type -> management.ListAuditLogResponse
+	54, // 80: management.Management.CreateWebhook:output_type -> management.Webhook
+	54, // 81: management.Management.GetWebhook:output_type -> management.Webhook
+	54, // 82: management.Management.UpdateWebhook:output_type -> management.Webhook
+	55, // 83: management.Management.ListWebhooks:output_type -> management.ListWebhooksResponse
+	39, // 84: management.Management.DeleteWebhook:output_type -> google.protobuf.Empty
+	56, // 85: management.Management.ListWebhookDeliveries:output_type -> management.ListWebhookDeliveriesResponse
+	43, // [43:86] is the sub-list for method output_type
+	0,  // [0:43] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -153,6 +245,9 @@ func file_management_management_proto_init() {
 	file_management_node_proto_init()
 	file_management_vds_proto_init()
 	file_management_task_proto_init()
+	file_management_ip_pool_proto_init()
+	file_management_audit_proto_init()
+	file_management_webhook_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
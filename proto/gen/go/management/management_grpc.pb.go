@@ -20,28 +20,49 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Management_CreatePlan_FullMethodName           = "/management.Management/CreatePlan"
-	Management_GetPlan_FullMethodName              = "/management.Management/GetPlan"
-	Management_UpdatePlan_FullMethodName           = "/management.Management/UpdatePlan"
-	Management_ListPlans_FullMethodName            = "/management.Management/ListPlans"
-	Management_DeletePlan_FullMethodName           = "/management.Management/DeletePlan"
-	Management_CreateNode_FullMethodName           = "/management.Management/CreateNode"
-	Management_GetNode_FullMethodName              = "/management.Management/GetNode"
-	Management_UpdateNode_FullMethodName           = "/management.Management/UpdateNode"
-	Management_ListNodes_FullMethodName            = "/management.Management/ListNodes"
-	Management_DeleteNode_FullMethodName           = "/management.Management/DeleteNode"
-	Management_GetNodeUtilization_FullMethodName   = "/management.Management/GetNodeUtilization"
-	Management_CreateVDS_FullMethodName            = "/management.Management/CreateVDS"
-	Management_GetVDS_FullMethodName               = "/management.Management/GetVDS"
-	Management_ListVDSByUser_FullMethodName        = "/management.Management/ListVDSByUser"
-	Management_UpdateVDSStatus_FullMethodName      = "/management.Management/UpdateVDSStatus"
-	Management_AllocateIP_FullMethodName           = "/management.Management/AllocateIP"
-	Management_DeleteVDS_FullMethodName            = "/management.Management/DeleteVDS"
-	Management_CreateTask_FullMethodName           = "/management.Management/CreateTask"
-	Management_GetTask_FullMethodName              = "/management.Management/GetTask"
-	Management_ListTasksByVDS_FullMethodName       = "/management.Management/ListTasksByVDS"
-	Management_UpdateTaskStatus_FullMethodName     = "/management.Management/UpdateTaskStatus"
-	Management_GetPendingTasksCount_FullMethodName = "/management.Management/GetPendingTasksCount"
+	Management_CreatePlan_FullMethodName            = "/management.Management/CreatePlan"
+	Management_GetPlan_FullMethodName               = "/management.Management/GetPlan"
+	Management_UpdatePlan_FullMethodName            = "/management.Management/UpdatePlan"
+	Management_ListPlans_FullMethodName             = "/management.Management/ListPlans"
+	Management_DeletePlan_FullMethodName            = "/management.Management/DeletePlan"
+	Management_ListArchivedPlans_FullMethodName     = "/management.Management/ListArchivedPlans"
+	Management_CreateNode_FullMethodName            = "/management.Management/CreateNode"
+	Management_GetNode_FullMethodName               = "/management.Management/GetNode"
+	Management_UpdateNode_FullMethodName            = "/management.Management/UpdateNode"
+	Management_ListNodes_FullMethodName             = "/management.Management/ListNodes"
+	Management_DeleteNode_FullMethodName            = "/management.Management/DeleteNode"
+	Management_GetNodeUtilization_FullMethodName    = "/management.Management/GetNodeUtilization"
+	Management_CreateVDS_FullMethodName             = "/management.Management/CreateVDS"
+	Management_GetVDS_FullMethodName                = "/management.Management/GetVDS"
+	Management_ListVDSByUser_FullMethodName         = "/management.Management/ListVDSByUser"
+	Management_UpdateVDSStatus_FullMethodName       = "/management.Management/UpdateVDSStatus"
+	Management_AllocateIP_FullMethodName            = "/management.Management/AllocateIP"
+	Management_DeleteVDS_FullMethodName             = "/management.Management/DeleteVDS"
+	Management_ListVDSEvents_FullMethodName         = "/management.Management/ListVDSEvents"
+	Management_RenewVDS_FullMethodName              = "/management.Management/RenewVDS"
+	Management_SetVDSAutoRenew_FullMethodName       = "/management.Management/SetVDSAutoRenew"
+	Management_StartVDS_FullMethodName              = "/management.Management/StartVDS"
+	Management_StopVDS_FullMethodName               = "/management.Management/StopVDS"
+	Management_RebootVDS_FullMethodName             = "/management.Management/RebootVDS"
+	Management_PowerOffVDS_FullMethodName           = "/management.Management/PowerOffVDS"
+	Management_WatchVDS_FullMethodName              = "/management.Management/WatchVDS"
+	Management_CreateTask_FullMethodName            = "/management.Management/CreateTask"
+	Management_GetTask_FullMethodName               = "/management.Management/GetTask"
+	Management_WatchTask_FullMethodName             = "/management.Management/WatchTask"
+	Management_ListTasksByVDS_FullMethodName        = "/management.Management/ListTasksByVDS"
+	Management_UpdateTaskStatus_FullMethodName      = "/management.Management/UpdateTaskStatus"
+	Management_GetPendingTasksCount_FullMethodName  = "/management.Management/GetPendingTasksCount"
+	Management_CreateIPPool_FullMethodName          = "/management.Management/CreateIPPool"
+	Management_GetIPPoolUsage_FullMethodName        = "/management.Management/GetIPPoolUsage"
+	Management_ListIPPools_FullMethodName           = "/management.Management/ListIPPools"
+	Management_DeleteIPPool_FullMethodName          = "/management.Management/DeleteIPPool"
+	Management_ListAuditLog_FullMethodName          = "/management.Management/ListAuditLog"
+	Management_CreateWebhook_FullMethodName         = "/management.Management/CreateWebhook"
+	Management_GetWebhook_FullMethodName            = "/management.Management/GetWebhook"
+	Management_UpdateWebhook_FullMethodName         = "/management.Management/UpdateWebhook"
+	Management_ListWebhooks_FullMethodName          = "/management.Management/ListWebhooks"
+	Management_DeleteWebhook_FullMethodName         = "/management.Management/DeleteWebhook"
+	Management_ListWebhookDeliveries_FullMethodName = "/management.Management/ListWebhookDeliveries"
 )
 
 // ManagementClient is the client API for Management service.
@@ -49,11 +70,13 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ManagementClient interface {
 	// === PLAN Operations ===
+	// for admin endpoints
 	CreatePlan(ctx context.Context, in *CreatePlanRequest, opts ...grpc.CallOption) (*Plan, error)
 	GetPlan(ctx context.Context, in *GetPlanRequest, opts ...grpc.CallOption) (*Plan, error)
 	UpdatePlan(ctx context.Context, in *UpdatePlanRequest, opts ...grpc.CallOption) (*Plan, error)
 	ListPlans(ctx context.Context, in *ListPlansRequest, opts ...grpc.CallOption) (*ListPlansResponse, error)
-	DeletePlan(ctx context.Context, in *GetPlanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeletePlan(ctx context.Context, in *DeletePlanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListArchivedPlans(ctx context.Context, in *ListArchivedPlansRequest, opts ...grpc.CallOption) (*ListPlansResponse, error)
 	// === NODE Operations ===
 	CreateNode(ctx context.Context, in *CreateNodeRequest, opts ...grpc.CallOption) (*Node, error)
 	GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*Node, error)
@@ -68,12 +91,51 @@ type ManagementClient interface {
 	UpdateVDSStatus(ctx context.Context, in *UpdateVDSStatusRequest, opts ...grpc.CallOption) (*VDS, error)
 	AllocateIP(ctx context.Context, in *AllocateIPRequest, opts ...grpc.CallOption) (*VDS, error)
 	DeleteVDS(ctx context.Context, in *DeleteVDSRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListVDSEvents(ctx context.Context, in *ListVDSEventsRequest, opts ...grpc.CallOption) (*ListVDSEventsResponse, error)
+	// Продление подписки на месяц. Оплата резервируется с баланса владельца VDS,
+	// поэтому платную VDS продлевает только сам владелец. Снимает приостановку за неоплату
+	RenewVDS(ctx context.Context, in *RenewVDSRequest, opts ...grpc.CallOption) (*VDS, error)
+	// Автопродление: планировщик продлевает подписку за expiry.renew_before до окончания,
+	// резервируя оплату на балансе владельца по его сохранённой авторизации. Если средств нет,
+	// попытка повторяется через expiry.retry_after, отозванная авторизация отключает автопродление
+	SetVDSAutoRenew(ctx context.Context, in *SetVDSAutoRenewRequest, opts ...grpc.CallOption) (*VDS, error)
+	// Операции питания: проверяют статус VDS и ставят задачу. Пока по VDS выполняется
+	// другая задача - ERROR_CODE_TASK_IN_PROGRESS, статус не допускает операцию - ERROR_CODE_INVALID_STATE
+	StartVDS(ctx context.Context, in *VDSPowerRequest, opts ...grpc.CallOption) (*VDSPowerResponse, error)
+	// Корректное выключение через ACPI
+	StopVDS(ctx context.Context, in *VDSPowerRequest, opts ...grpc.CallOption) (*VDSPowerResponse, error)
+	RebootVDS(ctx context.Context, in *VDSPowerRequest, opts ...grpc.CallOption) (*VDSPowerResponse, error)
+	// Немедленная остановка, как отключение питания: для VM, которые не реагируют на StopVDS
+	PowerOffVDS(ctx context.Context, in *VDSPowerRequest, opts ...grpc.CallOption) (*VDSPowerResponse, error)
+	// Текущее состояние VDS, затем каждое изменение статуса. Поток завершается после DELETED
+	WatchVDS(ctx context.Context, in *WatchVDSRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VDS], error)
 	// === TASK Operations ===
+	// Устарел: задачи питания ставятся через StartVDS, StopVDS, RebootVDS и PowerOffVDS,
+	// create и delete - через CreateVDS и DeleteVDS. Любой тип задачи отклоняется с ERROR_CODE_INVALID_ARGUMENT
 	CreateTask(ctx context.Context, in *CreateTaskRequest, opts ...grpc.CallOption) (*Task, error)
 	GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	// Текущее состояние задачи, затем каждое изменение статуса. Поток завершается после DONE или ERROR
+	WatchTask(ctx context.Context, in *WatchTaskRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Task], error)
 	ListTasksByVDS(ctx context.Context, in *ListTasksByVDSRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
 	UpdateTaskStatus(ctx context.Context, in *UpdateTaskStatusRequest, opts ...grpc.CallOption) (*Task, error)
 	GetPendingTasksCount(ctx context.Context, in *GetPendingTasksCountRequest, opts ...grpc.CallOption) (*GetPendingTasksCountResponse, error)
+	// === IP POOL Operations ===
+	// for admin endpoints
+	CreateIPPool(ctx context.Context, in *CreateIPPoolRequest, opts ...grpc.CallOption) (*IPPool, error)
+	GetIPPoolUsage(ctx context.Context, in *GetIPPoolRequest, opts ...grpc.CallOption) (*IPPoolUsage, error)
+	ListIPPools(ctx context.Context, in *ListIPPoolsRequest, opts ...grpc.CallOption) (*ListIPPoolsResponse, error)
+	DeleteIPPool(ctx context.Context, in *GetIPPoolRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// === AUDIT ===
+	// Журнал изменяющих вызовов, только для администраторов
+	ListAuditLog(ctx context.Context, in *ListAuditLogRequest, opts ...grpc.CallOption) (*ListAuditLogResponse, error)
+	// === WEBHOOK Operations ===
+	// Подписки на доменные события с подписанной доставкой и журналом попыток
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	GetWebhook(ctx context.Context, in *GetWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
 }
 
 type managementClient struct {
@@ -124,7 +186,7 @@ func (c *managementClient) ListPlans(ctx context.Context, in *ListPlansRequest,
 	return out, nil
 }
 
-func (c *managementClient) DeletePlan(ctx context.Context, in *GetPlanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *managementClient) DeletePlan(ctx context.Context, in *DeletePlanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, Management_DeletePlan_FullMethodName, in, out, cOpts...)
@@ -134,6 +196,16 @@ func (c *managementClient) DeletePlan(ctx context.Context, in *GetPlanRequest, o
 	return out, nil
 }
 
+func (c *managementClient) ListArchivedPlans(ctx context.Context, in *ListArchivedPlansRequest, opts ...grpc.CallOption) (*ListPlansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPlansResponse)
+	err := c.cc.Invoke(ctx, Management_ListArchivedPlans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *managementClient) CreateNode(ctx context.Context, in *CreateNodeRequest, opts ...grpc.CallOption) (*Node, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Node)
@@ -254,6 +326,95 @@ func (c *managementClient) DeleteVDS(ctx context.Context, in *DeleteVDSRequest,
 	return out, nil
 }
 
+func (c *managementClient) ListVDSEvents(ctx context.Context, in *ListVDSEventsRequest, opts ...grpc.CallOption) (*ListVDSEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVDSEventsResponse)
+	err := c.cc.Invoke(ctx, Management_ListVDSEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) RenewVDS(ctx context.Context, in *RenewVDSRequest, opts ...grpc.CallOption) (*VDS, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VDS)
+	err := c.cc.Invoke(ctx, Management_RenewVDS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) SetVDSAutoRenew(ctx context.Context, in *SetVDSAutoRenewRequest, opts ...grpc.CallOption) (*VDS, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VDS)
+	err := c.cc.Invoke(ctx, Management_SetVDSAutoRenew_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) StartVDS(ctx context.Context, in *VDSPowerRequest, opts ...grpc.CallOption) (*VDSPowerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VDSPowerResponse)
+	err := c.cc.Invoke(ctx, Management_StartVDS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) StopVDS(ctx context.Context, in *VDSPowerRequest, opts ...grpc.CallOption) (*VDSPowerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VDSPowerResponse)
+	err := c.cc.Invoke(ctx, Management_StopVDS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) RebootVDS(ctx context.Context, in *VDSPowerRequest, opts ...grpc.CallOption) (*VDSPowerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VDSPowerResponse)
+	err := c.cc.Invoke(ctx, Management_RebootVDS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) PowerOffVDS(ctx context.Context, in *VDSPowerRequest, opts ...grpc.CallOption) (*VDSPowerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VDSPowerResponse)
+	err := c.cc.Invoke(ctx, Management_PowerOffVDS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) WatchVDS(ctx context.Context, in *WatchVDSRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VDS], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Management_ServiceDesc.Streams[0], Management_WatchVDS_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchVDSRequest, VDS]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Management_WatchVDSClient = grpc.ServerStreamingClient[VDS]
+
 func (c *managementClient) CreateTask(ctx context.Context, in *CreateTaskRequest, opts ...grpc.CallOption) (*Task, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Task)
@@ -274,6 +435,25 @@ func (c *managementClient) GetTask(ctx context.Context, in *GetTaskRequest, opts
 	return out, nil
 }
 
+func (c *managementClient) WatchTask(ctx context.Context, in *WatchTaskRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Task], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Management_ServiceDesc.Streams[1], Management_WatchTask_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchTaskRequest, Task]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Management_WatchTaskClient = grpc.ServerStreamingClient[Task]
+
 func (c *managementClient) ListTasksByVDS(ctx context.Context, in *ListTasksByVDSRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListTasksResponse)
@@ -304,16 +484,128 @@ func (c *managementClient) GetPendingTasksCount(ctx context.Context, in *GetPend
 	return out, nil
 }
 
+func (c *managementClient) CreateIPPool(ctx context.Context, in *CreateIPPoolRequest, opts ...grpc.CallOption) (*IPPool, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IPPool)
+	err := c.cc.Invoke(ctx, Management_CreateIPPool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) GetIPPoolUsage(ctx context.Context, in *GetIPPoolRequest, opts ...grpc.CallOption) (*IPPoolUsage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IPPoolUsage)
+	err := c.cc.Invoke(ctx, Management_GetIPPoolUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) ListIPPools(ctx context.Context, in *ListIPPoolsRequest, opts ...grpc.CallOption) (*ListIPPoolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIPPoolsResponse)
+	err := c.cc.Invoke(ctx, Management_ListIPPools_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) DeleteIPPool(ctx context.Context, in *GetIPPoolRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Management_DeleteIPPool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) ListAuditLog(ctx context.Context, in *ListAuditLogRequest, opts ...grpc.CallOption) (*ListAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditLogResponse)
+	err := c.cc.Invoke(ctx, Management_ListAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, Management_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) GetWebhook(ctx context.Context, in *GetWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, Management_GetWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, Management_UpdateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, Management_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Management_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, Management_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ManagementServer is the server API for Management service.
 // All implementations must embed UnimplementedManagementServer
 // for forward compatibility.
 type ManagementServer interface {
 	// === PLAN Operations ===
+	// for admin endpoints
 	CreatePlan(context.Context, *CreatePlanRequest) (*Plan, error)
 	GetPlan(context.Context, *GetPlanRequest) (*Plan, error)
 	UpdatePlan(context.Context, *UpdatePlanRequest) (*Plan, error)
 	ListPlans(context.Context, *ListPlansRequest) (*ListPlansResponse, error)
-	DeletePlan(context.Context, *GetPlanRequest) (*emptypb.Empty, error)
+	DeletePlan(context.Context, *DeletePlanRequest) (*emptypb.Empty, error)
+	ListArchivedPlans(context.Context, *ListArchivedPlansRequest) (*ListPlansResponse, error)
 	// === NODE Operations ===
 	CreateNode(context.Context, *CreateNodeRequest) (*Node, error)
 	GetNode(context.Context, *GetNodeRequest) (*Node, error)
@@ -328,12 +620,51 @@ type ManagementServer interface {
 	UpdateVDSStatus(context.Context, *UpdateVDSStatusRequest) (*VDS, error)
 	AllocateIP(context.Context, *AllocateIPRequest) (*VDS, error)
 	DeleteVDS(context.Context, *DeleteVDSRequest) (*emptypb.Empty, error)
+	ListVDSEvents(context.Context, *ListVDSEventsRequest) (*ListVDSEventsResponse, error)
+	// Продление подписки на месяц. Оплата резервируется с баланса владельца VDS,
+	// поэтому платную VDS продлевает только сам владелец. Снимает приостановку за неоплату
+	RenewVDS(context.Context, *RenewVDSRequest) (*VDS, error)
+	// Автопродление: планировщик продлевает подписку за expiry.renew_before до окончания,
+	// резервируя оплату на балансе владельца по его сохранённой авторизации. Если средств нет,
+	// попытка повторяется через expiry.retry_after, отозванная авторизация отключает автопродление
+	SetVDSAutoRenew(context.Context, *SetVDSAutoRenewRequest) (*VDS, error)
+	// Операции питания: проверяют статус VDS и ставят задачу. Пока по VDS выполняется
+	// другая задача - ERROR_CODE_TASK_IN_PROGRESS, статус не допускает операцию - ERROR_CODE_INVALID_STATE
+	StartVDS(context.Context, *VDSPowerRequest) (*VDSPowerResponse, error)
+	// Корректное выключение через ACPI
+	StopVDS(context.Context, *VDSPowerRequest) (*VDSPowerResponse, error)
+	RebootVDS(context.Context, *VDSPowerRequest) (*VDSPowerResponse, error)
+	// Немедленная остановка, как отключение питания: для VM, которые не реагируют на StopVDS
+	PowerOffVDS(context.Context, *VDSPowerRequest) (*VDSPowerResponse, error)
+	// Текущее состояние VDS, затем каждое изменение статуса. Поток завершается после DELETED
+	WatchVDS(*WatchVDSRequest, grpc.ServerStreamingServer[VDS]) error
 	// === TASK Operations ===
+	// Устарел: задачи питания ставятся через StartVDS, StopVDS, RebootVDS и PowerOffVDS,
+	// create и delete - через CreateVDS и DeleteVDS. Любой тип задачи отклоняется с ERROR_CODE_INVALID_ARGUMENT
 	CreateTask(context.Context, *CreateTaskRequest) (*Task, error)
 	GetTask(context.Context, *GetTaskRequest) (*Task, error)
+	// Текущее состояние задачи, затем каждое изменение статуса. Поток завершается после DONE или ERROR
+	WatchTask(*WatchTaskRequest, grpc.ServerStreamingServer[Task]) error
 	ListTasksByVDS(context.Context, *ListTasksByVDSRequest) (*ListTasksResponse, error)
 	UpdateTaskStatus(context.Context, *UpdateTaskStatusRequest) (*Task, error)
 	GetPendingTasksCount(context.Context, *GetPendingTasksCountRequest) (*GetPendingTasksCountResponse, error)
+	// === IP POOL Operations ===
+	// for admin endpoints
+	CreateIPPool(context.Context, *CreateIPPoolRequest) (*IPPool, error)
+	GetIPPoolUsage(context.Context, *GetIPPoolRequest) (*IPPoolUsage, error)
+	ListIPPools(context.Context, *ListIPPoolsRequest) (*ListIPPoolsResponse, error)
+	DeleteIPPool(context.Context, *GetIPPoolRequest) (*emptypb.Empty, error)
+	// === AUDIT ===
+	// Журнал изменяющих вызовов, только для администраторов
+	ListAuditLog(context.Context, *ListAuditLogRequest) (*ListAuditLogResponse, error)
+	// === WEBHOOK Operations ===
+	// Подписки на доменные события с подписанной доставкой и журналом попыток
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error)
+	GetWebhook(context.Context, *GetWebhookRequest) (*Webhook, error)
+	UpdateWebhook(context.Context, *UpdateWebhookRequest) (*Webhook, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*emptypb.Empty, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
 	mustEmbedUnimplementedManagementServer()
 }
 
@@ -356,9 +687,12 @@ func (UnimplementedManagementServer) UpdatePlan(context.Context, *UpdatePlanRequ
 func (UnimplementedManagementServer) ListPlans(context.Context, *ListPlansRequest) (*ListPlansResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListPlans not implemented")
 }
-func (UnimplementedManagementServer) DeletePlan(context.Context, *GetPlanRequest) (*emptypb.Empty, error) {
+func (UnimplementedManagementServer) DeletePlan(context.Context, *DeletePlanRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeletePlan not implemented")
 }
+func (UnimplementedManagementServer) ListArchivedPlans(context.Context, *ListArchivedPlansRequest) (*ListPlansResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListArchivedPlans not implemented")
+}
 func (UnimplementedManagementServer) CreateNode(context.Context, *CreateNodeRequest) (*Node, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateNode not implemented")
 }
@@ -395,12 +729,39 @@ func (UnimplementedManagementServer) AllocateIP(context.Context, *AllocateIPRequ
 func (UnimplementedManagementServer) DeleteVDS(context.Context, *DeleteVDSRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteVDS not implemented")
 }
+func (UnimplementedManagementServer) ListVDSEvents(context.Context, *ListVDSEventsRequest) (*ListVDSEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListVDSEvents not implemented")
+}
+func (UnimplementedManagementServer) RenewVDS(context.Context, *RenewVDSRequest) (*VDS, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenewVDS not implemented")
+}
+func (UnimplementedManagementServer) SetVDSAutoRenew(context.Context, *SetVDSAutoRenewRequest) (*VDS, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetVDSAutoRenew not implemented")
+}
+func (UnimplementedManagementServer) StartVDS(context.Context, *VDSPowerRequest) (*VDSPowerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartVDS not implemented")
+}
+func (UnimplementedManagementServer) StopVDS(context.Context, *VDSPowerRequest) (*VDSPowerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopVDS not implemented")
+}
+func (UnimplementedManagementServer) RebootVDS(context.Context, *VDSPowerRequest) (*VDSPowerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RebootVDS not implemented")
+}
+func (UnimplementedManagementServer) PowerOffVDS(context.Context, *VDSPowerRequest) (*VDSPowerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PowerOffVDS not implemented")
+}
+func (UnimplementedManagementServer) WatchVDS(*WatchVDSRequest, grpc.ServerStreamingServer[VDS]) error {
+	return status.Error(codes.Unimplemented, "method WatchVDS not implemented")
+}
 func (UnimplementedManagementServer) CreateTask(context.Context, *CreateTaskRequest) (*Task, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateTask not implemented")
 }
 func (UnimplementedManagementServer) GetTask(context.Context, *GetTaskRequest) (*Task, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTask not implemented")
 }
+func (UnimplementedManagementServer) WatchTask(*WatchTaskRequest, grpc.ServerStreamingServer[Task]) error {
+	return status.Error(codes.Unimplemented, "method WatchTask not implemented")
+}
 func (UnimplementedManagementServer) ListTasksByVDS(context.Context, *ListTasksByVDSRequest) (*ListTasksResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListTasksByVDS not implemented")
 }
@@ -410,6 +771,39 @@ func (UnimplementedManagementServer) UpdateTaskStatus(context.Context, *UpdateTa
 func (UnimplementedManagementServer) GetPendingTasksCount(context.Context, *GetPendingTasksCountRequest) (*GetPendingTasksCountResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPendingTasksCount not implemented")
 }
+func (UnimplementedManagementServer) CreateIPPool(context.Context, *CreateIPPoolRequest) (*IPPool, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateIPPool not implemented")
+}
+func (UnimplementedManagementServer) GetIPPoolUsage(context.Context, *GetIPPoolRequest) (*IPPoolUsage, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetIPPoolUsage not implemented")
+}
+func (UnimplementedManagementServer) ListIPPools(context.Context, *ListIPPoolsRequest) (*ListIPPoolsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListIPPools not implemented")
+}
+func (UnimplementedManagementServer) DeleteIPPool(context.Context, *GetIPPoolRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteIPPool not implemented")
+}
+func (UnimplementedManagementServer) ListAuditLog(context.Context, *ListAuditLogRequest) (*ListAuditLogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAuditLog not implemented")
+}
+func (UnimplementedManagementServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedManagementServer) GetWebhook(context.Context, *GetWebhookRequest) (*Webhook, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWebhook not implemented")
+}
+func (UnimplementedManagementServer) UpdateWebhook(context.Context, *UpdateWebhookRequest) (*Webhook, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (UnimplementedManagementServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedManagementServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedManagementServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
 func (UnimplementedManagementServer) mustEmbedUnimplementedManagementServer() {}
 func (UnimplementedManagementServer) testEmbeddedByValue()                    {}
 
@@ -504,7 +898,7 @@ func _Management_ListPlans_Handler(srv interface{}, ctx context.Context, dec fun
 }
 
 func _Management_DeletePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPlanRequest)
+	in := new(DeletePlanRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -516,7 +910,25 @@ func _Management_DeletePlan_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: Management_DeletePlan_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ManagementServer).DeletePlan(ctx, req.(*GetPlanRequest))
+		return srv.(ManagementServer).DeletePlan(ctx, req.(*DeletePlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_ListArchivedPlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArchivedPlansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).ListArchivedPlans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_ListArchivedPlans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).ListArchivedPlans(ctx, req.(*ListArchivedPlansRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -737,6 +1149,143 @@ func _Management_DeleteVDS_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Management_ListVDSEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVDSEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).ListVDSEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_ListVDSEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).ListVDSEvents(ctx, req.(*ListVDSEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_RenewVDS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewVDSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).RenewVDS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_RenewVDS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).RenewVDS(ctx, req.(*RenewVDSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_SetVDSAutoRenew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVDSAutoRenewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).SetVDSAutoRenew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_SetVDSAutoRenew_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).SetVDSAutoRenew(ctx, req.(*SetVDSAutoRenewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_StartVDS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VDSPowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).StartVDS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_StartVDS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).StartVDS(ctx, req.(*VDSPowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_StopVDS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VDSPowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).StopVDS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_StopVDS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).StopVDS(ctx, req.(*VDSPowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_RebootVDS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VDSPowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).RebootVDS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_RebootVDS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).RebootVDS(ctx, req.(*VDSPowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_PowerOffVDS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VDSPowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).PowerOffVDS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_PowerOffVDS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).PowerOffVDS(ctx, req.(*VDSPowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_WatchVDS_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchVDSRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManagementServer).WatchVDS(m, &grpc.GenericServerStream[WatchVDSRequest, VDS]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Management_WatchVDSServer = grpc.ServerStreamingServer[VDS]
+
 func _Management_CreateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateTaskRequest)
 	if err := dec(in); err != nil {
@@ -773,6 +1322,17 @@ func _Management_GetTask_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Management_WatchTask_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTaskRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManagementServer).WatchTask(m, &grpc.GenericServerStream[WatchTaskRequest, Task]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Management_WatchTaskServer = grpc.ServerStreamingServer[Task]
+
 func _Management_ListTasksByVDS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListTasksByVDSRequest)
 	if err := dec(in); err != nil {
@@ -827,6 +1387,204 @@ func _Management_GetPendingTasksCount_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Management_CreateIPPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateIPPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).CreateIPPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_CreateIPPool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).CreateIPPool(ctx, req.(*CreateIPPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_GetIPPoolUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIPPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).GetIPPoolUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_GetIPPoolUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).GetIPPoolUsage(ctx, req.(*GetIPPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_ListIPPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIPPoolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).ListIPPools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_ListIPPools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).ListIPPools(ctx, req.(*ListIPPoolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_DeleteIPPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIPPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).DeleteIPPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_DeleteIPPool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).DeleteIPPool(ctx, req.(*GetIPPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_ListAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).ListAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_ListAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).ListAuditLog(ctx, req.(*ListAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_GetWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).GetWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_GetWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).GetWebhook(ctx, req.(*GetWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).UpdateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_UpdateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).UpdateWebhook(ctx, req.(*UpdateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Management_ServiceDesc is the grpc.ServiceDesc for Management service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -854,6 +1612,10 @@ var Management_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeletePlan",
 			Handler:    _Management_DeletePlan_Handler,
 		},
+		{
+			MethodName: "ListArchivedPlans",
+			Handler:    _Management_ListArchivedPlans_Handler,
+		},
 		{
 			MethodName: "CreateNode",
 			Handler:    _Management_CreateNode_Handler,
@@ -902,6 +1664,34 @@ var Management_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteVDS",
 			Handler:    _Management_DeleteVDS_Handler,
 		},
+		{
+			MethodName: "ListVDSEvents",
+			Handler:    _Management_ListVDSEvents_Handler,
+		},
+		{
+			MethodName: "RenewVDS",
+			Handler:    _Management_RenewVDS_Handler,
+		},
+		{
+			MethodName: "SetVDSAutoRenew",
+			Handler:    _Management_SetVDSAutoRenew_Handler,
+		},
+		{
+			MethodName: "StartVDS",
+			Handler:    _Management_StartVDS_Handler,
+		},
+		{
+			MethodName: "StopVDS",
+			Handler:    _Management_StopVDS_Handler,
+		},
+		{
+			MethodName: "RebootVDS",
+			Handler:    _Management_RebootVDS_Handler,
+		},
+		{
+			MethodName: "PowerOffVDS",
+			Handler:    _Management_PowerOffVDS_Handler,
+		},
 		{
 			MethodName: "CreateTask",
 			Handler:    _Management_CreateTask_Handler,
@@ -922,7 +1712,62 @@ var Management_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPendingTasksCount",
 			Handler:    _Management_GetPendingTasksCount_Handler,
 		},
+		{
+			MethodName: "CreateIPPool",
+			Handler:    _Management_CreateIPPool_Handler,
+		},
+		{
+			MethodName: "GetIPPoolUsage",
+			Handler:    _Management_GetIPPoolUsage_Handler,
+		},
+		{
+			MethodName: "ListIPPools",
+			Handler:    _Management_ListIPPools_Handler,
+		},
+		{
+			MethodName: "DeleteIPPool",
+			Handler:    _Management_DeleteIPPool_Handler,
+		},
+		{
+			MethodName: "ListAuditLog",
+			Handler:    _Management_ListAuditLog_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _Management_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "GetWebhook",
+			Handler:    _Management_GetWebhook_Handler,
+		},
+		{
+			MethodName: "UpdateWebhook",
+			Handler:    _Management_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _Management_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _Management_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _Management_ListWebhookDeliveries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchVDS",
+			Handler:       _Management_WatchVDS_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchTask",
+			Handler:       _Management_WatchTask_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "management/management.proto",
 }
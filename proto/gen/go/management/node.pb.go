@@ -32,6 +32,8 @@ type Node struct {
 	MaxDisk       int32                  `protobuf:"varint,6,opt,name=max_disk,json=maxDisk,proto3" json:"max_disk,omitempty"`
 	IsActive      bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Region        string                 `protobuf:"bytes,9,opt,name=region,proto3" json:"region,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -122,6 +124,20 @@ func (x *Node) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Node) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Node) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type CreateNodeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -129,6 +145,8 @@ type CreateNodeRequest struct {
 	MaxCpu        int32                  `protobuf:"varint,3,opt,name=max_cpu,json=maxCpu,proto3" json:"max_cpu,omitempty"`
 	MaxRam        int32                  `protobuf:"varint,4,opt,name=max_ram,json=maxRam,proto3" json:"max_ram,omitempty"`
 	MaxDisk       int32                  `protobuf:"varint,5,opt,name=max_disk,json=maxDisk,proto3" json:"max_disk,omitempty"`
+	Region        string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -198,6 +216,65 @@ func (x *CreateNodeRequest) GetMaxDisk() int32 {
 	return 0
 }
 
+func (x *CreateNodeRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *CreateNodeRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// NodeLabels - обёртка над метками, чтобы отличать "не менять" от "очистить"
+type NodeLabels struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Labels        map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeLabels) Reset() {
+	*x = NodeLabels{}
+	mi := &file_management_node_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeLabels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeLabels) ProtoMessage() {}
+
+func (x *NodeLabels) ProtoReflect() protoreflect.Message {
+	mi := &file_management_node_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeLabels.ProtoReflect.Descriptor instead.
+func (*NodeLabels) Descriptor() ([]byte, []int) {
+	return file_management_node_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NodeLabels) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type UpdateNodeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -206,13 +283,15 @@ type UpdateNodeRequest struct {
 	MaxRam        *int32                 `protobuf:"varint,4,opt,name=max_ram,json=maxRam,proto3,oneof" json:"max_ram,omitempty"`
 	MaxDisk       *int32                 `protobuf:"varint,5,opt,name=max_disk,json=maxDisk,proto3,oneof" json:"max_disk,omitempty"`
 	IsActive      *bool                  `protobuf:"varint,6,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	Region        *string                `protobuf:"bytes,7,opt,name=region,proto3,oneof" json:"region,omitempty"`
+	Labels        *NodeLabels            `protobuf:"bytes,8,opt,name=labels,proto3" json:"labels,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateNodeRequest) Reset() {
 	*x = UpdateNodeRequest{}
-	mi := &file_management_node_proto_msgTypes[2]
+	mi := &file_management_node_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -224,7 +303,7 @@ func (x *UpdateNodeRequest) String() string {
 func (*UpdateNodeRequest) ProtoMessage() {}
 
 func (x *UpdateNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_management_node_proto_msgTypes[2]
+	mi := &file_management_node_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -237,7 +316,7 @@ func (x *UpdateNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateNodeRequest.ProtoReflect.Descriptor instead.
 func (*UpdateNodeRequest) Descriptor() ([]byte, []int) {
-	return file_management_node_proto_rawDescGZIP(), []int{2}
+	return file_management_node_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *UpdateNodeRequest) GetId() int32 {
@@ -282,6 +361,20 @@ func (x *UpdateNodeRequest) GetIsActive() bool {
 	return false
 }
 
+func (x *UpdateNodeRequest) GetRegion() string {
+	if x != nil && x.Region != nil {
+		return *x.Region
+	}
+	return ""
+}
+
+func (x *UpdateNodeRequest) GetLabels() *NodeLabels {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type GetNodeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -291,7 +384,7 @@ type GetNodeRequest struct {
 
 func (x *GetNodeRequest) Reset() {
 	*x = GetNodeRequest{}
-	mi := &file_management_node_proto_msgTypes[3]
+	mi := &file_management_node_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -303,7 +396,7 @@ func (x *GetNodeRequest) String() string {
 func (*GetNodeRequest) ProtoMessage() {}
 
 func (x *GetNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_management_node_proto_msgTypes[3]
+	mi := &file_management_node_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -316,7 +409,7 @@ func (x *GetNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNodeRequest.ProtoReflect.Descriptor instead.
 func (*GetNodeRequest) Descriptor() ([]byte, []int) {
-	return file_management_node_proto_rawDescGZIP(), []int{3}
+	return file_management_node_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetNodeRequest) GetId() int32 {
@@ -327,15 +420,26 @@ func (x *GetNodeRequest) GetId() int32 {
 }
 
 type ListNodesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ActiveOnly    bool                   `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ActiveOnly bool                   `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	// Размер страницы: 0 - 50, не больше 500
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token из предыдущего ответа, пусто - первая страница.
+	// Токен действителен только с теми же фильтрами и order_by
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Сортировка "поле [asc|desc]", по умолчанию "id". Поля: id, name, region, created_at
+	OrderBy string  `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	Region  *string `protobuf:"bytes,5,opt,name=region,proto3,oneof" json:"region,omitempty"`
+	// Время создания: from включительно, to не включительно
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListNodesRequest) Reset() {
 	*x = ListNodesRequest{}
-	mi := &file_management_node_proto_msgTypes[4]
+	mi := &file_management_node_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -347,7 +451,7 @@ func (x *ListNodesRequest) String() string {
 func (*ListNodesRequest) ProtoMessage() {}
 
 func (x *ListNodesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_management_node_proto_msgTypes[4]
+	mi := &file_management_node_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -360,7 +464,7 @@ func (x *ListNodesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNodesRequest.ProtoReflect.Descriptor instead.
 func (*ListNodesRequest) Descriptor() ([]byte, []int) {
-	return file_management_node_proto_rawDescGZIP(), []int{4}
+	return file_management_node_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListNodesRequest) GetActiveOnly() bool {
@@ -370,16 +474,60 @@ func (x *ListNodesRequest) GetActiveOnly() bool {
 	return false
 }
 
+func (x *ListNodesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListNodesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListNodesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListNodesRequest) GetRegion() string {
+	if x != nil && x.Region != nil {
+		return *x.Region
+	}
+	return ""
+}
+
+func (x *ListNodesRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListNodesRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
 type ListNodesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Nodes         []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Nodes []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// Токен следующей страницы, пусто - страница последняя
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListNodesResponse) Reset() {
 	*x = ListNodesResponse{}
-	mi := &file_management_node_proto_msgTypes[5]
+	mi := &file_management_node_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -391,7 +539,7 @@ func (x *ListNodesResponse) String() string {
 func (*ListNodesResponse) ProtoMessage() {}
 
 func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_management_node_proto_msgTypes[5]
+	mi := &file_management_node_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -404,7 +552,7 @@ func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNodesResponse.ProtoReflect.Descriptor instead.
 func (*ListNodesResponse) Descriptor() ([]byte, []int) {
-	return file_management_node_proto_rawDescGZIP(), []int{5}
+	return file_management_node_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListNodesResponse) GetNodes() []*Node {
@@ -414,6 +562,13 @@ func (x *ListNodesResponse) GetNodes() []*Node {
 	return nil
 }
 
+func (x *ListNodesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type NodeUtilization struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NodeId        int32                  `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
@@ -434,7 +589,7 @@ type NodeUtilization struct {
 
 func (x *NodeUtilization) Reset() {
 	*x = NodeUtilization{}
-	mi := &file_management_node_proto_msgTypes[6]
+	mi := &file_management_node_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -446,7 +601,7 @@ func (x *NodeUtilization) String() string {
 func (*NodeUtilization) ProtoMessage() {}
 
 func (x *NodeUtilization) ProtoReflect() protoreflect.Message {
-	mi := &file_management_node_proto_msgTypes[6]
+	mi := &file_management_node_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -459,7 +614,7 @@ func (x *NodeUtilization) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeUtilization.ProtoReflect.Descriptor instead.
 func (*NodeUtilization) Descriptor() ([]byte, []int) {
-	return file_management_node_proto_rawDescGZIP(), []int{6}
+	return file_management_node_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *NodeUtilization) GetNodeId() int32 {
@@ -551,7 +706,7 @@ var File_management_node_proto protoreflect.FileDescriptor
 const file_management_node_proto_rawDesc = "" +
 	"\n" +
 	"\x15management/node.proto\x12\n" +
-	"management\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe8\x01\n" +
+	"management\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf1\x02\n" +
 	"\x04Node\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x17\n" +
@@ -561,20 +716,39 @@ const file_management_node_proto_rawDesc = "" +
 	"\bmax_disk\x18\x06 \x01(\x05R\amaxDisk\x12\x1b\n" +
 	"\tis_active\x18\a \x01(\bR\bisActive\x129\n" +
 	"\n" +
-	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x8d\x01\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06region\x18\t \x01(\tR\x06region\x124\n" +
+	"\x06labels\x18\n" +
+	" \x03(\v2\x1c.management.Node.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa3\x02\n" +
 	"\x11CreateNodeRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x17\n" +
 	"\aapi_url\x18\x02 \x01(\tR\x06apiUrl\x12\x17\n" +
 	"\amax_cpu\x18\x03 \x01(\x05R\x06maxCpu\x12\x17\n" +
 	"\amax_ram\x18\x04 \x01(\x05R\x06maxRam\x12\x19\n" +
-	"\bmax_disk\x18\x05 \x01(\x05R\amaxDisk\"\xfe\x01\n" +
+	"\bmax_disk\x18\x05 \x01(\x05R\amaxDisk\x12\x16\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x12A\n" +
+	"\x06labels\x18\a \x03(\v2).management.CreateNodeRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x83\x01\n" +
+	"\n" +
+	"NodeLabels\x12:\n" +
+	"\x06labels\x18\x01 \x03(\v2\".management.NodeLabels.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd6\x02\n" +
 	"\x11UpdateNodeRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1c\n" +
 	"\aapi_url\x18\x02 \x01(\tH\x00R\x06apiUrl\x88\x01\x01\x12\x1c\n" +
 	"\amax_cpu\x18\x03 \x01(\x05H\x01R\x06maxCpu\x88\x01\x01\x12\x1c\n" +
 	"\amax_ram\x18\x04 \x01(\x05H\x02R\x06maxRam\x88\x01\x01\x12\x1e\n" +
 	"\bmax_disk\x18\x05 \x01(\x05H\x03R\amaxDisk\x88\x01\x01\x12 \n" +
-	"\tis_active\x18\x06 \x01(\bH\x04R\bisActive\x88\x01\x01B\n" +
+	"\tis_active\x18\x06 \x01(\bH\x04R\bisActive\x88\x01\x01\x12\x1b\n" +
+	"\x06region\x18\a \x01(\tH\x05R\x06region\x88\x01\x01\x12.\n" +
+	"\x06labels\x18\b \x01(\v2\x16.management.NodeLabelsR\x06labelsB\n" +
 	"\n" +
 	"\b_api_urlB\n" +
 	"\n" +
@@ -583,14 +757,25 @@ const file_management_node_proto_rawDesc = "" +
 	"\b_max_ramB\v\n" +
 	"\t_max_diskB\f\n" +
 	"\n" +
-	"_is_active\" \n" +
+	"_is_activeB\t\n" +
+	"\a_region\" \n" +
 	"\x0eGetNodeRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"3\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\xac\x02\n" +
 	"\x10ListNodesRequest\x12\x1f\n" +
 	"\vactive_only\x18\x01 \x01(\bR\n" +
-	"activeOnly\";\n" +
+	"activeOnly\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12\x1b\n" +
+	"\x06region\x18\x05 \x01(\tH\x00R\x06region\x88\x01\x01\x12=\n" +
+	"\fcreated_from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedToB\t\n" +
+	"\a_region\"c\n" +
 	"\x11ListNodesResponse\x12&\n" +
-	"\x05nodes\x18\x01 \x03(\v2\x10.management.NodeR\x05nodes\"\xf2\x02\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x10.management.NodeR\x05nodes\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xf2\x02\n" +
 	"\x0fNodeUtilization\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\x05R\x06nodeId\x12\x1b\n" +
 	"\tnode_name\x18\x02 \x01(\tR\bnodeName\x12\x17\n" +
@@ -618,25 +803,35 @@ func file_management_node_proto_rawDescGZIP() []byte {
 	return file_management_node_proto_rawDescData
 }
 
-var file_management_node_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_management_node_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_management_node_proto_goTypes = []any{
 	(*Node)(nil),                  // 0: management.Node
 	(*CreateNodeRequest)(nil),     // 1: management.CreateNodeRequest
-	(*UpdateNodeRequest)(nil),     // 2: management.UpdateNodeRequest
-	(*GetNodeRequest)(nil),        // 3: management.GetNodeRequest
-	(*ListNodesRequest)(nil),      // 4: management.ListNodesRequest
-	(*ListNodesResponse)(nil),     // 5: management.ListNodesResponse
-	(*NodeUtilization)(nil),       // 6: management.NodeUtilization
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*NodeLabels)(nil),            // 2: management.NodeLabels
+	(*UpdateNodeRequest)(nil),     // 3: management.UpdateNodeRequest
+	(*GetNodeRequest)(nil),        // 4: management.GetNodeRequest
+	(*ListNodesRequest)(nil),      // 5: management.ListNodesRequest
+	(*ListNodesResponse)(nil),     // 6: management.ListNodesResponse
+	(*NodeUtilization)(nil),       // 7: management.NodeUtilization
+	nil,                           // 8: management.Node.LabelsEntry
+	nil,                           // 9: management.CreateNodeRequest.LabelsEntry
+	nil,                           // 10: management.NodeLabels.LabelsEntry
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
 }
 var file_management_node_proto_depIdxs = []int32{
-	7, // 0: management.Node.created_at:type_name -> google.protobuf.Timestamp
-	0, // 1: management.ListNodesResponse.nodes:type_name -> management.Node
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	11, // 0: management.Node.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 1: management.Node.labels:type_name -> management.Node.LabelsEntry
+	9,  // 2: management.CreateNodeRequest.labels:type_name -> management.CreateNodeRequest.LabelsEntry
+	10, // 3: management.NodeLabels.labels:type_name -> management.NodeLabels.LabelsEntry
+	2,  // 4: management.UpdateNodeRequest.labels:type_name -> management.NodeLabels
+	11, // 5: management.ListNodesRequest.created_from:type_name -> google.protobuf.Timestamp
+	11, // 6: management.ListNodesRequest.created_to:type_name -> google.protobuf.Timestamp
+	0,  // 7: management.ListNodesResponse.nodes:type_name -> management.Node
+	8,  // [8:8] is the sub-list for method output_type
+	8,  // [8:8] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_management_node_proto_init() }
@@ -644,14 +839,15 @@ func file_management_node_proto_init() {
 	if File_management_node_proto != nil {
 		return
 	}
-	file_management_node_proto_msgTypes[2].OneofWrappers = []any{}
+	file_management_node_proto_msgTypes[3].OneofWrappers = []any{}
+	file_management_node_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_management_node_proto_rawDesc), len(file_management_node_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
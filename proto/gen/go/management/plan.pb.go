@@ -23,15 +23,17 @@ const (
 )
 
 type Plan struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Cpu           int32                  `protobuf:"varint,3,opt,name=cpu,proto3" json:"cpu,omitempty"`
-	RamMb         int32                  `protobuf:"varint,4,opt,name=ram_mb,json=ramMb,proto3" json:"ram_mb,omitempty"`
-	DiskGb        int32                  `protobuf:"varint,5,opt,name=disk_gb,json=diskGb,proto3" json:"disk_gb,omitempty"`
-	PriceMonth    float64                `protobuf:"fixed64,6,opt,name=price_month,json=priceMonth,proto3" json:"price_month,omitempty"`
-	IsActive      bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Cpu        int32                  `protobuf:"varint,3,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	RamMb      int32                  `protobuf:"varint,4,opt,name=ram_mb,json=ramMb,proto3" json:"ram_mb,omitempty"`
+	DiskGb     int32                  `protobuf:"varint,5,opt,name=disk_gb,json=diskGb,proto3" json:"disk_gb,omitempty"`
+	PriceMonth float64                `protobuf:"fixed64,6,opt,name=price_month,json=priceMonth,proto3" json:"price_month,omitempty"`
+	IsActive   bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Время архивации, не заполнено для планов в продаже
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -122,6 +124,13 @@ func (x *Plan) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Plan) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
 type CreatePlanRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -334,16 +343,84 @@ func (x *GetPlanRequest) GetId() int32 {
 	return 0
 }
 
+type DeletePlanRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Архивировать план вместо удаления: план снимается с продажи и скрывается из ListPlans,
+	// но остаётся для истории биллинга и продления купленных VDS.
+	// План, по которому есть VDS, можно только архивировать (ERROR_CODE_PLAN_IN_USE)
+	Archive       bool `protobuf:"varint,2,opt,name=archive,proto3" json:"archive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePlanRequest) Reset() {
+	*x = DeletePlanRequest{}
+	mi := &file_management_plan_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePlanRequest) ProtoMessage() {}
+
+func (x *DeletePlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_plan_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePlanRequest.ProtoReflect.Descriptor instead.
+func (*DeletePlanRequest) Descriptor() ([]byte, []int) {
+	return file_management_plan_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeletePlanRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeletePlanRequest) GetArchive() bool {
+	if x != nil {
+		return x.Archive
+	}
+	return false
+}
+
 type ListPlansRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ActiveOnly    bool                   `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ActiveOnly bool                   `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	// Размер страницы: 0 - 50, не больше 500
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token из предыдущего ответа, пусто - первая страница.
+	// Токен действителен только с теми же фильтрами и order_by
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Сортировка "поле [asc|desc]", по умолчанию "id". Поля: id, name, cpu, ram_mb, price_month, created_at
+	OrderBy string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Цена за месяц включительно
+	MinPrice *float64 `protobuf:"fixed64,5,opt,name=min_price,json=minPrice,proto3,oneof" json:"min_price,omitempty"`
+	MaxPrice *float64 `protobuf:"fixed64,6,opt,name=max_price,json=maxPrice,proto3,oneof" json:"max_price,omitempty"`
+	// Время создания: from включительно, to не включительно
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListPlansRequest) Reset() {
 	*x = ListPlansRequest{}
-	mi := &file_management_plan_proto_msgTypes[4]
+	mi := &file_management_plan_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -355,7 +432,7 @@ func (x *ListPlansRequest) String() string {
 func (*ListPlansRequest) ProtoMessage() {}
 
 func (x *ListPlansRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_management_plan_proto_msgTypes[4]
+	mi := &file_management_plan_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -368,7 +445,7 @@ func (x *ListPlansRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPlansRequest.ProtoReflect.Descriptor instead.
 func (*ListPlansRequest) Descriptor() ([]byte, []int) {
-	return file_management_plan_proto_rawDescGZIP(), []int{4}
+	return file_management_plan_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListPlansRequest) GetActiveOnly() bool {
@@ -378,16 +455,67 @@ func (x *ListPlansRequest) GetActiveOnly() bool {
 	return false
 }
 
+func (x *ListPlansRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPlansRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListPlansRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListPlansRequest) GetMinPrice() float64 {
+	if x != nil && x.MinPrice != nil {
+		return *x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListPlansRequest) GetMaxPrice() float64 {
+	if x != nil && x.MaxPrice != nil {
+		return *x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListPlansRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListPlansRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
 type ListPlansResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Plans         []*Plan                `protobuf:"bytes,1,rep,name=plans,proto3" json:"plans,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Plans []*Plan                `protobuf:"bytes,1,rep,name=plans,proto3" json:"plans,omitempty"`
+	// Токен следующей страницы, пусто - страница последняя
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListPlansResponse) Reset() {
 	*x = ListPlansResponse{}
-	mi := &file_management_plan_proto_msgTypes[5]
+	mi := &file_management_plan_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -399,7 +527,7 @@ func (x *ListPlansResponse) String() string {
 func (*ListPlansResponse) ProtoMessage() {}
 
 func (x *ListPlansResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_management_plan_proto_msgTypes[5]
+	mi := &file_management_plan_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -412,7 +540,7 @@ func (x *ListPlansResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPlansResponse.ProtoReflect.Descriptor instead.
 func (*ListPlansResponse) Descriptor() ([]byte, []int) {
-	return file_management_plan_proto_rawDescGZIP(), []int{5}
+	return file_management_plan_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListPlansResponse) GetPlans() []*Plan {
@@ -422,12 +550,83 @@ func (x *ListPlansResponse) GetPlans() []*Plan {
 	return nil
 }
 
+func (x *ListPlansResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListArchivedPlansRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Размер страницы: 0 - 50, не больше 500
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token из предыдущего ответа, пусто - первая страница.
+	// Токен действителен только с теми же фильтрами и order_by
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Сортировка "поле [asc|desc]", по умолчанию "deleted_at desc". Поля: id, name, cpu, ram_mb, price_month, created_at, deleted_at
+	OrderBy       string `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArchivedPlansRequest) Reset() {
+	*x = ListArchivedPlansRequest{}
+	mi := &file_management_plan_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArchivedPlansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArchivedPlansRequest) ProtoMessage() {}
+
+func (x *ListArchivedPlansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_plan_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArchivedPlansRequest.ProtoReflect.Descriptor instead.
+func (*ListArchivedPlansRequest) Descriptor() ([]byte, []int) {
+	return file_management_plan_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListArchivedPlansRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListArchivedPlansRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListArchivedPlansRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
 var File_management_plan_proto protoreflect.FileDescriptor
 
 const file_management_plan_proto_rawDesc = "" +
 	"\n" +
 	"\x15management/plan.proto\x12\n" +
-	"management\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe5\x01\n" +
+	"management\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa0\x02\n" +
 	"\x04Plan\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
@@ -438,7 +637,9 @@ const file_management_plan_proto_rawDesc = "" +
 	"priceMonth\x12\x1b\n" +
 	"\tis_active\x18\a \x01(\bR\bisActive\x129\n" +
 	"\n" +
-	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x8a\x01\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\x8a\x01\n" +
 	"\x11CreatePlanRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
 	"\x03cpu\x18\x02 \x01(\x05R\x03cpu\x12\x15\n" +
@@ -464,12 +665,34 @@ const file_management_plan_proto_rawDesc = "" +
 	"\n" +
 	"_is_active\" \n" +
 	"\x0eGetPlanRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"3\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"=\n" +
+	"\x11DeletePlanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
+	"\aarchive\x18\x02 \x01(\bR\aarchive\"\xe4\x02\n" +
 	"\x10ListPlansRequest\x12\x1f\n" +
 	"\vactive_only\x18\x01 \x01(\bR\n" +
-	"activeOnly\";\n" +
+	"activeOnly\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12 \n" +
+	"\tmin_price\x18\x05 \x01(\x01H\x00R\bminPrice\x88\x01\x01\x12 \n" +
+	"\tmax_price\x18\x06 \x01(\x01H\x01R\bmaxPrice\x88\x01\x01\x12=\n" +
+	"\fcreated_from\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedToB\f\n" +
+	"\n" +
+	"_min_priceB\f\n" +
+	"\n" +
+	"_max_price\"c\n" +
 	"\x11ListPlansResponse\x12&\n" +
-	"\x05plans\x18\x01 \x03(\v2\x10.management.PlanR\x05plansBCZAgithub.com/makhkets/management/pkg/api/management/v1;managementv1b\x06proto3"
+	"\x05plans\x18\x01 \x03(\v2\x10.management.PlanR\x05plans\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"q\n" +
+	"\x18ListArchivedPlansRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderByBCZAgithub.com/makhkets/management/pkg/api/management/v1;managementv1b\x06proto3"
 
 var (
 	file_management_plan_proto_rawDescOnce sync.Once
@@ -483,24 +706,29 @@ func file_management_plan_proto_rawDescGZIP() []byte {
 	return file_management_plan_proto_rawDescData
 }
 
-var file_management_plan_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_management_plan_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_management_plan_proto_goTypes = []any{
-	(*Plan)(nil),                  // 0: management.Plan
-	(*CreatePlanRequest)(nil),     // 1: management.CreatePlanRequest
-	(*UpdatePlanRequest)(nil),     // 2: management.UpdatePlanRequest
-	(*GetPlanRequest)(nil),        // 3: management.GetPlanRequest
-	(*ListPlansRequest)(nil),      // 4: management.ListPlansRequest
-	(*ListPlansResponse)(nil),     // 5: management.ListPlansResponse
-	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*Plan)(nil),                     // 0: management.Plan
+	(*CreatePlanRequest)(nil),        // 1: management.CreatePlanRequest
+	(*UpdatePlanRequest)(nil),        // 2: management.UpdatePlanRequest
+	(*GetPlanRequest)(nil),           // 3: management.GetPlanRequest
+	(*DeletePlanRequest)(nil),        // 4: management.DeletePlanRequest
+	(*ListPlansRequest)(nil),         // 5: management.ListPlansRequest
+	(*ListPlansResponse)(nil),        // 6: management.ListPlansResponse
+	(*ListArchivedPlansRequest)(nil), // 7: management.ListArchivedPlansRequest
+	(*timestamppb.Timestamp)(nil),    // 8: google.protobuf.Timestamp
 }
 var file_management_plan_proto_depIdxs = []int32{
-	6, // 0: management.Plan.created_at:type_name -> google.protobuf.Timestamp
-	0, // 1: management.ListPlansResponse.plans:type_name -> management.Plan
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	8, // 0: management.Plan.created_at:type_name -> google.protobuf.Timestamp
+	8, // 1: management.Plan.deleted_at:type_name -> google.protobuf.Timestamp
+	8, // 2: management.ListPlansRequest.created_from:type_name -> google.protobuf.Timestamp
+	8, // 3: management.ListPlansRequest.created_to:type_name -> google.protobuf.Timestamp
+	0, // 4: management.ListPlansResponse.plans:type_name -> management.Plan
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_management_plan_proto_init() }
@@ -509,13 +737,14 @@ func file_management_plan_proto_init() {
 		return
 	}
 	file_management_plan_proto_msgTypes[2].OneofWrappers = []any{}
+	file_management_plan_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_management_plan_proto_rawDesc), len(file_management_plan_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
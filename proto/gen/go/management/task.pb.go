@@ -31,6 +31,8 @@ const (
 	TaskType_TASK_TYPE_START   TaskType = 3
 	TaskType_TASK_TYPE_STOP    TaskType = 4
 	TaskType_TASK_TYPE_RESTART TaskType = 5
+	// Немедленная остановка VM без корректного выключения ОС
+	TaskType_TASK_TYPE_POWEROFF TaskType = 6
 )
 
 // Enum value maps for TaskType.
@@ -42,14 +44,16 @@ var (
 		3: "TASK_TYPE_START",
 		4: "TASK_TYPE_STOP",
 		5: "TASK_TYPE_RESTART",
+		6: "TASK_TYPE_POWEROFF",
 	}
 	TaskType_value = map[string]int32{
-		"TASK_TYPE_UNKNOWN": 0,
-		"TASK_TYPE_CREATE":  1,
-		"TASK_TYPE_DELETE":  2,
-		"TASK_TYPE_START":   3,
-		"TASK_TYPE_STOP":    4,
-		"TASK_TYPE_RESTART": 5,
+		"TASK_TYPE_UNKNOWN":  0,
+		"TASK_TYPE_CREATE":   1,
+		"TASK_TYPE_DELETE":   2,
+		"TASK_TYPE_START":    3,
+		"TASK_TYPE_STOP":     4,
+		"TASK_TYPE_RESTART":  5,
+		"TASK_TYPE_POWEROFF": 6,
 	}
 )
 
@@ -136,15 +140,17 @@ func (TaskStatus) EnumDescriptor() ([]byte, []int) {
 }
 
 type Task struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	VdsId         int32                  `protobuf:"varint,2,opt,name=vds_id,json=vdsId,proto3" json:"vds_id,omitempty"`
-	Type          TaskType               `protobuf:"varint,3,opt,name=type,proto3,enum=management.TaskType" json:"type,omitempty"`
-	Status        TaskStatus             `protobuf:"varint,4,opt,name=status,proto3,enum=management.TaskStatus" json:"status,omitempty"`
-	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	VdsId       int32                  `protobuf:"varint,2,opt,name=vds_id,json=vdsId,proto3" json:"vds_id,omitempty"`
+	Type        TaskType               `protobuf:"varint,3,opt,name=type,proto3,enum=management.TaskType" json:"type,omitempty"`
+	Status      TaskStatus             `protobuf:"varint,4,opt,name=status,proto3,enum=management.TaskStatus" json:"status,omitempty"`
+	Error       string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// Класс ошибки для задач в статусе ERROR (например, ERROR_CODE_PROXMOX_ERROR)
+	ErrorCode     ErrorCode `protobuf:"varint,9,opt,name=error_code,json=errorCode,proto3,enum=management.ErrorCode" json:"error_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -235,6 +241,13 @@ func (x *Task) GetCompletedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Task) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_ERROR_CODE_OK
+}
+
 type CreateTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	VdsId         int32                  `protobuf:"varint,1,opt,name=vds_id,json=vdsId,proto3" json:"vds_id,omitempty"`
@@ -331,16 +344,72 @@ func (x *GetTaskRequest) GetId() int32 {
 	return 0
 }
 
-type ListTasksByVDSRequest struct {
+type WatchTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	VdsId         int32                  `protobuf:"varint,1,opt,name=vds_id,json=vdsId,proto3" json:"vds_id,omitempty"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTaskRequest) Reset() {
+	*x = WatchTaskRequest{}
+	mi := &file_management_task_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTaskRequest) ProtoMessage() {}
+
+func (x *WatchTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_task_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTaskRequest.ProtoReflect.Descriptor instead.
+func (*WatchTaskRequest) Descriptor() ([]byte, []int) {
+	return file_management_task_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WatchTaskRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListTasksByVDSRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	VdsId int32                  `protobuf:"varint,1,opt,name=vds_id,json=vdsId,proto3" json:"vds_id,omitempty"`
+	// Размер страницы: 0 - 50, не больше 500
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token из предыдущего ответа, пусто - первая страница.
+	// Токен действителен только с теми же фильтрами и order_by
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Сортировка "поле [asc|desc]", по умолчанию "created_at desc". Поля: id, status, created_at
+	OrderBy string      `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	Status  *TaskStatus `protobuf:"varint,5,opt,name=status,proto3,enum=management.TaskStatus,oneof" json:"status,omitempty"`
+	Type    *TaskType   `protobuf:"varint,6,opt,name=type,proto3,enum=management.TaskType,oneof" json:"type,omitempty"`
+	// Время создания: from включительно, to не включительно
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListTasksByVDSRequest) Reset() {
 	*x = ListTasksByVDSRequest{}
-	mi := &file_management_task_proto_msgTypes[3]
+	mi := &file_management_task_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -352,7 +421,7 @@ func (x *ListTasksByVDSRequest) String() string {
 func (*ListTasksByVDSRequest) ProtoMessage() {}
 
 func (x *ListTasksByVDSRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_management_task_proto_msgTypes[3]
+	mi := &file_management_task_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,7 +434,7 @@ func (x *ListTasksByVDSRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTasksByVDSRequest.ProtoReflect.Descriptor instead.
 func (*ListTasksByVDSRequest) Descriptor() ([]byte, []int) {
-	return file_management_task_proto_rawDescGZIP(), []int{3}
+	return file_management_task_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ListTasksByVDSRequest) GetVdsId() int32 {
@@ -375,16 +444,67 @@ func (x *ListTasksByVDSRequest) GetVdsId() int32 {
 	return 0
 }
 
+func (x *ListTasksByVDSRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTasksByVDSRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTasksByVDSRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListTasksByVDSRequest) GetStatus() TaskStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNKNOWN
+}
+
+func (x *ListTasksByVDSRequest) GetType() TaskType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return TaskType_TASK_TYPE_UNKNOWN
+}
+
+func (x *ListTasksByVDSRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListTasksByVDSRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
 type ListTasksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// Токен следующей страницы, пусто - страница последняя
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListTasksResponse) Reset() {
 	*x = ListTasksResponse{}
-	mi := &file_management_task_proto_msgTypes[4]
+	mi := &file_management_task_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -396,7 +516,7 @@ func (x *ListTasksResponse) String() string {
 func (*ListTasksResponse) ProtoMessage() {}
 
 func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_management_task_proto_msgTypes[4]
+	mi := &file_management_task_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -409,7 +529,7 @@ func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
 func (*ListTasksResponse) Descriptor() ([]byte, []int) {
-	return file_management_task_proto_rawDescGZIP(), []int{4}
+	return file_management_task_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListTasksResponse) GetTasks() []*Task {
@@ -419,6 +539,13 @@ func (x *ListTasksResponse) GetTasks() []*Task {
 	return nil
 }
 
+func (x *ListTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type UpdateTaskStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -430,7 +557,7 @@ type UpdateTaskStatusRequest struct {
 
 func (x *UpdateTaskStatusRequest) Reset() {
 	*x = UpdateTaskStatusRequest{}
-	mi := &file_management_task_proto_msgTypes[5]
+	mi := &file_management_task_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -442,7 +569,7 @@ func (x *UpdateTaskStatusRequest) String() string {
 func (*UpdateTaskStatusRequest) ProtoMessage() {}
 
 func (x *UpdateTaskStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_management_task_proto_msgTypes[5]
+	mi := &file_management_task_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -455,7 +582,7 @@ func (x *UpdateTaskStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTaskStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateTaskStatusRequest) Descriptor() ([]byte, []int) {
-	return file_management_task_proto_rawDescGZIP(), []int{5}
+	return file_management_task_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UpdateTaskStatusRequest) GetId() int32 {
@@ -488,7 +615,7 @@ type GetPendingTasksCountRequest struct {
 
 func (x *GetPendingTasksCountRequest) Reset() {
 	*x = GetPendingTasksCountRequest{}
-	mi := &file_management_task_proto_msgTypes[6]
+	mi := &file_management_task_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -500,7 +627,7 @@ func (x *GetPendingTasksCountRequest) String() string {
 func (*GetPendingTasksCountRequest) ProtoMessage() {}
 
 func (x *GetPendingTasksCountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_management_task_proto_msgTypes[6]
+	mi := &file_management_task_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -513,7 +640,7 @@ func (x *GetPendingTasksCountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPendingTasksCountRequest.ProtoReflect.Descriptor instead.
 func (*GetPendingTasksCountRequest) Descriptor() ([]byte, []int) {
-	return file_management_task_proto_rawDescGZIP(), []int{6}
+	return file_management_task_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetPendingTasksCountRequest) GetVdsId() int32 {
@@ -532,7 +659,7 @@ type GetPendingTasksCountResponse struct {
 
 func (x *GetPendingTasksCountResponse) Reset() {
 	*x = GetPendingTasksCountResponse{}
-	mi := &file_management_task_proto_msgTypes[7]
+	mi := &file_management_task_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -544,7 +671,7 @@ func (x *GetPendingTasksCountResponse) String() string {
 func (*GetPendingTasksCountResponse) ProtoMessage() {}
 
 func (x *GetPendingTasksCountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_management_task_proto_msgTypes[7]
+	mi := &file_management_task_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,7 +684,7 @@ func (x *GetPendingTasksCountResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPendingTasksCountResponse.ProtoReflect.Descriptor instead.
 func (*GetPendingTasksCountResponse) Descriptor() ([]byte, []int) {
-	return file_management_task_proto_rawDescGZIP(), []int{7}
+	return file_management_task_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetPendingTasksCountResponse) GetCount() int32 {
@@ -572,7 +699,7 @@ var File_management_task_proto protoreflect.FileDescriptor
 const file_management_task_proto_rawDesc = "" +
 	"\n" +
 	"\x15management/task.proto\x12\n" +
-	"management\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd2\x02\n" +
+	"management\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17management/errors.proto\"\x88\x03\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x15\n" +
 	"\x06vds_id\x18\x02 \x01(\x05R\x05vdsId\x12(\n" +
@@ -583,16 +710,32 @@ const file_management_task_proto_rawDesc = "" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"started_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
-	"\fcompleted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"T\n" +
+	"\fcompleted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x124\n" +
+	"\n" +
+	"error_code\x18\t \x01(\x0e2\x15.management.ErrorCodeR\terrorCode\"T\n" +
 	"\x11CreateTaskRequest\x12\x15\n" +
 	"\x06vds_id\x18\x01 \x01(\x05R\x05vdsId\x12(\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x14.management.TaskTypeR\x04type\" \n" +
 	"\x0eGetTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\".\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\"\n" +
+	"\x10WatchTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\xf7\x02\n" +
 	"\x15ListTasksByVDSRequest\x12\x15\n" +
-	"\x06vds_id\x18\x01 \x01(\x05R\x05vdsId\";\n" +
+	"\x06vds_id\x18\x01 \x01(\x05R\x05vdsId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x123\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x16.management.TaskStatusH\x00R\x06status\x88\x01\x01\x12-\n" +
+	"\x04type\x18\x06 \x01(\x0e2\x14.management.TaskTypeH\x01R\x04type\x88\x01\x01\x12=\n" +
+	"\fcreated_from\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedToB\t\n" +
+	"\a_statusB\a\n" +
+	"\x05_type\"c\n" +
 	"\x11ListTasksResponse\x12&\n" +
-	"\x05tasks\x18\x01 \x03(\v2\x10.management.TaskR\x05tasks\"~\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x10.management.TaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"~\n" +
 	"\x17UpdateTaskStatusRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12.\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x16.management.TaskStatusR\x06status\x12\x19\n" +
@@ -601,14 +744,15 @@ const file_management_task_proto_rawDesc = "" +
 	"\x1bGetPendingTasksCountRequest\x12\x15\n" +
 	"\x06vds_id\x18\x01 \x01(\x05R\x05vdsId\"4\n" +
 	"\x1cGetPendingTasksCountResponse\x12\x14\n" +
-	"\x05count\x18\x01 \x01(\x05R\x05count*\x8d\x01\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count*\xa5\x01\n" +
 	"\bTaskType\x12\x15\n" +
 	"\x11TASK_TYPE_UNKNOWN\x10\x00\x12\x14\n" +
 	"\x10TASK_TYPE_CREATE\x10\x01\x12\x14\n" +
 	"\x10TASK_TYPE_DELETE\x10\x02\x12\x13\n" +
 	"\x0fTASK_TYPE_START\x10\x03\x12\x12\n" +
 	"\x0eTASK_TYPE_STOP\x10\x04\x12\x15\n" +
-	"\x11TASK_TYPE_RESTART\x10\x05*\x84\x01\n" +
+	"\x11TASK_TYPE_RESTART\x10\x05\x12\x16\n" +
+	"\x12TASK_TYPE_POWEROFF\x10\x06*\x84\x01\n" +
 	"\n" +
 	"TaskStatus\x12\x17\n" +
 	"\x13TASK_STATUS_UNKNOWN\x10\x00\x12\x17\n" +
@@ -630,34 +774,41 @@ func file_management_task_proto_rawDescGZIP() []byte {
 }
 
 var file_management_task_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_management_task_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_management_task_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_management_task_proto_goTypes = []any{
 	(TaskType)(0),                        // 0: management.TaskType
 	(TaskStatus)(0),                      // 1: management.TaskStatus
 	(*Task)(nil),                         // 2: management.Task
 	(*CreateTaskRequest)(nil),            // 3: management.CreateTaskRequest
 	(*GetTaskRequest)(nil),               // 4: management.GetTaskRequest
-	(*ListTasksByVDSRequest)(nil),        // 5: management.ListTasksByVDSRequest
-	(*ListTasksResponse)(nil),            // 6: management.ListTasksResponse
-	(*UpdateTaskStatusRequest)(nil),      // 7: management.UpdateTaskStatusRequest
-	(*GetPendingTasksCountRequest)(nil),  // 8: management.GetPendingTasksCountRequest
-	(*GetPendingTasksCountResponse)(nil), // 9: management.GetPendingTasksCountResponse
-	(*timestamppb.Timestamp)(nil),        // 10: google.protobuf.Timestamp
+	(*WatchTaskRequest)(nil),             // 5: management.WatchTaskRequest
+	(*ListTasksByVDSRequest)(nil),        // 6: management.ListTasksByVDSRequest
+	(*ListTasksResponse)(nil),            // 7: management.ListTasksResponse
+	(*UpdateTaskStatusRequest)(nil),      // 8: management.UpdateTaskStatusRequest
+	(*GetPendingTasksCountRequest)(nil),  // 9: management.GetPendingTasksCountRequest
+	(*GetPendingTasksCountResponse)(nil), // 10: management.GetPendingTasksCountResponse
+	(*timestamppb.Timestamp)(nil),        // 11: google.protobuf.Timestamp
+	(ErrorCode)(0),                       // 12: management.ErrorCode
 }
 var file_management_task_proto_depIdxs = []int32{
 	0,  // 0: management.Task.type:type_name -> management.TaskType
 	1,  // 1: management.Task.status:type_name -> management.TaskStatus
-	10, // 2: management.Task.created_at:type_name -> google.protobuf.Timestamp
-	10, // 3: management.Task.started_at:type_name -> google.protobuf.Timestamp
-	10, // 4: management.Task.completed_at:type_name -> google.protobuf.Timestamp
-	0,  // 5: management.CreateTaskRequest.type:type_name -> management.TaskType
-	2,  // 6: management.ListTasksResponse.tasks:type_name -> management.Task
-	1,  // 7: management.UpdateTaskStatusRequest.status:type_name -> management.TaskStatus
-	8,  // [8:8] is the sub-list for method output_type
-	8,  // [8:8] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	11, // 2: management.Task.created_at:type_name -> google.protobuf.Timestamp
+	11, // 3: management.Task.started_at:type_name -> google.protobuf.Timestamp
+	11, // 4: management.Task.completed_at:type_name -> google.protobuf.Timestamp
+	12, // 5: management.Task.error_code:type_name -> management.ErrorCode
+	0,  // 6: management.CreateTaskRequest.type:type_name -> management.TaskType
+	1,  // 7: management.ListTasksByVDSRequest.status:type_name -> management.TaskStatus
+	0,  // 8: management.ListTasksByVDSRequest.type:type_name -> management.TaskType
+	11, // 9: management.ListTasksByVDSRequest.created_from:type_name -> google.protobuf.Timestamp
+	11, // 10: management.ListTasksByVDSRequest.created_to:type_name -> google.protobuf.Timestamp
+	2,  // 11: management.ListTasksResponse.tasks:type_name -> management.Task
+	1,  // 12: management.UpdateTaskStatusRequest.status:type_name -> management.TaskStatus
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_management_task_proto_init() }
@@ -665,14 +816,16 @@ func file_management_task_proto_init() {
 	if File_management_task_proto != nil {
 		return
 	}
-	file_management_task_proto_msgTypes[5].OneofWrappers = []any{}
+	file_management_errors_proto_init()
+	file_management_task_proto_msgTypes[4].OneofWrappers = []any{}
+	file_management_task_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_management_task_proto_rawDesc), len(file_management_task_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   8,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
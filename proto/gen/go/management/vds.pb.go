@@ -9,6 +9,7 @@ package managementv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -31,6 +32,7 @@ const (
 	VDSStatus_VDS_STATUS_STOPPED  VDSStatus = 3
 	VDSStatus_VDS_STATUS_ERROR    VDSStatus = 4
 	VDSStatus_VDS_STATUS_DELETING VDSStatus = 5
+	VDSStatus_VDS_STATUS_DELETED  VDSStatus = 6
 )
 
 // Enum value maps for VDSStatus.
@@ -42,6 +44,7 @@ var (
 		3: "VDS_STATUS_STOPPED",
 		4: "VDS_STATUS_ERROR",
 		5: "VDS_STATUS_DELETING",
+		6: "VDS_STATUS_DELETED",
 	}
 	VDSStatus_value = map[string]int32{
 		"VDS_STATUS_UNKNOWN":  0,
@@ -50,6 +53,7 @@ var (
 		"VDS_STATUS_STOPPED":  3,
 		"VDS_STATUS_ERROR":    4,
 		"VDS_STATUS_DELETING": 5,
+		"VDS_STATUS_DELETED":  6,
 	}
 )
 
@@ -80,6 +84,118 @@ func (VDSStatus) EnumDescriptor() ([]byte, []int) {
 	return file_management_vds_proto_rawDescGZIP(), []int{0}
 }
 
+// Событие жизненного цикла подписки VDS
+type VDSEventType int32
+
+const (
+	VDSEventType_VDS_EVENT_TYPE_UNKNOWN         VDSEventType = 0
+	VDSEventType_VDS_EVENT_TYPE_RENEWED         VDSEventType = 1 // Подписка продлена, оплата списана
+	VDSEventType_VDS_EVENT_TYPE_RENEWAL_FAILED  VDSEventType = 2 // Продлить подписку не удалось
+	VDSEventType_VDS_EVENT_TYPE_SUSPENDED       VDSEventType = 3 // VDS остановлена за неоплату
+	VDSEventType_VDS_EVENT_TYPE_RESUMED         VDSEventType = 4 // VDS запущена после продления
+	VDSEventType_VDS_EVENT_TYPE_DELETION_QUEUED VDSEventType = 5 // VDS поставлена на удаление после срока хранения
+)
+
+// Enum value maps for VDSEventType.
+var (
+	VDSEventType_name = map[int32]string{
+		0: "VDS_EVENT_TYPE_UNKNOWN",
+		1: "VDS_EVENT_TYPE_RENEWED",
+		2: "VDS_EVENT_TYPE_RENEWAL_FAILED",
+		3: "VDS_EVENT_TYPE_SUSPENDED",
+		4: "VDS_EVENT_TYPE_RESUMED",
+		5: "VDS_EVENT_TYPE_DELETION_QUEUED",
+	}
+	VDSEventType_value = map[string]int32{
+		"VDS_EVENT_TYPE_UNKNOWN":         0,
+		"VDS_EVENT_TYPE_RENEWED":         1,
+		"VDS_EVENT_TYPE_RENEWAL_FAILED":  2,
+		"VDS_EVENT_TYPE_SUSPENDED":       3,
+		"VDS_EVENT_TYPE_RESUMED":         4,
+		"VDS_EVENT_TYPE_DELETION_QUEUED": 5,
+	}
+)
+
+func (x VDSEventType) Enum() *VDSEventType {
+	p := new(VDSEventType)
+	*p = x
+	return p
+}
+
+func (x VDSEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VDSEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_management_vds_proto_enumTypes[1].Descriptor()
+}
+
+func (VDSEventType) Type() protoreflect.EnumType {
+	return &file_management_vds_proto_enumTypes[1]
+}
+
+func (x VDSEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VDSEventType.Descriptor instead.
+func (VDSEventType) EnumDescriptor() ([]byte, []int) {
+	return file_management_vds_proto_rawDescGZIP(), []int{1}
+}
+
+// Состояние оплаты VDS (резервирование средств в SSO)
+type BillingStatus int32
+
+const (
+	BillingStatus_BILLING_STATUS_NONE      BillingStatus = 0 // VDS создана без оплаты
+	BillingStatus_BILLING_STATUS_RESERVED  BillingStatus = 1 // Средства заморожены до завершения создания
+	BillingStatus_BILLING_STATUS_COMMITTED BillingStatus = 2 // Средства списаны
+	BillingStatus_BILLING_STATUS_CANCELLED BillingStatus = 3 // Резервирование отменено, средства разморожены
+)
+
+// Enum value maps for BillingStatus.
+var (
+	BillingStatus_name = map[int32]string{
+		0: "BILLING_STATUS_NONE",
+		1: "BILLING_STATUS_RESERVED",
+		2: "BILLING_STATUS_COMMITTED",
+		3: "BILLING_STATUS_CANCELLED",
+	}
+	BillingStatus_value = map[string]int32{
+		"BILLING_STATUS_NONE":      0,
+		"BILLING_STATUS_RESERVED":  1,
+		"BILLING_STATUS_COMMITTED": 2,
+		"BILLING_STATUS_CANCELLED": 3,
+	}
+)
+
+func (x BillingStatus) Enum() *BillingStatus {
+	p := new(BillingStatus)
+	*p = x
+	return p
+}
+
+func (x BillingStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BillingStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_management_vds_proto_enumTypes[2].Descriptor()
+}
+
+func (BillingStatus) Type() protoreflect.EnumType {
+	return &file_management_vds_proto_enumTypes[2]
+}
+
+func (x BillingStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BillingStatus.Descriptor instead.
+func (BillingStatus) EnumDescriptor() ([]byte, []int) {
+	return file_management_vds_proto_rawDescGZIP(), []int{2}
+}
+
 type VDS struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -92,6 +208,11 @@ type VDS struct {
 	Ipv6          string                 `protobuf:"bytes,8,opt,name=ipv6,proto3" json:"ipv6,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ReservationId string                 `protobuf:"bytes,11,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	BillingAmount int64                  `protobuf:"varint,12,opt,name=billing_amount,json=billingAmount,proto3" json:"billing_amount,omitempty"` // Сумма оплаты в копейках
+	BillingStatus BillingStatus          `protobuf:"varint,13,opt,name=billing_status,json=billingStatus,proto3,enum=management.BillingStatus" json:"billing_status,omitempty"`
+	SuspendedAt   *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=suspended_at,json=suspendedAt,proto3" json:"suspended_at,omitempty"` // Остановлена за неоплату, не задано - не приостановлена
+	AutoRenew     bool                   `protobuf:"varint,15,opt,name=auto_renew,json=autoRenew,proto3" json:"auto_renew,omitempty"`      // Подписка продлевается автоматически с баланса владельца
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -196,6 +317,41 @@ func (x *VDS) GetExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *VDS) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *VDS) GetBillingAmount() int64 {
+	if x != nil {
+		return x.BillingAmount
+	}
+	return 0
+}
+
+func (x *VDS) GetBillingStatus() BillingStatus {
+	if x != nil {
+		return x.BillingStatus
+	}
+	return BillingStatus_BILLING_STATUS_NONE
+}
+
+func (x *VDS) GetSuspendedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SuspendedAt
+	}
+	return nil
+}
+
+func (x *VDS) GetAutoRenew() bool {
+	if x != nil {
+		return x.AutoRenew
+	}
+	return false
+}
+
 type VDSWithDetails struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -313,11 +469,21 @@ func (x *VDSWithDetails) GetNode() *Node {
 }
 
 type CreateVDSRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	PlanId        int32                  `protobuf:"varint,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
-	NodeId        int32                  `protobuf:"varint,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PlanId int32                  `protobuf:"varint,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	// Если не указан, нода выбирается автоматически по свободным ресурсам
+	NodeId *int32 `protobuf:"varint,3,opt,name=node_id,json=nodeId,proto3,oneof" json:"node_id,omitempty"`
+	// Только для бесплатных тарифов. Платная VDS оплачивается и создаётся на один месяц,
+	// а оплату резервирует сам владелец: user_id должен совпадать с вызывающим пользователем
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Ограничения автоматического выбора ноды. Игнорируются при явном node_id
+	Region     string            `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	NodeLabels map[string]string `protobuf:"bytes,6,rep,name=node_labels,json=nodeLabels,proto3" json:"node_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Клиентский ID запроса. Повтор с тем же request_id не списывает оплату повторно
+	// и возвращает уже созданную VDS. Для платного тарифа обязателен; если не задан,
+	// берётся заголовок idempotency-key
+	RequestId     string `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -367,8 +533,8 @@ func (x *CreateVDSRequest) GetPlanId() int32 {
 }
 
 func (x *CreateVDSRequest) GetNodeId() int32 {
-	if x != nil {
-		return x.NodeId
+	if x != nil && x.NodeId != nil {
+		return *x.NodeId
 	}
 	return 0
 }
@@ -380,6 +546,27 @@ func (x *CreateVDSRequest) GetExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *CreateVDSRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *CreateVDSRequest) GetNodeLabels() map[string]string {
+	if x != nil {
+		return x.NodeLabels
+	}
+	return nil
+}
+
+func (x *CreateVDSRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
 type GetVDSRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -424,16 +611,297 @@ func (x *GetVDSRequest) GetId() int32 {
 	return 0
 }
 
-type ListVDSByUserRequest struct {
+type WatchVDSRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchVDSRequest) Reset() {
+	*x = WatchVDSRequest{}
+	mi := &file_management_vds_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchVDSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchVDSRequest) ProtoMessage() {}
+
+func (x *WatchVDSRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_vds_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchVDSRequest.ProtoReflect.Descriptor instead.
+func (*WatchVDSRequest) Descriptor() ([]byte, []int) {
+	return file_management_vds_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WatchVDSRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RenewVDSRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewVDSRequest) Reset() {
+	*x = RenewVDSRequest{}
+	mi := &file_management_vds_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewVDSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewVDSRequest) ProtoMessage() {}
+
+func (x *RenewVDSRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_vds_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewVDSRequest.ProtoReflect.Descriptor instead.
+func (*RenewVDSRequest) Descriptor() ([]byte, []int) {
+	return file_management_vds_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RenewVDSRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type SetVDSAutoRenewRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Enabled bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Refresh token владельца VDS, обязателен при включении. Сервис обменивает его в SSO и хранит
+	// новый токен зашифрованным, поэтому передавайте токен отдельного входа, а не своей сессии
+	RefreshToken  string `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetVDSAutoRenewRequest) Reset() {
+	*x = SetVDSAutoRenewRequest{}
+	mi := &file_management_vds_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetVDSAutoRenewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVDSAutoRenewRequest) ProtoMessage() {}
+
+func (x *SetVDSAutoRenewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_vds_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVDSAutoRenewRequest.ProtoReflect.Descriptor instead.
+func (*SetVDSAutoRenewRequest) Descriptor() ([]byte, []int) {
+	return file_management_vds_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetVDSAutoRenewRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetVDSAutoRenewRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetVDSAutoRenewRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// Запрос StartVDS, StopVDS, RebootVDS и PowerOffVDS
+type VDSPowerRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Дождаться завершения задачи. Если задача не завершилась за wait_timeout,
+	// ответ содержит её текущее состояние
+	Wait bool `protobuf:"varint,2,opt,name=wait,proto3" json:"wait,omitempty"`
+	// Время ожидания при wait: 0 - 1 минута, не больше 10 минут
+	WaitTimeout   *durationpb.Duration `protobuf:"bytes,3,opt,name=wait_timeout,json=waitTimeout,proto3" json:"wait_timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VDSPowerRequest) Reset() {
+	*x = VDSPowerRequest{}
+	mi := &file_management_vds_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VDSPowerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VDSPowerRequest) ProtoMessage() {}
+
+func (x *VDSPowerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_vds_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VDSPowerRequest.ProtoReflect.Descriptor instead.
+func (*VDSPowerRequest) Descriptor() ([]byte, []int) {
+	return file_management_vds_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VDSPowerRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *VDSPowerRequest) GetWait() bool {
+	if x != nil {
+		return x.Wait
+	}
+	return false
+}
+
+func (x *VDSPowerRequest) GetWaitTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.WaitTimeout
+	}
+	return nil
+}
+
+type VDSPowerResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Поставленная задача. При wait - её состояние на момент ответа
+	Task          *Task `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Vds           *VDS  `protobuf:"bytes,2,opt,name=vds,proto3" json:"vds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VDSPowerResponse) Reset() {
+	*x = VDSPowerResponse{}
+	mi := &file_management_vds_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VDSPowerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VDSPowerResponse) ProtoMessage() {}
+
+func (x *VDSPowerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_vds_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VDSPowerResponse.ProtoReflect.Descriptor instead.
+func (*VDSPowerResponse) Descriptor() ([]byte, []int) {
+	return file_management_vds_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VDSPowerResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *VDSPowerResponse) GetVds() *VDS {
+	if x != nil {
+		return x.Vds
+	}
+	return nil
+}
+
+type ListVDSByUserRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Размер страницы: 0 - 50, не больше 500
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token из предыдущего ответа, пусто - первая страница.
+	// Токен действителен только с теми же фильтрами и order_by
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Сортировка "поле [asc|desc]", по умолчанию "id". Поля: id, status, created_at, expires_at
+	OrderBy string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Без фильтра по статусу удалённые VDS не возвращаются
+	Status *VDSStatus `protobuf:"varint,5,opt,name=status,proto3,enum=management.VDSStatus,oneof" json:"status,omitempty"`
+	NodeId *int32     `protobuf:"varint,6,opt,name=node_id,json=nodeId,proto3,oneof" json:"node_id,omitempty"`
+	PlanId *int32     `protobuf:"varint,7,opt,name=plan_id,json=planId,proto3,oneof" json:"plan_id,omitempty"`
+	// Время создания: from включительно, to не включительно
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListVDSByUserRequest) Reset() {
 	*x = ListVDSByUserRequest{}
-	mi := &file_management_vds_proto_msgTypes[4]
+	mi := &file_management_vds_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -445,7 +913,7 @@ func (x *ListVDSByUserRequest) String() string {
 func (*ListVDSByUserRequest) ProtoMessage() {}
 
 func (x *ListVDSByUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_management_vds_proto_msgTypes[4]
+	mi := &file_management_vds_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,7 +926,7 @@ func (x *ListVDSByUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListVDSByUserRequest.ProtoReflect.Descriptor instead.
 func (*ListVDSByUserRequest) Descriptor() ([]byte, []int) {
-	return file_management_vds_proto_rawDescGZIP(), []int{4}
+	return file_management_vds_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListVDSByUserRequest) GetUserId() int32 {
@@ -468,16 +936,74 @@ func (x *ListVDSByUserRequest) GetUserId() int32 {
 	return 0
 }
 
+func (x *ListVDSByUserRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListVDSByUserRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListVDSByUserRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListVDSByUserRequest) GetStatus() VDSStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return VDSStatus_VDS_STATUS_UNKNOWN
+}
+
+func (x *ListVDSByUserRequest) GetNodeId() int32 {
+	if x != nil && x.NodeId != nil {
+		return *x.NodeId
+	}
+	return 0
+}
+
+func (x *ListVDSByUserRequest) GetPlanId() int32 {
+	if x != nil && x.PlanId != nil {
+		return *x.PlanId
+	}
+	return 0
+}
+
+func (x *ListVDSByUserRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListVDSByUserRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
 type ListVDSResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Vds           []*VDS                 `protobuf:"bytes,1,rep,name=vds,proto3" json:"vds,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Vds   []*VDS                 `protobuf:"bytes,1,rep,name=vds,proto3" json:"vds,omitempty"`
+	// Токен следующей страницы, пусто - страница последняя
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListVDSResponse) Reset() {
 	*x = ListVDSResponse{}
-	mi := &file_management_vds_proto_msgTypes[5]
+	mi := &file_management_vds_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -489,7 +1015,7 @@ func (x *ListVDSResponse) String() string {
 func (*ListVDSResponse) ProtoMessage() {}
 
 func (x *ListVDSResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_management_vds_proto_msgTypes[5]
+	mi := &file_management_vds_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -502,7 +1028,7 @@ func (x *ListVDSResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListVDSResponse.ProtoReflect.Descriptor instead.
 func (*ListVDSResponse) Descriptor() ([]byte, []int) {
-	return file_management_vds_proto_rawDescGZIP(), []int{5}
+	return file_management_vds_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListVDSResponse) GetVds() []*VDS {
@@ -512,6 +1038,13 @@ func (x *ListVDSResponse) GetVds() []*VDS {
 	return nil
 }
 
+func (x *ListVDSResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type UpdateVDSStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -522,7 +1055,7 @@ type UpdateVDSStatusRequest struct {
 
 func (x *UpdateVDSStatusRequest) Reset() {
 	*x = UpdateVDSStatusRequest{}
-	mi := &file_management_vds_proto_msgTypes[6]
+	mi := &file_management_vds_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -534,7 +1067,7 @@ func (x *UpdateVDSStatusRequest) String() string {
 func (*UpdateVDSStatusRequest) ProtoMessage() {}
 
 func (x *UpdateVDSStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_management_vds_proto_msgTypes[6]
+	mi := &file_management_vds_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -547,7 +1080,7 @@ func (x *UpdateVDSStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateVDSStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateVDSStatusRequest) Descriptor() ([]byte, []int) {
-	return file_management_vds_proto_rawDescGZIP(), []int{6}
+	return file_management_vds_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateVDSStatusRequest) GetId() int32 {
@@ -575,7 +1108,7 @@ type AllocateIPRequest struct {
 
 func (x *AllocateIPRequest) Reset() {
 	*x = AllocateIPRequest{}
-	mi := &file_management_vds_proto_msgTypes[7]
+	mi := &file_management_vds_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -587,7 +1120,7 @@ func (x *AllocateIPRequest) String() string {
 func (*AllocateIPRequest) ProtoMessage() {}
 
 func (x *AllocateIPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_management_vds_proto_msgTypes[7]
+	mi := &file_management_vds_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -600,7 +1133,7 @@ func (x *AllocateIPRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AllocateIPRequest.ProtoReflect.Descriptor instead.
 func (*AllocateIPRequest) Descriptor() ([]byte, []int) {
-	return file_management_vds_proto_rawDescGZIP(), []int{7}
+	return file_management_vds_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *AllocateIPRequest) GetVdsId() int32 {
@@ -633,7 +1166,7 @@ type DeleteVDSRequest struct {
 
 func (x *DeleteVDSRequest) Reset() {
 	*x = DeleteVDSRequest{}
-	mi := &file_management_vds_proto_msgTypes[8]
+	mi := &file_management_vds_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -645,7 +1178,7 @@ func (x *DeleteVDSRequest) String() string {
 func (*DeleteVDSRequest) ProtoMessage() {}
 
 func (x *DeleteVDSRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_management_vds_proto_msgTypes[8]
+	mi := &file_management_vds_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -658,7 +1191,7 @@ func (x *DeleteVDSRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteVDSRequest.ProtoReflect.Descriptor instead.
 func (*DeleteVDSRequest) Descriptor() ([]byte, []int) {
-	return file_management_vds_proto_rawDescGZIP(), []int{8}
+	return file_management_vds_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DeleteVDSRequest) GetId() int32 {
@@ -668,12 +1201,247 @@ func (x *DeleteVDSRequest) GetId() int32 {
 	return 0
 }
 
+type VDSEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	VdsId         int32                  `protobuf:"varint,2,opt,name=vds_id,json=vdsId,proto3" json:"vds_id,omitempty"`
+	Type          VDSEventType           `protobuf:"varint,3,opt,name=type,proto3,enum=management.VDSEventType" json:"type,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Details       map[string]string      `protobuf:"bytes,5,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VDSEvent) Reset() {
+	*x = VDSEvent{}
+	mi := &file_management_vds_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VDSEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VDSEvent) ProtoMessage() {}
+
+func (x *VDSEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_management_vds_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VDSEvent.ProtoReflect.Descriptor instead.
+func (*VDSEvent) Descriptor() ([]byte, []int) {
+	return file_management_vds_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *VDSEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *VDSEvent) GetVdsId() int32 {
+	if x != nil {
+		return x.VdsId
+	}
+	return 0
+}
+
+func (x *VDSEvent) GetType() VDSEventType {
+	if x != nil {
+		return x.Type
+	}
+	return VDSEventType_VDS_EVENT_TYPE_UNKNOWN
+}
+
+func (x *VDSEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *VDSEvent) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *VDSEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListVDSEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID VDS
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Размер страницы: 0 - 50, не больше 500
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token из предыдущего ответа, пусто - первая страница.
+	// Токен действителен только с теми же фильтрами и order_by
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Сортировка "поле [asc|desc]", по умолчанию "id". Поля: id, created_at
+	OrderBy string        `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	Type    *VDSEventType `protobuf:"varint,5,opt,name=type,proto3,enum=management.VDSEventType,oneof" json:"type,omitempty"`
+	// Время создания: from включительно, to не включительно
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVDSEventsRequest) Reset() {
+	*x = ListVDSEventsRequest{}
+	mi := &file_management_vds_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVDSEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVDSEventsRequest) ProtoMessage() {}
+
+func (x *ListVDSEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_vds_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVDSEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListVDSEventsRequest) Descriptor() ([]byte, []int) {
+	return file_management_vds_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListVDSEventsRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ListVDSEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListVDSEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListVDSEventsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListVDSEventsRequest) GetType() VDSEventType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return VDSEventType_VDS_EVENT_TYPE_UNKNOWN
+}
+
+func (x *ListVDSEventsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListVDSEventsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+type ListVDSEventsResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Events []*VDSEvent            `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Токен следующей страницы, пусто - страница последняя
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVDSEventsResponse) Reset() {
+	*x = ListVDSEventsResponse{}
+	mi := &file_management_vds_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVDSEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVDSEventsResponse) ProtoMessage() {}
+
+func (x *ListVDSEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_vds_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVDSEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListVDSEventsResponse) Descriptor() ([]byte, []int) {
+	return file_management_vds_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListVDSEventsResponse) GetEvents() []*VDSEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListVDSEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_management_vds_proto protoreflect.FileDescriptor
 
 const file_management_vds_proto_rawDesc = "" +
 	"\n" +
 	"\x14management/vds.proto\x12\n" +
-	"management\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15management/plan.proto\x1a\x15management/node.proto\"\xd1\x02\n" +
+	"management\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15management/plan.proto\x1a\x15management/node.proto\x1a\x15management/task.proto\"\xbf\x04\n" +
 	"\x03VDS\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x17\n" +
@@ -687,7 +1455,13 @@ const file_management_vds_proto_rawDesc = "" +
 	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"expires_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xf6\x02\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12%\n" +
+	"\x0ereservation_id\x18\v \x01(\tR\rreservationId\x12%\n" +
+	"\x0ebilling_amount\x18\f \x01(\x03R\rbillingAmount\x12@\n" +
+	"\x0ebilling_status\x18\r \x01(\x0e2\x19.management.BillingStatusR\rbillingStatus\x12=\n" +
+	"\fsuspended_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\vsuspendedAt\x12\x1d\n" +
+	"\n" +
+	"auto_renew\x18\x0f \x01(\bR\tautoRenew\"\xf6\x02\n" +
 	"\x0eVDSWithDetails\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\"\n" +
@@ -701,19 +1475,60 @@ const file_management_vds_proto_rawDesc = "" +
 	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12$\n" +
 	"\x04plan\x18\t \x01(\v2\x10.management.PlanR\x04plan\x12$\n" +
 	"\x04node\x18\n" +
-	" \x01(\v2\x10.management.NodeR\x04node\"\x98\x01\n" +
+	" \x01(\v2\x10.management.NodeR\x04node\"\xee\x02\n" +
 	"\x10CreateVDSRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
-	"\aplan_id\x18\x02 \x01(\x05R\x06planId\x12\x17\n" +
-	"\anode_id\x18\x03 \x01(\x05R\x06nodeId\x129\n" +
+	"\aplan_id\x18\x02 \x01(\x05R\x06planId\x12\x1c\n" +
+	"\anode_id\x18\x03 \x01(\x05H\x00R\x06nodeId\x88\x01\x01\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12M\n" +
+	"\vnode_labels\x18\x06 \x03(\v2,.management.CreateVDSRequest.NodeLabelsEntryR\n" +
+	"nodeLabels\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tR\trequestId\x1a=\n" +
+	"\x0fNodeLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\n" +
 	"\n" +
-	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x1f\n" +
+	"\b_node_id\"\x1f\n" +
 	"\rGetVDSRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"/\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"!\n" +
+	"\x0fWatchVDSRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"!\n" +
+	"\x0fRenewVDSRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"g\n" +
+	"\x16SetVDSAutoRenewRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\"s\n" +
+	"\x0fVDSPowerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04wait\x18\x02 \x01(\bR\x04wait\x12<\n" +
+	"\fwait_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vwaitTimeout\"[\n" +
+	"\x10VDSPowerResponse\x12$\n" +
+	"\x04task\x18\x01 \x01(\v2\x10.management.TaskR\x04task\x12!\n" +
+	"\x03vds\x18\x02 \x01(\v2\x0f.management.VDSR\x03vds\"\x93\x03\n" +
 	"\x14ListVDSByUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x05R\x06userId\"4\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x122\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x15.management.VDSStatusH\x00R\x06status\x88\x01\x01\x12\x1c\n" +
+	"\anode_id\x18\x06 \x01(\x05H\x01R\x06nodeId\x88\x01\x01\x12\x1c\n" +
+	"\aplan_id\x18\a \x01(\x05H\x02R\x06planId\x88\x01\x01\x12=\n" +
+	"\fcreated_from\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedToB\t\n" +
+	"\a_statusB\n" +
+	"\n" +
+	"\b_node_idB\n" +
+	"\n" +
+	"\b_plan_id\"\\\n" +
 	"\x0fListVDSResponse\x12!\n" +
-	"\x03vds\x18\x01 \x03(\v2\x0f.management.VDSR\x03vds\"W\n" +
+	"\x03vds\x18\x01 \x03(\v2\x0f.management.VDSR\x03vds\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"W\n" +
 	"\x16UpdateVDSStatusRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12-\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x15.management.VDSStatusR\x06status\"R\n" +
@@ -722,14 +1537,52 @@ const file_management_vds_proto_rawDesc = "" +
 	"\x04ipv4\x18\x02 \x01(\tR\x04ipv4\x12\x12\n" +
 	"\x04ipv6\x18\x03 \x01(\tR\x04ipv6\"\"\n" +
 	"\x10DeleteVDSRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id*\x9b\x01\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\xad\x02\n" +
+	"\bVDSEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06vds_id\x18\x02 \x01(\x05R\x05vdsId\x12,\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x18.management.VDSEventTypeR\x04type\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12;\n" +
+	"\adetails\x18\x05 \x03(\v2!.management.VDSEvent.DetailsEntryR\adetails\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb3\x02\n" +
+	"\x14ListVDSEventsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x121\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x18.management.VDSEventTypeH\x00R\x04type\x88\x01\x01\x12=\n" +
+	"\fcreated_from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedToB\a\n" +
+	"\x05_type\"m\n" +
+	"\x15ListVDSEventsResponse\x12,\n" +
+	"\x06events\x18\x01 \x03(\v2\x14.management.VDSEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\xb3\x01\n" +
 	"\tVDSStatus\x12\x16\n" +
 	"\x12VDS_STATUS_UNKNOWN\x10\x00\x12\x17\n" +
 	"\x13VDS_STATUS_CREATING\x10\x01\x12\x16\n" +
 	"\x12VDS_STATUS_RUNNING\x10\x02\x12\x16\n" +
 	"\x12VDS_STATUS_STOPPED\x10\x03\x12\x14\n" +
 	"\x10VDS_STATUS_ERROR\x10\x04\x12\x17\n" +
-	"\x13VDS_STATUS_DELETING\x10\x05BCZAgithub.com/makhkets/management/pkg/api/management/v1;managementv1b\x06proto3"
+	"\x13VDS_STATUS_DELETING\x10\x05\x12\x16\n" +
+	"\x12VDS_STATUS_DELETED\x10\x06*\xc7\x01\n" +
+	"\fVDSEventType\x12\x1a\n" +
+	"\x16VDS_EVENT_TYPE_UNKNOWN\x10\x00\x12\x1a\n" +
+	"\x16VDS_EVENT_TYPE_RENEWED\x10\x01\x12!\n" +
+	"\x1dVDS_EVENT_TYPE_RENEWAL_FAILED\x10\x02\x12\x1c\n" +
+	"\x18VDS_EVENT_TYPE_SUSPENDED\x10\x03\x12\x1a\n" +
+	"\x16VDS_EVENT_TYPE_RESUMED\x10\x04\x12\"\n" +
+	"\x1eVDS_EVENT_TYPE_DELETION_QUEUED\x10\x05*\x81\x01\n" +
+	"\rBillingStatus\x12\x17\n" +
+	"\x13BILLING_STATUS_NONE\x10\x00\x12\x1b\n" +
+	"\x17BILLING_STATUS_RESERVED\x10\x01\x12\x1c\n" +
+	"\x18BILLING_STATUS_COMMITTED\x10\x02\x12\x1c\n" +
+	"\x18BILLING_STATUS_CANCELLED\x10\x03BCZAgithub.com/makhkets/management/pkg/api/management/v1;managementv1b\x06proto3"
 
 var (
 	file_management_vds_proto_rawDescOnce sync.Once
@@ -743,40 +1596,70 @@ func file_management_vds_proto_rawDescGZIP() []byte {
 	return file_management_vds_proto_rawDescData
 }
 
-var file_management_vds_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_management_vds_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_management_vds_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_management_vds_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_management_vds_proto_goTypes = []any{
 	(VDSStatus)(0),                 // 0: management.VDSStatus
-	(*VDS)(nil),                    // 1: management.VDS
-	(*VDSWithDetails)(nil),         // 2: management.VDSWithDetails
-	(*CreateVDSRequest)(nil),       // 3: management.CreateVDSRequest
-	(*GetVDSRequest)(nil),          // 4: management.GetVDSRequest
-	(*ListVDSByUserRequest)(nil),   // 5: management.ListVDSByUserRequest
-	(*ListVDSResponse)(nil),        // 6: management.ListVDSResponse
-	(*UpdateVDSStatusRequest)(nil), // 7: management.UpdateVDSStatusRequest
-	(*AllocateIPRequest)(nil),      // 8: management.AllocateIPRequest
-	(*DeleteVDSRequest)(nil),       // 9: management.DeleteVDSRequest
-	(*timestamppb.Timestamp)(nil),  // 10: google.protobuf.Timestamp
-	(*Plan)(nil),                   // 11: management.Plan
-	(*Node)(nil),                   // 12: management.Node
+	(VDSEventType)(0),              // 1: management.VDSEventType
+	(BillingStatus)(0),             // 2: management.BillingStatus
+	(*VDS)(nil),                    // 3: management.VDS
+	(*VDSWithDetails)(nil),         // 4: management.VDSWithDetails
+	(*CreateVDSRequest)(nil),       // 5: management.CreateVDSRequest
+	(*GetVDSRequest)(nil),          // 6: management.GetVDSRequest
+	(*WatchVDSRequest)(nil),        // 7: management.WatchVDSRequest
+	(*RenewVDSRequest)(nil),        // 8: management.RenewVDSRequest
+	(*SetVDSAutoRenewRequest)(nil), // 9: management.SetVDSAutoRenewRequest
+	(*VDSPowerRequest)(nil),        // 10: management.VDSPowerRequest
+	(*VDSPowerResponse)(nil),       // 11: management.VDSPowerResponse
+	(*ListVDSByUserRequest)(nil),   // 12: management.ListVDSByUserRequest
+	(*ListVDSResponse)(nil),        // 13: management.ListVDSResponse
+	(*UpdateVDSStatusRequest)(nil), // 14: management.UpdateVDSStatusRequest
+	(*AllocateIPRequest)(nil),      // 15: management.AllocateIPRequest
+	(*DeleteVDSRequest)(nil),       // 16: management.DeleteVDSRequest
+	(*VDSEvent)(nil),               // 17: management.VDSEvent
+	(*ListVDSEventsRequest)(nil),   // 18: management.ListVDSEventsRequest
+	(*ListVDSEventsResponse)(nil),  // 19: management.ListVDSEventsResponse
+	nil,                            // 20: management.CreateVDSRequest.NodeLabelsEntry
+	nil,                            // 21: management.VDSEvent.DetailsEntry
+	(*timestamppb.Timestamp)(nil),  // 22: google.protobuf.Timestamp
+	(*Plan)(nil),                   // 23: management.Plan
+	(*Node)(nil),                   // 24: management.Node
+	(*durationpb.Duration)(nil),    // 25: google.protobuf.Duration
+	(*Task)(nil),                   // 26: management.Task
 }
 var file_management_vds_proto_depIdxs = []int32{
 	0,  // 0: management.VDS.status:type_name -> management.VDSStatus
-	10, // 1: management.VDS.created_at:type_name -> google.protobuf.Timestamp
-	10, // 2: management.VDS.expires_at:type_name -> google.protobuf.Timestamp
-	0,  // 3: management.VDSWithDetails.status:type_name -> management.VDSStatus
-	10, // 4: management.VDSWithDetails.created_at:type_name -> google.protobuf.Timestamp
-	10, // 5: management.VDSWithDetails.expires_at:type_name -> google.protobuf.Timestamp
-	11, // 6: management.VDSWithDetails.plan:type_name -> management.Plan
-	12, // 7: management.VDSWithDetails.node:type_name -> management.Node
-	10, // 8: management.CreateVDSRequest.expires_at:type_name -> google.protobuf.Timestamp
-	1,  // 9: management.ListVDSResponse.vds:type_name -> management.VDS
-	0,  // 10: management.UpdateVDSStatusRequest.status:type_name -> management.VDSStatus
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	22, // 1: management.VDS.created_at:type_name -> google.protobuf.Timestamp
+	22, // 2: management.VDS.expires_at:type_name -> google.protobuf.Timestamp
+	2,  // 3: management.VDS.billing_status:type_name -> management.BillingStatus
+	22, // 4: management.VDS.suspended_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: management.VDSWithDetails.status:type_name -> management.VDSStatus
+	22, // 6: management.VDSWithDetails.created_at:type_name -> google.protobuf.Timestamp
+	22, // 7: management.VDSWithDetails.expires_at:type_name -> google.protobuf.Timestamp
+	23, // 8: management.VDSWithDetails.plan:type_name -> management.Plan
+	24, // 9: management.VDSWithDetails.node:type_name -> management.Node
+	22, // 10: management.CreateVDSRequest.expires_at:type_name -> google.protobuf.Timestamp
+	20, // 11: management.CreateVDSRequest.node_labels:type_name -> management.CreateVDSRequest.NodeLabelsEntry
+	25, // 12: management.VDSPowerRequest.wait_timeout:type_name -> google.protobuf.Duration
+	26, // 13: management.VDSPowerResponse.task:type_name -> management.Task
+	3,  // 14: management.VDSPowerResponse.vds:type_name -> management.VDS
+	0,  // 15: management.ListVDSByUserRequest.status:type_name -> management.VDSStatus
+	22, // 16: management.ListVDSByUserRequest.created_from:type_name -> google.protobuf.Timestamp
+	22, // 17: management.ListVDSByUserRequest.created_to:type_name -> google.protobuf.Timestamp
+	3,  // 18: management.ListVDSResponse.vds:type_name -> management.VDS
+	0,  // 19: management.UpdateVDSStatusRequest.status:type_name -> management.VDSStatus
+	1,  // 20: management.VDSEvent.type:type_name -> management.VDSEventType
+	21, // 21: management.VDSEvent.details:type_name -> management.VDSEvent.DetailsEntry
+	22, // 22: management.VDSEvent.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 23: management.ListVDSEventsRequest.type:type_name -> management.VDSEventType
+	22, // 24: management.ListVDSEventsRequest.created_from:type_name -> google.protobuf.Timestamp
+	22, // 25: management.ListVDSEventsRequest.created_to:type_name -> google.protobuf.Timestamp
+	17, // 26: management.ListVDSEventsResponse.events:type_name -> management.VDSEvent
+	27, // [27:27] is the sub-list for method output_type
+	27, // [27:27] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_management_vds_proto_init() }
@@ -786,13 +1669,17 @@ func file_management_vds_proto_init() {
 	}
 	file_management_plan_proto_init()
 	file_management_node_proto_init()
+	file_management_task_proto_init()
+	file_management_vds_proto_msgTypes[2].OneofWrappers = []any{}
+	file_management_vds_proto_msgTypes[9].OneofWrappers = []any{}
+	file_management_vds_proto_msgTypes[15].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_management_vds_proto_rawDesc), len(file_management_vds_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   9,
+			NumEnums:      3,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   0,
 		},